@@ -3,11 +3,19 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"time"
 
+	"github.com/olgasafonova/productplan-mcp-server/internal/analysis"
 	"github.com/olgasafonova/productplan-mcp-server/internal/api"
 	"github.com/olgasafonova/productplan-mcp-server/internal/cli"
+	"github.com/olgasafonova/productplan-mcp-server/internal/config"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+	"github.com/olgasafonova/productplan-mcp-server/internal/estimates"
+	"github.com/olgasafonova/productplan-mcp-server/internal/launchtemplate"
 	"github.com/olgasafonova/productplan-mcp-server/internal/logging"
 	"github.com/olgasafonova/productplan-mcp-server/internal/mcp"
 	"github.com/olgasafonova/productplan-mcp-server/internal/tools"
@@ -31,33 +39,152 @@ func isServerArg(arg string) bool {
 	return arg == "" || arg == "serve" || arg == "mcp"
 }
 
-// requireToken reads PRODUCTPLAN_API_TOKEN and reports a friendly error to stderr if missing.
-func requireToken() (string, bool) {
-	token := os.Getenv("PRODUCTPLAN_API_TOKEN")
-	if token == "" {
-		fmt.Fprintln(os.Stderr, "Error: PRODUCTPLAN_API_TOKEN environment variable is required")
-		return "", false
+// globalFlags are the options accepted before the command.
+type globalFlags struct {
+	profile       string
+	logPayloads   bool
+	logLevel      string
+	logFile       string
+	logMaxSize    int
+	logMaxAge     time.Duration
+	logMaxBackups int
+}
+
+// parseGlobalFlags reads the leading options and returns the remaining
+// arguments, starting at the command.
+func parseGlobalFlags(args []string) (globalFlags, []string, error) {
+	var g globalFlags
+	fs := flag.NewFlagSet("productplan", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&g.profile, "profile", "", "config file profile to use")
+	fs.BoolVar(&g.logPayloads, "log-payloads", false, "log redacted API request and response bodies")
+	fs.StringVar(&g.logLevel, "log-level", "", "minimum log level: debug, info, warn or error")
+	fs.StringVar(&g.logFile, "log-file", "", "write logs to this file instead of stderr")
+	fs.IntVar(&g.logMaxSize, "log-max-size", 10, "rotate the log file at this many megabytes")
+	fs.DurationVar(&g.logMaxAge, "log-max-age", 24*time.Hour, "rotate the log file at this age (0 for never)")
+	fs.IntVar(&g.logMaxBackups, "log-max-backups", logging.DefaultMaxBackups, "rotated log files to keep")
+	if err := fs.Parse(args); err != nil {
+		return g, nil, err
+	}
+	// ParseLevel falls back to info; an explicit flag must name a level.
+	if g.logLevel != "" && logging.ParseLevel(g.logLevel).String() != g.logLevel {
+		return g, nil, fmt.Errorf("unknown log level %q (debug, info, warn, error)", g.logLevel)
 	}
-	return token, true
+	if g.logMaxSize <= 0 || g.logMaxAge < 0 || g.logMaxBackups < 0 {
+		return g, nil, fmt.Errorf("log rotation limits must not be negative, and -log-max-size must be positive")
+	}
+	return g, fs.Args(), nil
 }
 
-func run() int {
-	args := os.Args[1:]
+// newLogger creates the logger the flags ask for: stderr, or a rotating
+// file. The closer, when not nil, closes the file.
+func newLogger(g globalFlags) (*logging.JSONLogger, io.Closer, error) {
+	level := logging.LevelInfo
+	switch {
+	case g.logLevel != "":
+		level = logging.ParseLevel(g.logLevel)
+	case g.logPayloads:
+		// Payloads are logged with each request's debug entries.
+		level = logging.LevelDebug
+	}
+	if g.logFile == "" {
+		return logging.New(level), nil, nil
+	}
+	backups := g.logMaxBackups
+	if backups == 0 {
+		backups = -1 // the flag's 0 means keep all
+	}
+	f, err := logging.OpenRotatingFile(g.logFile, logging.RotateOptions{
+		MaxSize:    int64(g.logMaxSize) << 20,
+		MaxAge:     g.logMaxAge,
+		MaxBackups: backups,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return logging.NewWithWriter(f, level), f, nil
+}
+
+// loadProfile reads the config file and selects the profile named by
+// --profile, $PRODUCTPLAN_PROFILE or the file's default.
+func loadProfile(name string) (*config.File, config.Profile, error) {
+	path, err := config.DefaultPath()
+	if err != nil {
+		if name == "" && os.Getenv(config.EnvProfile) == "" {
+			// No config directory and no profile asked for: run on
+			// environment variables alone.
+			return &config.File{}, config.Profile{}, nil
+		}
+		return nil, config.Profile{}, err
+	}
+	file, err := config.Load(path)
+	if err != nil {
+		return nil, config.Profile{}, err
+	}
+	profile, err := file.Profile(name)
+	return file, profile, err
+}
 
-	if len(args) > 0 && isHelpArg(args[0]) {
+// newClient creates an API client with the profile's token and settings.
+// The token is read again from the same source after a 401, so a rotated
+// token file or secret is picked up without a restart.
+func newClient(profile config.Profile, logger logging.Logger, logPayloads bool) (*api.Client, error) {
+	token, err := profile.Token(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	cfg := profile.Apply(api.DefaultConfig(token))
+	cfg.Logger = logger
+	cfg.LogPayloads = logPayloads
+	cfg.ReloadToken = profile.Token
+	client, err := api.New(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API client: %w", err)
+	}
+	return client, nil
+}
+
+func run() int {
+	if len(os.Args) > 1 && isHelpArg(os.Args[1]) {
 		printHelp()
 		return 0
 	}
+	global, args, err := parseGlobalFlags(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	file, profile, err := loadProfile(global.profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	logger, logFile, err := newLogger(global)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if logFile != nil {
+		defer func() { _ = logFile.Close() }()
+	}
+	redactor, err := file.Redactor()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	logger.SetRedactor(redactor)
 
-	apiToken, ok := requireToken()
-	if !ok {
+	client, err := newClient(profile, logger, global.logPayloads)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return 1
 	}
 
-	logger := logging.New(logging.LevelInfo)
-	client, err := api.New(api.Config{Token: apiToken, Logger: logger})
+	calendar, err := profile.FiscalCalendar()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: failed to create API client: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return 1
 	}
 
@@ -66,17 +193,64 @@ func run() int {
 		first = args[0]
 	}
 	if isServerArg(first) {
-		return runMCPServer(client, logger)
+		var workspaces []config.Profile
+		if workspaces, err = file.Workspaces(profile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return runMCPServer(client, logger, calendar, profile, workspaces, global.logPayloads)
 	}
-	return runCLI(client, args)
+	return runCLI(client, args, calendar)
 }
 
-func runMCPServer(client *api.Client, logger logging.Logger) int {
+func runMCPServer(client *api.Client, logger logging.Logger, calendar dates.Calendar, profile config.Profile, workspaceProfiles []config.Profile, logPayloads bool) int {
+	filter := tools.ToolFilter{
+		Include:  profile.Tools.Include,
+		Exclude:  profile.Tools.Exclude,
+		ReadOnly: profile.Tools.ReadOnly,
+	}
+	if err := filter.Check(tools.BuildAllTools()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: profile %q: %v\n", profile.Name, err)
+		return 1
+	}
+	if profile.Name != "" {
+		logger.Info("using config profile", logging.F("profile", profile.Name))
+	}
+
+	// Each extra workspace gets its own client, and so its own token and
+	// rate limiter.
+	workspaces := make([]tools.Workspace, 0, len(workspaceProfiles))
+	for _, p := range workspaceProfiles {
+		wsClient, err := newClient(p, logger, logPayloads)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: workspace %q: %v\n", p.Name, err)
+			return 1
+		}
+		workspaces = append(workspaces, tools.Workspace{
+			Name:           p.Name,
+			Client:         wsClient,
+			HealthChecker:  newHealthChecker(wsClient, version),
+			DefaultRoadmap: p.DefaultRoadmap,
+			Estimates:      estimatesStore(logger, p.Name),
+		})
+	}
+	if len(workspaces) > 0 {
+		logger.Info("serving workspaces", logging.Count(len(workspaces)+1))
+	}
+
 	// Create MCP registry and register tools
 	registry := mcp.NewRegistry()
 	tools.RegisterAll(registry, tools.Config{
-		Client:        client,
-		HealthChecker: newHealthChecker(client, version),
+		Client:          client,
+		HealthChecker:   newHealthChecker(client, version),
+		OKRLinks:        okrLinkConventions(profile),
+		Estimates:       estimatesStore(logger, ""),
+		LaunchTemplates: launchTemplates(logger),
+		Calendar:        calendar,
+		Filter:          filter,
+		DefaultRoadmap:  profile.DefaultRoadmap,
+		Workspace:       profile.Name,
+		Workspaces:      workspaces,
 	})
 
 	// Create and run MCP server
@@ -92,9 +266,54 @@ func runMCPServer(client *api.Client, logger logging.Logger) int {
 	return 0
 }
 
-func runCLI(client *api.Client, args []string) int {
+// estimatesStore opens the local estimates file used by
+// score_opportunities, with a separate file for each extra workspace. When
+// no config directory can be found, estimates last for the session only.
+func estimatesStore(logger logging.Logger, workspace string) *estimates.Store {
+	path, err := estimates.DefaultPath()
+	if err != nil {
+		logger.Warn("estimates will not persist", logging.Error(err))
+		return estimates.Open("")
+	}
+	return estimates.Open(estimates.WorkspacePath(path, workspace))
+}
+
+// launchTemplates opens the launch checklist template directory. When no
+// config directory can be found, the template tools report it on use.
+func launchTemplates(logger logging.Logger) *launchtemplate.Library {
+	dir, err := launchtemplate.DefaultDir()
+	if err != nil {
+		logger.Warn("launch templates unavailable", logging.Error(err))
+		return nil
+	}
+	return launchtemplate.OpenLibrary(dir)
+}
+
+// okrLinkConventions reads the bar-to-objective link conventions from the
+// profile's okr section, with PRODUCTPLAN_OKR_TAG_PREFIX and
+// PRODUCTPLAN_OKR_FIELD overriding it. A value set to an empty string
+// turns that convention off.
+func okrLinkConventions(profile config.Profile) analysis.LinkConventions {
+	links := analysis.DefaultLinkConventions()
+	if v := profile.OKR.TagPrefix; v != nil {
+		links.TagPrefix = *v
+	}
+	if v := profile.OKR.Field; v != nil {
+		links.CustomField = *v
+	}
+	if v, ok := os.LookupEnv("PRODUCTPLAN_OKR_TAG_PREFIX"); ok {
+		links.TagPrefix = v
+	}
+	if v, ok := os.LookupEnv("PRODUCTPLAN_OKR_FIELD"); ok {
+		links.CustomField = v
+	}
+	return links
+}
+
+func runCLI(client *api.Client, args []string, calendar dates.Calendar) int {
 	c := cli.New(client, cli.Config{
-		Version: version,
+		Version:  version,
+		Calendar: calendar,
 	})
 	return c.Run(args)
 }
@@ -103,8 +322,21 @@ func printHelp() {
 	fmt.Printf(`ProductPlan MCP Server v%s
 
 Usage:
-  productplan [serve|mcp]              Start MCP server (default)
-  productplan <command> [args]         Run CLI command
+  productplan [options] [serve|mcp]        Start MCP server (default)
+  productplan [options] <command> [args]   Run CLI command
+
+Options:
+  --profile NAME   Use a profile from the config file (default: $PRODUCTPLAN_PROFILE,
+                   then the file's default_profile). The file is $PRODUCTPLAN_CONFIG or
+                   productplan-mcp/config.yaml in the user config directory.
+  --log-payloads   Log API request and response bodies at debug level, after
+                   redacting tokens, emails and the config file's redact rules.
+  --log-level L    Minimum log level: debug, info (default), warn or error. MCP clients
+                   can change it live with logging/setLevel and receive logs inline.
+  --log-file PATH  Write logs to PATH instead of stderr, rotating it by size and age:
+    --log-max-size MB     rotate at this size (default 10)
+    --log-max-age D       rotate at this age, e.g. 24h (default 24h, 0 for never)
+    --log-max-backups N   rotated files to keep (default 5, 0 for all)
 
 For CLI commands, run: productplan help
 `, version)
@@ -1,3 +1,5 @@
 module github.com/olgasafonova/productplan-mcp-server
 
 go 1.25
+
+require gopkg.in/yaml.v3 v3.0.1
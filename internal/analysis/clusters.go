@@ -0,0 +1,342 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+)
+
+// DefaultClusterThreshold is the cosine similarity from which two ideas are
+// treated as near-duplicates.
+const DefaultClusterThreshold = 0.5
+
+// clusterKeywords is how many top terms describe a cluster.
+const clusterKeywords = 5
+
+// ClusterOptions tunes ClusterIdeas.
+type ClusterOptions struct {
+	// Threshold is the minimum cosine similarity, in (0, 1], that links two
+	// ideas. Zero means DefaultClusterThreshold.
+	Threshold float64
+	// MinSize drops clusters with fewer ideas. Values below 2 mean 2.
+	MinSize int
+	// IncludeClosed keeps ideas with a closed status (done, rejected, ...).
+	IncludeClosed bool
+}
+
+// ClusterIdea is an idea within a cluster.
+type ClusterIdea struct {
+	ID                 api.ID `json:"id"`
+	Name               string `json:"name"`
+	Status             string `json:"status,omitempty"`
+	CustomerCount      int    `json:"customer_count"`
+	OpportunitiesCount int    `json:"opportunities_count"`
+}
+
+// MergeCandidate is an idea suggested for merging into the cluster's keeper.
+type MergeCandidate struct {
+	ClusterIdea
+	// Similarity is the cosine similarity to the keeper, 0..1.
+	Similarity float64 `json:"similarity"`
+}
+
+// IdeaCluster is a group of ideas with similar titles and descriptions.
+type IdeaCluster struct {
+	Keywords []string `json:"keywords"`
+	Size     int      `json:"size"`
+	// CustomerCount counts distinct customers across the cluster.
+	CustomerCount int      `json:"customer_count"`
+	Customers     []string `json:"customers"`
+	Tags          []string `json:"tags"`
+	// Keep is the suggested idea to merge the others into: the one with
+	// the most customers, then the most opportunities.
+	Keep  ClusterIdea      `json:"keep"`
+	Merge []MergeCandidate `json:"merge"`
+}
+
+// IdeaClusterReport is the result of ClusterIdeas.
+type IdeaClusterReport struct {
+	Ideas     int           `json:"ideas"`
+	Threshold float64       `json:"threshold"`
+	Clustered int           `json:"clustered"`
+	Clusters  []IdeaCluster `json:"clusters"`
+}
+
+// ClusterIdeas fetches every idea and groups near-duplicates.
+func ClusterIdeas(ctx context.Context, client *api.Client, opts ClusterOptions) (*IdeaClusterReport, error) {
+	ideas, err := client.FetchIdeas(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ideas: %w", err)
+	}
+	return GroupIdeas(ideas, opts), nil
+}
+
+// GroupIdeas clusters ideas by TF-IDF cosine similarity of their name and
+// description. Ideas are linked when their similarity reaches the
+// threshold, and clusters are the connected groups of linked ideas, so a
+// chain of close pairs can join ideas that are less alike end to end; the
+// merge list shows each idea's similarity to the keeper to make that
+// visible. Clusters with the most customers come first.
+func GroupIdeas(ideas []api.Idea, opts ClusterOptions) *IdeaClusterReport {
+	threshold := opts.Threshold
+	if threshold <= 0 {
+		threshold = DefaultClusterThreshold
+	}
+	minSize := max(opts.MinSize, 2)
+
+	var kept []api.Idea
+	for _, i := range ideas {
+		if opts.IncludeClosed || !isClosed(i.Status) {
+			kept = append(kept, i)
+		}
+	}
+	docs := make([][]string, len(kept))
+	for n, i := range kept {
+		// The name counts twice: titles say what the idea is, descriptions
+		// wander.
+		docs[n] = append(append(tokenize(i.Name), tokenize(i.Name)...), tokenize(i.Description)...)
+	}
+	vectors := tfidf(docs)
+
+	uf := newUnionFind(len(kept))
+	for a, sims := range pairSimilarities(vectors) {
+		for b, sim := range sims {
+			if sim >= threshold {
+				uf.union(a, b)
+			}
+		}
+	}
+	members := make(map[int][]int)
+	for n := range kept {
+		root := uf.find(n)
+		members[root] = append(members[root], n)
+	}
+
+	report := &IdeaClusterReport{Ideas: len(kept), Threshold: threshold, Clusters: []IdeaCluster{}}
+	for _, idx := range members {
+		if len(idx) < minSize {
+			continue
+		}
+		report.Clustered += len(idx)
+		report.Clusters = append(report.Clusters, buildCluster(kept, vectors, idx))
+	}
+	sort.Slice(report.Clusters, func(i, j int) bool {
+		a, b := report.Clusters[i], report.Clusters[j]
+		if a.CustomerCount != b.CustomerCount {
+			return a.CustomerCount > b.CustomerCount
+		}
+		if a.Size != b.Size {
+			return a.Size > b.Size
+		}
+		return a.Keep.ID < b.Keep.ID
+	})
+	return report
+}
+
+func buildCluster(ideas []api.Idea, vectors []map[string]float64, idx []int) IdeaCluster {
+	sort.SliceStable(idx, func(i, j int) bool {
+		a, b := ideas[idx[i]], ideas[idx[j]]
+		if len(a.Customers) != len(b.Customers) {
+			return len(a.Customers) > len(b.Customers)
+		}
+		if a.OpportunitiesCount != b.OpportunitiesCount {
+			return a.OpportunitiesCount > b.OpportunitiesCount
+		}
+		return idx[i] < idx[j]
+	})
+	keep := idx[0]
+	c := IdeaCluster{Size: len(idx), Keep: clusterIdea(ideas[keep]), Merge: []MergeCandidate{}}
+	for _, n := range idx[1:] {
+		c.Merge = append(c.Merge, MergeCandidate{
+			ClusterIdea: clusterIdea(ideas[n]),
+			Similarity:  math.Round(cosine(vectors[keep], vectors[n])*100) / 100,
+		})
+	}
+	sort.SliceStable(c.Merge, func(i, j int) bool { return c.Merge[i].Similarity > c.Merge[j].Similarity })
+
+	weights := make(map[string]float64)
+	var customers, tags []string
+	for _, n := range idx {
+		for term, w := range vectors[n] {
+			weights[term] += w
+		}
+		customers = append(customers, ideas[n].Customers...)
+		tags = append(tags, ideas[n].Tags...)
+	}
+	c.Customers = distinctFold(customers)
+	c.CustomerCount = len(c.Customers)
+	c.Tags = distinctFold(tags)
+	c.Keywords = topTerms(weights, clusterKeywords)
+	return c
+}
+
+func clusterIdea(i api.Idea) ClusterIdea {
+	return ClusterIdea{ID: i.ID, Name: i.Name, Status: i.Status, CustomerCount: len(i.Customers), OpportunitiesCount: i.OpportunitiesCount}
+}
+
+// distinctFold returns values without case-insensitive duplicates, keeping
+// the first spelling, sorted case-insensitively.
+func distinctFold(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := []string{}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		key := strings.ToLower(v)
+		if v == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, v)
+	}
+	sort.Slice(out, func(i, j int) bool { return strings.ToLower(out[i]) < strings.ToLower(out[j]) })
+	return out
+}
+
+// topTerms returns the n highest-weighted terms, ties broken alphabetically.
+func topTerms(weights map[string]float64, n int) []string {
+	terms := make([]string, 0, len(weights))
+	for t := range weights {
+		terms = append(terms, t)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if weights[terms[i]] != weights[terms[j]] {
+			return weights[terms[i]] > weights[terms[j]]
+		}
+		return terms[i] < terms[j]
+	})
+	return terms[:min(n, len(terms))]
+}
+
+// stopWords are common words that say nothing about what an idea is.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "but": true,
+	"by": true, "can": true, "could": true, "do": true, "for": true, "from": true, "has": true,
+	"have": true, "how": true, "i": true, "if": true, "in": true, "into": true, "is": true, "it": true,
+	"its": true, "let": true, "me": true, "more": true, "my": true, "need": true, "not": true, "of": true,
+	"on": true, "or": true, "our": true, "please": true, "should": true, "so": true, "some": true,
+	"than": true, "that": true, "the": true, "their": true, "them": true, "then": true, "there": true,
+	"these": true, "they": true, "this": true, "to": true, "us": true, "use": true, "want": true,
+	"was": true, "we": true, "when": true, "which": true, "while": true, "will": true, "with": true,
+	"would": true, "you": true, "your": true, "able": true, "like": true, "also": true, "all": true,
+}
+
+// tokenize lowercases text, splits it on anything but letters and digits,
+// drops stop words and one-letter tokens, and strips a plural "s".
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	out := fields[:0]
+	for _, f := range fields {
+		if len([]rune(f)) < 2 || stopWords[f] {
+			continue
+		}
+		if len(f) > 3 && strings.HasSuffix(f, "s") && !strings.HasSuffix(f, "ss") {
+			f = strings.TrimSuffix(f, "s")
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// tfidf turns token lists into L2-normalised TF-IDF vectors, using a
+// smoothed IDF so terms found in every document still count a little.
+func tfidf(docs [][]string) []map[string]float64 {
+	df := make(map[string]int)
+	for _, doc := range docs {
+		seen := make(map[string]bool, len(doc))
+		for _, t := range doc {
+			if !seen[t] {
+				seen[t] = true
+				df[t]++
+			}
+		}
+	}
+	n := float64(len(docs))
+	vectors := make([]map[string]float64, len(docs))
+	for i, doc := range docs {
+		v := make(map[string]float64, len(doc))
+		for _, t := range doc {
+			v[t]++
+		}
+		var norm float64
+		for t, tf := range v {
+			w := tf * (math.Log((1+n)/(1+float64(df[t]))) + 1)
+			v[t] = w
+			norm += w * w
+		}
+		if norm > 0 {
+			norm = math.Sqrt(norm)
+			for t := range v {
+				v[t] /= norm
+			}
+		}
+		vectors[i] = v
+	}
+	return vectors
+}
+
+// pairSimilarities returns the cosine similarity of every pair of vectors
+// that share a term, keyed [a][b] with a < b. An inverted index keeps this
+// well under all-pairs cost for short texts.
+func pairSimilarities(vectors []map[string]float64) map[int]map[int]float64 {
+	postings := make(map[string][]int)
+	for i, v := range vectors {
+		for t := range v {
+			postings[t] = append(postings[t], i)
+		}
+	}
+	sims := make(map[int]map[int]float64)
+	for t, docs := range postings {
+		for x, a := range docs {
+			for _, b := range docs[x+1:] {
+				if sims[a] == nil {
+					sims[a] = make(map[int]float64)
+				}
+				sims[a][b] += vectors[a][t] * vectors[b][t]
+			}
+		}
+	}
+	return sims
+}
+
+func cosine(a, b map[string]float64) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	var dot float64
+	for t, w := range a {
+		dot += w * b[t]
+	}
+	return dot
+}
+
+// unionFind is a disjoint-set forest over 0..n-1.
+type unionFind []int
+
+func newUnionFind(n int) unionFind {
+	uf := make(unionFind, n)
+	for i := range uf {
+		uf[i] = i
+	}
+	return uf
+}
+
+func (uf unionFind) find(i int) int {
+	for uf[i] != i {
+		uf[i] = uf[uf[i]]
+		i = uf[i]
+	}
+	return i
+}
+
+func (uf unionFind) union(a, b int) {
+	if ra, rb := uf.find(a), uf.find(b); ra != rb {
+		uf[rb] = ra
+	}
+}
@@ -0,0 +1,87 @@
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+)
+
+func TestGroupIdeas(t *testing.T) {
+	var ideas []api.Idea
+	if err := json.Unmarshal([]byte(`[
+		{"id": 1, "name": "Dark mode", "description": "Add a dark theme to the dashboard", "customers": ["Acme"], "tags": ["UI"]},
+		{"id": 2, "name": "Dark mode please", "description": "Our team wants a dark theme", "customers": [{"name": "Globex"}, {"name": "acme"}], "tags": ["ui", "Theme"], "opportunities_count": 1},
+		{"id": 3, "name": "Dashboard dark mode", "customers": ["Initech"]},
+		{"id": 4, "name": "Export to CSV", "description": "Export roadmap bars as CSV files"},
+		{"id": 5, "name": "CSV export", "description": "Download bars in CSV", "customers": ["Hooli"]},
+		{"id": 6, "name": "SSO with Okta", "description": "Single sign-on"},
+		{"id": 7, "name": "Dark mode", "status": "Rejected"}
+	]`), &ideas); err != nil {
+		t.Fatalf("decode ideas: %v", err)
+	}
+
+	report := GroupIdeas(ideas, ClusterOptions{Threshold: 0.4})
+
+	if report.Ideas != 6 || report.Clustered != 5 || len(report.Clusters) != 2 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	dark := report.Clusters[0]
+	if dark.Size != 3 || dark.Keep.ID != "2" || len(dark.Merge) != 2 {
+		t.Errorf("unexpected dark mode cluster %+v", dark)
+	}
+	if strings.Join(dark.Customers, ",") != "acme,Globex,Initech" || dark.CustomerCount != 3 {
+		t.Errorf("customers = %v (%d)", dark.Customers, dark.CustomerCount)
+	}
+	if strings.Join(dark.Tags, ",") != "Theme,ui" {
+		t.Errorf("tags = %v", dark.Tags)
+	}
+	if dark.Keywords[0] != "dark" && dark.Keywords[0] != "mode" {
+		t.Errorf("keywords = %v", dark.Keywords)
+	}
+	for _, m := range dark.Merge {
+		if m.Similarity <= 0 || m.Similarity > 1 {
+			t.Errorf("similarity out of range: %+v", m)
+		}
+	}
+	if dark.Merge[0].Similarity < dark.Merge[1].Similarity {
+		t.Error("merge candidates should be sorted by similarity")
+	}
+	csv := report.Clusters[1]
+	if csv.Keep.ID != "5" || csv.Merge[0].ID != "4" {
+		t.Errorf("unexpected csv cluster %+v", csv)
+	}
+
+	if r := GroupIdeas(ideas, ClusterOptions{Threshold: 0.4, MinSize: 3}); len(r.Clusters) != 1 {
+		t.Errorf("expected MinSize to drop the pair, got %d clusters", len(r.Clusters))
+	}
+	if r := GroupIdeas(ideas, ClusterOptions{Threshold: 0.4, IncludeClosed: true}); r.Clusters[0].Size != 4 {
+		t.Errorf("expected the rejected duplicate to join, got %+v", r.Clusters[0])
+	}
+	if r := GroupIdeas(ideas, ClusterOptions{Threshold: 1}); len(r.Clusters) != 0 {
+		t.Errorf("expected no clusters at threshold 1, got %+v", r.Clusters)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	got := strings.Join(tokenize("Please add CSV exports, e.g. for the Roadmaps & Bars!"), " ")
+	if got != "add csv export roadmap bar" {
+		t.Errorf("tokenize = %q", got)
+	}
+}
+
+func TestClusterIdeas(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/discovery/ideas": `{"results": [{"id": 1, "name": "Bulk edit bars"}, {"id": 2, "name": "Bulk edit for bars"}]}`,
+	})
+	report, err := ClusterIdeas(context.Background(), client, ClusterOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Clusters) != 1 || report.Clusters[0].Size != 2 || report.Threshold != DefaultClusterThreshold {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
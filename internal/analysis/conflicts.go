@@ -0,0 +1,357 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+)
+
+// DefaultMaxConcurrent is how many bars a lane may run at once before
+// DetectScheduleConflicts reports an overlap.
+const DefaultMaxConcurrent = 3
+
+// Load periods.
+const (
+	PeriodWeek    = "week"
+	PeriodMonth   = "month"
+	PeriodQuarter = "quarter"
+)
+
+// Date issues reported by DetectScheduleConflicts.
+const (
+	IssueEndBeforeStart  = "end_before_start"
+	IssueMissingDates    = "missing_dates"
+	IssueMissingStart    = "missing_start"
+	IssueMissingEnd      = "missing_end"
+	IssueUnparseableDate = "unparseable_date"
+	IssueParkedWithDates = "parked_with_dates"
+)
+
+// ConflictOptions tunes DetectScheduleConflicts.
+type ConflictOptions struct {
+	// MaxConcurrent is the number of overlapping bars a lane may carry.
+	// Zero means DefaultMaxConcurrent.
+	MaxConcurrent int
+	// Period buckets effort by PeriodWeek, PeriodMonth or PeriodQuarter.
+	// Empty means PeriodMonth.
+	Period string
+	// Capacity is the effort a lane can absorb per period. Zero disables
+	// the over_capacity flag.
+	Capacity float64
+	// Calendar places weeks and fiscal quarters.
+	Calendar dates.Calendar
+}
+
+// ScheduledBar identifies a bar with its dates.
+type ScheduledBar struct {
+	ID       api.ID `json:"id"`
+	Name     string `json:"name"`
+	StartsOn string `json:"starts_on"`
+	EndsOn   string `json:"ends_on"`
+}
+
+// Overlap is a stretch of time in which a lane runs more bars at once than
+// allowed.
+type Overlap struct {
+	StartsOn string `json:"starts_on"`
+	EndsOn   string `json:"ends_on"`
+	// Peak is the highest number of bars running on one day.
+	Peak int            `json:"peak"`
+	Bars []ScheduledBar `json:"bars"`
+}
+
+// LoadBucket is a lane's effort in one week, month or quarter. Effort is prorated
+// by the share of each bar's days that fall in the period.
+type LoadBucket struct {
+	Period       string  `json:"period"`
+	StartsOn     string  `json:"starts_on"`
+	Effort       float64 `json:"effort"`
+	Bars         int     `json:"bars"`
+	Unestimated  int     `json:"unestimated"`
+	OverCapacity bool    `json:"over_capacity,omitempty"`
+}
+
+// LaneSchedule is one lane's conflicts and load.
+type LaneSchedule struct {
+	LaneID         api.ID       `json:"lane_id"`
+	Lane           string       `json:"lane"`
+	BarCount       int          `json:"bar_count"`
+	PeakConcurrent int          `json:"peak_concurrent"`
+	Overlaps       []Overlap    `json:"overlaps"`
+	Load           []LoadBucket `json:"load"`
+}
+
+// DateIssue is a bar whose dates cannot be scheduled as entered.
+type DateIssue struct {
+	Bar   ScheduledBar `json:"bar"`
+	Lane  string       `json:"lane"`
+	Issue string       `json:"issue"`
+}
+
+// ConflictReport is the result of DetectScheduleConflicts.
+type ConflictReport struct {
+	RoadmapID     api.ID         `json:"roadmap_id"`
+	Roadmap       string         `json:"roadmap"`
+	MaxConcurrent int            `json:"max_concurrent"`
+	Period        string         `json:"period"`
+	Capacity      float64        `json:"capacity,omitempty"`
+	OverlapCount  int            `json:"overlap_count"`
+	Lanes         []LaneSchedule `json:"lanes"`
+	DateIssues    []DateIssue    `json:"date_issues"`
+}
+
+// DetectScheduleConflicts scans a roadmap's bars lane by lane for overlapping
+// work above opts.MaxConcurrent, sums effort per period, and lists bars with
+// unusable dates.
+func DetectScheduleConflicts(ctx context.Context, client *api.Client, roadmapID string, opts ConflictOptions) (*ConflictReport, error) {
+	roadmaps, err := fetchRoadmapBars(ctx, client, []string{roadmapID})
+	if err != nil {
+		return nil, err
+	}
+	lanes, err := client.FetchRoadmapLanes(ctx, roadmapID)
+	if err != nil {
+		return nil, fmt.Errorf("lanes: %w", err)
+	}
+	return ScheduleConflicts(roadmaps[0].Roadmap, lanes, roadmaps[0].Bars, opts), nil
+}
+
+// laneBar is a schedulable bar with parsed dates.
+type laneBar struct {
+	ref        ScheduledBar
+	start, end time.Time
+	effort     *float64
+}
+
+// ScheduleConflicts computes the report from already-fetched data.
+// Container bars span their children, so they are checked for date issues
+// but left out of overlaps and load. Parked bars are off the schedule.
+func ScheduleConflicts(roadmap api.Roadmap, lanes []api.Lane, bars []api.Bar, opts ConflictOptions) *ConflictReport {
+	if opts.MaxConcurrent <= 0 {
+		opts.MaxConcurrent = DefaultMaxConcurrent
+	}
+	if opts.Period == "" {
+		opts.Period = PeriodMonth
+	}
+	report := &ConflictReport{
+		RoadmapID:     roadmap.ID,
+		Roadmap:       roadmap.Name,
+		MaxConcurrent: opts.MaxConcurrent,
+		Period:        opts.Period,
+		Capacity:      opts.Capacity,
+		Lanes:         []LaneSchedule{},
+		DateIssues:    []DateIssue{},
+	}
+
+	laneNames := make(map[api.ID]string, len(lanes))
+	order := make([]api.ID, 0, len(lanes))
+	for _, l := range lanes {
+		laneNames[l.ID] = l.Name
+		order = append(order, l.ID)
+	}
+	byLane := make(map[api.ID][]laneBar)
+	for _, b := range bars {
+		if _, ok := laneNames[b.LaneID]; !ok {
+			laneNames[b.LaneID] = "(no lane)"
+			order = append(order, b.LaneID)
+		}
+		ref := ScheduledBar{ID: b.ID, Name: b.Name, StartsOn: b.StartsOn, EndsOn: b.EndsOn}
+		lb, issue := scheduleBar(b, ref)
+		if issue != "" {
+			report.DateIssues = append(report.DateIssues, DateIssue{Bar: ref, Lane: laneNames[b.LaneID], Issue: issue})
+		}
+		if lb != nil && !b.Container {
+			byLane[b.LaneID] = append(byLane[b.LaneID], *lb)
+		}
+	}
+
+	for _, id := range order {
+		lane := LaneSchedule{LaneID: id, Lane: laneNames[id], BarCount: len(byLane[id])}
+		lane.Overlaps, lane.PeakConcurrent = overlaps(byLane[id], opts.MaxConcurrent)
+		lane.Load = laneLoad(byLane[id], opts)
+		report.OverlapCount += len(lane.Overlaps)
+		report.Lanes = append(report.Lanes, lane)
+	}
+	return report
+}
+
+// scheduleBar parses a bar's dates. It returns the bar when it belongs on
+// the schedule, and the date issue, if any.
+func scheduleBar(b api.Bar, ref ScheduledBar) (*laneBar, string) {
+	hasStart, hasEnd := b.StartsOn != "", b.EndsOn != ""
+	if b.Parked {
+		if hasStart || hasEnd {
+			return nil, IssueParkedWithDates
+		}
+		return nil, ""
+	}
+	switch {
+	case !hasStart && !hasEnd:
+		return nil, IssueMissingDates
+	case !hasStart:
+		return nil, IssueMissingStart
+	case !hasEnd:
+		return nil, IssueMissingEnd
+	}
+	start, okStart := api.ParseDate(b.StartsOn)
+	end, okEnd := api.ParseDate(b.EndsOn)
+	switch {
+	case !okStart || !okEnd:
+		return nil, IssueUnparseableDate
+	case end.Before(start):
+		return nil, IssueEndBeforeStart
+	}
+	return &laneBar{ref: ref, start: start, end: end, effort: b.Effort}, ""
+}
+
+// overlaps sweeps a lane's bars by day and returns each stretch in which
+// more than limit bars run at once, plus the lane's peak concurrency.
+func overlaps(bars []laneBar, limit int) ([]Overlap, int) {
+	type event struct {
+		day   time.Time
+		delta int
+		bar   int
+	}
+	events := make([]event, 0, 2*len(bars))
+	for i, b := range bars {
+		events = append(events, event{b.start, 1, i}, event{b.end.AddDate(0, 0, 1), -1, i})
+	}
+	// Process ends before starts on the same day: a bar ending the day
+	// before another starts does not overlap it.
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].day.Equal(events[j].day) {
+			return events[i].day.Before(events[j].day)
+		}
+		return events[i].delta < events[j].delta
+	})
+
+	out := []Overlap{}
+	active := make(map[int]bool)
+	var current *Overlap
+	var members map[int]bool
+	peak := 0
+	for i, e := range events {
+		if e.delta > 0 {
+			active[e.bar] = true
+		} else {
+			delete(active, e.bar)
+		}
+		// Evaluate once all events of the day are applied.
+		if i+1 < len(events) && events[i+1].day.Equal(e.day) {
+			continue
+		}
+		n := len(active)
+		peak = max(peak, n)
+		switch {
+		case n > limit && current == nil:
+			current = &Overlap{StartsOn: e.day.Format(time.DateOnly)}
+			members = make(map[int]bool)
+			fallthrough
+		case n > limit:
+			current.Peak = max(current.Peak, n)
+			for b := range active {
+				members[b] = true
+			}
+		case current != nil:
+			current.EndsOn = e.day.AddDate(0, 0, -1).Format(time.DateOnly)
+			current.Bars = overlapBars(bars, members)
+			out = append(out, *current)
+			current = nil
+		}
+	}
+	return out, peak
+}
+
+// overlapBars lists the member bars in input order.
+func overlapBars(bars []laneBar, members map[int]bool) []ScheduledBar {
+	out := make([]ScheduledBar, 0, len(members))
+	for i, b := range bars {
+		if members[i] {
+			out = append(out, b.ref)
+		}
+	}
+	return out
+}
+
+// laneLoad spreads each bar's effort over the periods it spans, in
+// proportion to its days in each, and returns the periods in order.
+func laneLoad(bars []laneBar, opts ConflictOptions) []LoadBucket {
+	period, cal := opts.Period, opts.Calendar
+	buckets := make(map[time.Time]*LoadBucket)
+	for _, b := range bars {
+		total := float64(daysBetween(b.start, b.end) + 1)
+		for start := periodStart(b.start, period, cal); !start.After(b.end); start = nextPeriod(start, period) {
+			bucket, ok := buckets[start]
+			if !ok {
+				bucket = &LoadBucket{Period: periodLabel(start, period, cal), StartsOn: start.Format(time.DateOnly)}
+				buckets[start] = bucket
+			}
+			bucket.Bars++
+			if b.effort == nil {
+				bucket.Unestimated++
+				continue
+			}
+			from := maxTime(start, b.start)
+			to := minTime(nextPeriod(start, period).AddDate(0, 0, -1), b.end)
+			bucket.Effort += *b.effort * float64(daysBetween(from, to)+1) / total
+		}
+	}
+
+	out := make([]LoadBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		bucket.Effort = *round1(bucket.Effort)
+		bucket.OverCapacity = opts.Capacity > 0 && bucket.Effort > opts.Capacity
+		out = append(out, *bucket)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StartsOn < out[j].StartsOn })
+	return out
+}
+
+// periodStart returns the first day of day's week, month or fiscal quarter.
+func periodStart(day time.Time, period string, cal dates.Calendar) time.Time {
+	switch period {
+	case PeriodWeek:
+		return cal.Week(day).Start
+	case PeriodQuarter:
+		return cal.Quarter(cal.QuarterOf(day)).Start
+	}
+	return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func nextPeriod(start time.Time, period string) time.Time {
+	switch period {
+	case PeriodWeek:
+		return start.AddDate(0, 0, 7)
+	case PeriodQuarter:
+		return start.AddDate(0, 3, 0)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+// periodLabel formats a period as "2026-W05", "2026-03" or "FY2027 Q1".
+func periodLabel(start time.Time, period string, cal dates.Calendar) string {
+	switch period {
+	case PeriodWeek:
+		return cal.WeekLabel(start)
+	case PeriodQuarter:
+		return cal.QuarterLabel(cal.QuarterOf(start))
+	}
+	return start.Format("2006-01")
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,141 @@
+package analysis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+)
+
+func scheduledNames(bars []ScheduledBar) []string {
+	names := make([]string, len(bars))
+	for i, b := range bars {
+		names[i] = b.Name
+	}
+	return names
+}
+
+func TestScheduleConflictsOverlaps(t *testing.T) {
+	lanes := []api.Lane{{ID: "1", Name: "Backend"}, {ID: "2", Name: "Mobile"}}
+	bars := decodeBars(t, `[
+		{"id": 10, "lane_id": 1, "name": "A", "starts_on": "2026-03-01", "ends_on": "2026-03-31"},
+		{"id": 11, "lane_id": 1, "name": "B", "starts_on": "2026-03-10", "ends_on": "2026-03-20"},
+		{"id": 12, "lane_id": 1, "name": "C", "starts_on": "2026-03-15", "ends_on": "2026-04-10"},
+		{"id": 13, "lane_id": 1, "name": "D", "starts_on": "2026-04-01", "ends_on": "2026-04-05"},
+		{"id": 14, "lane_id": 1, "name": "Epic", "container": true, "starts_on": "2026-03-01", "ends_on": "2026-04-30"},
+		{"id": 20, "lane_id": 2, "name": "E", "starts_on": "2026-03-01", "ends_on": "2026-03-09"},
+		{"id": 21, "lane_id": 2, "name": "F", "starts_on": "2026-03-10", "ends_on": "2026-03-31"}
+	]`)
+
+	report := ScheduleConflicts(api.Roadmap{ID: "9", Name: "Platform"}, lanes, bars, ConflictOptions{MaxConcurrent: 2})
+
+	if report.OverlapCount != 1 || len(report.Lanes) != 2 {
+		t.Fatalf("expected 1 overlap across 2 lanes, got %+v", report)
+	}
+	backend := report.Lanes[0]
+	if backend.BarCount != 4 || backend.PeakConcurrent != 3 {
+		t.Errorf("backend bars/peak = %d/%d, want 4/3 (container excluded)", backend.BarCount, backend.PeakConcurrent)
+	}
+	o := backend.Overlaps[0]
+	if o.StartsOn != "2026-03-15" || o.EndsOn != "2026-03-20" || o.Peak != 3 {
+		t.Errorf("unexpected overlap %+v", o)
+	}
+	if got := scheduledNames(o.Bars); len(got) != 3 || got[0] != "A" || got[2] != "C" {
+		t.Errorf("overlap bars = %v", got)
+	}
+
+	mobile := report.Lanes[1]
+	if mobile.PeakConcurrent != 1 || len(mobile.Overlaps) != 0 {
+		t.Errorf("back-to-back bars should not overlap, got %+v", mobile)
+	}
+}
+
+func TestScheduleConflictsLoad(t *testing.T) {
+	lanes := []api.Lane{{ID: "1", Name: "Backend"}}
+	bars := decodeBars(t, `[
+		{"id": 10, "lane_id": 1, "name": "A", "effort": 10, "starts_on": "2026-03-27", "ends_on": "2026-04-05"},
+		{"id": 11, "lane_id": 1, "name": "B", "effort": 4, "starts_on": "2026-04-01", "ends_on": "2026-04-30"},
+		{"id": 12, "lane_id": 1, "name": "C", "starts_on": "2026-04-01", "ends_on": "2026-04-02"}
+	]`)
+
+	report := ScheduleConflicts(api.Roadmap{ID: "9"}, lanes, bars, ConflictOptions{Capacity: 8})
+	load := report.Lanes[0].Load
+	if report.Period != PeriodMonth || len(load) != 2 {
+		t.Fatalf("expected 2 monthly buckets, got %+v", load)
+	}
+	if load[0].Period != "2026-03" || load[0].Effort != 5 || load[0].OverCapacity {
+		t.Errorf("unexpected March bucket %+v", load[0])
+	}
+	if load[1].Period != "2026-04" || load[1].Effort != 9 || load[1].Bars != 3 || load[1].Unestimated != 1 || !load[1].OverCapacity {
+		t.Errorf("unexpected April bucket %+v", load[1])
+	}
+
+	report = ScheduleConflicts(api.Roadmap{ID: "9"}, lanes, bars[:1], ConflictOptions{Period: PeriodWeek})
+	weeks := report.Lanes[0].Load
+	if len(weeks) != 2 || weeks[0].Period != "2026-W13" || weeks[0].StartsOn != "2026-03-23" || weeks[0].Effort != 3 || weeks[1].Effort != 7 {
+		t.Errorf("unexpected weekly load %+v", weeks)
+	}
+
+	fiscal := dates.Calendar{FiscalYearStart: time.April}
+	report = ScheduleConflicts(api.Roadmap{ID: "9"}, lanes, bars, ConflictOptions{Period: PeriodQuarter, Calendar: fiscal})
+	quarters := report.Lanes[0].Load
+	if len(quarters) != 2 || quarters[0].Period != "FY2026 Q4" || quarters[0].StartsOn != "2026-01-01" || quarters[0].Effort != 5 ||
+		quarters[1].Period != "FY2027 Q1" || quarters[1].Effort != 9 {
+		t.Errorf("unexpected quarterly load %+v", quarters)
+	}
+
+	report = ScheduleConflicts(api.Roadmap{ID: "9"}, lanes, bars[:1], ConflictOptions{Period: PeriodWeek, Calendar: fiscal.WithWeekStart(time.Sunday)})
+	if weeks = report.Lanes[0].Load; len(weeks) != 3 || weeks[0].StartsOn != "2026-03-22" || weeks[0].Effort != 2 || weeks[1].Effort != 7 {
+		t.Errorf("unexpected Sunday-week load %+v", weeks)
+	}
+}
+
+func TestScheduleConflictsDateIssues(t *testing.T) {
+	bars := decodeBars(t, `[
+		{"id": 1, "lane_id": 1, "name": "Backwards", "starts_on": "2026-05-01", "ends_on": "2026-04-01"},
+		{"id": 2, "lane_id": 1, "name": "Undated"},
+		{"id": 3, "lane_id": 1, "name": "No end", "starts_on": "2026-05-01"},
+		{"id": 4, "lane_id": 1, "name": "Parked", "parked": true, "starts_on": "2026-05-01", "ends_on": "2026-05-02"},
+		{"id": 5, "lane_id": 1, "name": "Idea", "parked": true},
+		{"id": 6, "lane_id": 7, "name": "Garbled", "starts_on": "soon", "ends_on": "2026-05-02"}
+	]`)
+
+	report := ScheduleConflicts(api.Roadmap{ID: "9"}, []api.Lane{{ID: "1", Name: "Backend"}}, bars, ConflictOptions{})
+	want := map[string]string{
+		"Backwards": IssueEndBeforeStart,
+		"Undated":   IssueMissingDates,
+		"No end":    IssueMissingEnd,
+		"Parked":    IssueParkedWithDates,
+		"Garbled":   IssueUnparseableDate,
+	}
+	if len(report.DateIssues) != len(want) {
+		t.Fatalf("expected %d date issues, got %+v", len(want), report.DateIssues)
+	}
+	for _, issue := range report.DateIssues {
+		if want[issue.Bar.Name] != issue.Issue {
+			t.Errorf("%s: issue %q, want %q", issue.Bar.Name, issue.Issue, want[issue.Bar.Name])
+		}
+	}
+	if last := report.Lanes[len(report.Lanes)-1]; last.Lane != "(no lane)" || report.DateIssues[4].Lane != "(no lane)" {
+		t.Errorf("bars in unknown lanes should be grouped under (no lane), got %+v", report.Lanes)
+	}
+}
+
+func TestDetectScheduleConflicts(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/roadmaps/9":       `{"id": 9, "name": "Platform"}`,
+		"/roadmaps/9/bars":  `[{"id": 1, "lane_id": 3, "name": "A", "starts_on": "2026-01-01", "ends_on": "2026-01-31"}]`,
+		"/roadmaps/9/lanes": `[{"id": 3, "name": "Backend"}]`,
+	})
+
+	report, err := DetectScheduleConflicts(context.Background(), client, "9", ConflictOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Roadmap != "Platform" || report.MaxConcurrent != DefaultMaxConcurrent || report.Lanes[0].Lane != "Backend" {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
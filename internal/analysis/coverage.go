@@ -0,0 +1,325 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+)
+
+// How a bar was linked to an objective.
+const (
+	LinkViaAPI         = "api"
+	LinkViaTag         = "tag"
+	LinkViaCustomField = "custom_field"
+	LinkViaParent      = "parent"
+)
+
+// maxParentDepth bounds the walk up a bar's parents, guarding against
+// cycles in malformed data.
+const maxParentDepth = 10
+
+// LinkConventions are the user-configured ways a bar names the objective
+// or key result it supports, for accounts where the API exposes no link.
+// Values match an objective or key result by ID or by name.
+type LinkConventions struct {
+	// TagPrefix marks strategy tags, e.g. "okr:" for a tag "okr:Grow revenue".
+	TagPrefix string `json:"tag_prefix"`
+	// CustomField names a custom field whose value is the objective.
+	CustomField string `json:"custom_field"`
+}
+
+// DefaultLinkConventions returns the conventions used when none are configured.
+func DefaultLinkConventions() LinkConventions {
+	return LinkConventions{TagPrefix: "okr:", CustomField: "Objective"}
+}
+
+// CoverageOptions selects the bars to join with objectives.
+type CoverageOptions struct {
+	// RoadmapIDs limits the bars to these roadmaps. Empty means all.
+	RoadmapIDs []string
+	Links      LinkConventions
+	// IncludeParked counts parked bars as roadmap work.
+	IncludeParked bool
+}
+
+// BarRef identifies a bar and, inside an objective, how it was linked.
+type BarRef struct {
+	ID        api.ID `json:"id"`
+	Name      string `json:"name"`
+	RoadmapID api.ID `json:"roadmap_id"`
+	Roadmap   string `json:"roadmap"`
+	Via       string `json:"via,omitempty"`
+}
+
+// ObjectiveRef identifies an objective.
+type ObjectiveRef struct {
+	ID        api.ID `json:"id"`
+	Name      string `json:"name"`
+	TimeFrame string `json:"time_frame"`
+}
+
+// KeyResultCoverage lists the bars linked to one key result.
+type KeyResultCoverage struct {
+	ID   api.ID   `json:"id"`
+	Name string   `json:"name"`
+	Bars []BarRef `json:"bars"`
+}
+
+// ObjectiveCoverage lists the bars supporting an objective, directly or
+// through one of its key results.
+type ObjectiveCoverage struct {
+	ObjectiveRef
+	BarCount   int                 `json:"bar_count"`
+	Bars       []BarRef            `json:"bars"`
+	KeyResults []KeyResultCoverage `json:"key_results"`
+}
+
+// UnmatchedLink is a tag or field value that names no known objective or
+// key result, usually a typo or a renamed objective.
+type UnmatchedLink struct {
+	Bar    BarRef `json:"bar"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// CoverageReport is the result of StrategicCoverage.
+type CoverageReport struct {
+	Conventions         LinkConventions     `json:"conventions"`
+	BarCount            int                 `json:"bar_count"`
+	LinkedBarCount      int                 `json:"linked_bar_count"`
+	CoveragePct         float64             `json:"coverage_pct"`
+	Objectives          []ObjectiveCoverage `json:"objectives"`
+	UncoveredObjectives []ObjectiveRef      `json:"uncovered_objectives"`
+	UnlinkedBars        []BarRef            `json:"unlinked_bars"`
+	UnmatchedLinks      []UnmatchedLink     `json:"unmatched_links"`
+}
+
+// StrategicCoverage joins bars across roadmaps with objectives and key
+// results. Links come from the API where bars carry objective or key result
+// IDs, then from the configured tag and custom field conventions. A bar with
+// no link of its own inherits its nearest linked parent's.
+func StrategicCoverage(ctx context.Context, client *api.Client, opts CoverageOptions) (*CoverageReport, error) {
+	objectives, err := client.FetchObjectives(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("objectives: %w", err)
+	}
+	keyResults, err := fetchKeyResults(ctx, client, objectives)
+	if err != nil {
+		return nil, err
+	}
+	roadmaps, err := fetchRoadmapBars(ctx, client, opts.RoadmapIDs)
+	if err != nil {
+		return nil, err
+	}
+	return JoinCoverage(objectives, keyResults, roadmaps, opts), nil
+}
+
+// strategyRef points at an objective and optionally one of its key results.
+type strategyRef struct {
+	objective int
+	keyResult int // -1 for the objective itself
+}
+
+// link is a resolved strategy reference on a bar.
+type link struct {
+	strategyRef
+	via string
+}
+
+// strategyIndex resolves IDs and names to objectives and key results.
+type strategyIndex struct {
+	byID   map[string]strategyRef
+	byName map[string]strategyRef
+}
+
+func newStrategyIndex(objectives []api.Objective, keyResults [][]api.KeyResult) strategyIndex {
+	idx := strategyIndex{byID: map[string]strategyRef{}, byName: map[string]strategyRef{}}
+	// Key results first so objectives win when an ID or name collides.
+	for i, krs := range keyResults {
+		for j, kr := range krs {
+			ref := strategyRef{objective: i, keyResult: j}
+			idx.byID[kr.ID.String()] = ref
+			idx.byName[strings.ToLower(kr.Name)] = ref
+		}
+	}
+	for i, o := range objectives {
+		ref := strategyRef{objective: i, keyResult: -1}
+		idx.byID[o.ID.String()] = ref
+		idx.byName[strings.ToLower(o.Name)] = ref
+	}
+	return idx
+}
+
+// resolve matches a value by ID, then by case-insensitive name.
+func (idx strategyIndex) resolve(value string) (strategyRef, bool) {
+	value = strings.TrimSpace(value)
+	if ref, ok := idx.byID[value]; ok {
+		return ref, true
+	}
+	ref, ok := idx.byName[strings.ToLower(value)]
+	return ref, ok
+}
+
+// JoinCoverage computes the report from already-fetched data. keyResults[i]
+// belongs to objectives[i].
+func JoinCoverage(objectives []api.Objective, keyResults [][]api.KeyResult, roadmaps []RoadmapBars, opts CoverageOptions) *CoverageReport {
+	idx := newStrategyIndex(objectives, keyResults)
+	report := &CoverageReport{
+		Conventions:         opts.Links,
+		Objectives:          make([]ObjectiveCoverage, len(objectives)),
+		UncoveredObjectives: []ObjectiveRef{},
+		UnlinkedBars:        []BarRef{},
+		UnmatchedLinks:      []UnmatchedLink{},
+	}
+	for i, o := range objectives {
+		report.Objectives[i] = ObjectiveCoverage{
+			ObjectiveRef: ObjectiveRef{ID: o.ID, Name: o.Name, TimeFrame: o.TimeFrame},
+			Bars:         []BarRef{},
+			KeyResults:   make([]KeyResultCoverage, len(keyResults[i])),
+		}
+		for j, kr := range keyResults[i] {
+			report.Objectives[i].KeyResults[j] = KeyResultCoverage{ID: kr.ID, Name: kr.Name, Bars: []BarRef{}}
+		}
+	}
+
+	for _, rm := range roadmaps {
+		bars := make(map[api.ID]api.Bar, len(rm.Bars))
+		direct := make(map[api.ID][]link, len(rm.Bars))
+		for _, b := range rm.Bars {
+			bars[b.ID] = b
+			ref := BarRef{ID: b.ID, Name: b.Name, RoadmapID: rm.Roadmap.ID, Roadmap: rm.Roadmap.Name}
+			links, unmatched := barLinks(b, idx, opts.Links)
+			direct[b.ID] = links
+			for _, u := range unmatched {
+				u.Bar = ref
+				report.UnmatchedLinks = append(report.UnmatchedLinks, u)
+			}
+		}
+
+		for _, b := range rm.Bars {
+			if b.Parked && !opts.IncludeParked {
+				continue
+			}
+			report.BarCount++
+			ref := BarRef{ID: b.ID, Name: b.Name, RoadmapID: rm.Roadmap.ID, Roadmap: rm.Roadmap.Name}
+			links := direct[b.ID]
+			if len(links) == 0 {
+				links = inheritedLinks(b, bars, direct)
+			}
+			if len(links) == 0 {
+				report.UnlinkedBars = append(report.UnlinkedBars, ref)
+				continue
+			}
+			report.LinkedBarCount++
+			addBarLinks(report, ref, links)
+		}
+	}
+
+	for i := range report.Objectives {
+		oc := &report.Objectives[i]
+		oc.BarCount = len(oc.Bars)
+		if oc.BarCount == 0 {
+			report.UncoveredObjectives = append(report.UncoveredObjectives, oc.ObjectiveRef)
+		}
+	}
+	if report.BarCount > 0 {
+		report.CoveragePct = *round1(float64(report.LinkedBarCount) / float64(report.BarCount) * 100)
+	}
+	sort.SliceStable(report.UnlinkedBars, func(i, j int) bool {
+		a, b := report.UnlinkedBars[i], report.UnlinkedBars[j]
+		if a.Roadmap != b.Roadmap {
+			return a.Roadmap < b.Roadmap
+		}
+		return a.Name < b.Name
+	})
+	return report
+}
+
+// barLinks returns a bar's own strategy links and any convention values
+// that matched nothing.
+func barLinks(b api.Bar, idx strategyIndex, conv LinkConventions) ([]link, []UnmatchedLink) {
+	var links []link
+	var unmatched []UnmatchedLink
+	add := func(value, via string) {
+		if ref, ok := idx.resolve(value); ok {
+			links = append(links, link{strategyRef: ref, via: via})
+		} else {
+			unmatched = append(unmatched, UnmatchedLink{Value: value, Source: via})
+		}
+	}
+
+	for _, id := range b.ObjectiveIDs {
+		add(id.String(), LinkViaAPI)
+	}
+	for _, id := range b.KeyResultIDs {
+		add(id.String(), LinkViaAPI)
+	}
+	if prefix := strings.ToLower(conv.TagPrefix); prefix != "" {
+		for _, tag := range b.Tags {
+			if strings.HasPrefix(strings.ToLower(tag), prefix) {
+				add(strings.TrimSpace(tag[len(prefix):]), LinkViaTag)
+			}
+		}
+	}
+	if conv.CustomField != "" {
+		for name, value := range b.CustomFields() {
+			if !strings.EqualFold(name, conv.CustomField) || strings.TrimSpace(value) == "" {
+				continue
+			}
+			// Try the whole value first so names containing commas match.
+			if _, ok := idx.resolve(value); ok {
+				add(value, LinkViaCustomField)
+				continue
+			}
+			for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == ',' }) {
+				if part = strings.TrimSpace(part); part != "" {
+					add(part, LinkViaCustomField)
+				}
+			}
+		}
+	}
+	return links, unmatched
+}
+
+// inheritedLinks returns the links of b's nearest ancestor that has any.
+func inheritedLinks(b api.Bar, bars map[api.ID]api.Bar, direct map[api.ID][]link) []link {
+	parentID := b.ParentID
+	for depth := 0; parentID != "" && depth < maxParentDepth; depth++ {
+		if links := direct[parentID]; len(links) > 0 {
+			inherited := make([]link, len(links))
+			for i, l := range links {
+				inherited[i] = link{strategyRef: l.strategyRef, via: LinkViaParent}
+			}
+			return inherited
+		}
+		parent, ok := bars[parentID]
+		if !ok {
+			break
+		}
+		parentID = parent.ParentID
+	}
+	return nil
+}
+
+// addBarLinks records a bar under each objective and key result it links to,
+// once per objective and once per key result.
+func addBarLinks(report *CoverageReport, ref BarRef, links []link) {
+	seenObjective := make(map[int]bool)
+	seenKeyResult := make(map[strategyRef]bool)
+	for _, l := range links {
+		ref.Via = l.via
+		oc := &report.Objectives[l.objective]
+		if !seenObjective[l.objective] {
+			seenObjective[l.objective] = true
+			oc.Bars = append(oc.Bars, ref)
+		}
+		if l.keyResult >= 0 && !seenKeyResult[l.strategyRef] {
+			seenKeyResult[l.strategyRef] = true
+			kr := &oc.KeyResults[l.keyResult]
+			kr.Bars = append(kr.Bars, ref)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+)
+
+func decodeBars(t *testing.T, raw string) []api.Bar {
+	t.Helper()
+	var bars []api.Bar
+	if err := json.Unmarshal([]byte(raw), &bars); err != nil {
+		t.Fatalf("decode bars: %v", err)
+	}
+	return bars
+}
+
+func barNames(refs []BarRef) []string {
+	names := make([]string, len(refs))
+	for i, r := range refs {
+		names[i] = r.Name + "/" + r.Via
+	}
+	return names
+}
+
+func TestJoinCoverage(t *testing.T) {
+	objectives := []api.Objective{
+		{ID: "1", Name: "Grow revenue", TimeFrame: "Q3 2026"},
+		{ID: "2", Name: "Retain customers", TimeFrame: "Q3 2026"},
+		{ID: "3", Name: "Cut costs", TimeFrame: "Q4 2026"},
+	}
+	keyResults := [][]api.KeyResult{
+		{{ID: "11", Name: "MRR $2M"}},
+		{{ID: "21", Name: "Churn below 5%"}},
+		nil,
+	}
+	roadmaps := []RoadmapBars{
+		{
+			Roadmap: api.Roadmap{ID: "7", Name: "Platform"},
+			Bars: decodeBars(t, `[
+				{"id": 70, "name": "Billing", "objective_ids": [1]},
+				{"id": 71, "name": "Invoices", "parent_id": 70},
+				{"id": 72, "name": "Usage pricing", "key_results": [{"id": 11}], "tags": ["okr:Grow Revenue"]},
+				{"id": 73, "name": "Health score", "custom_text_fields": [{"name": "objective", "value": "Churn below 5%; Grow revenue"}]},
+				{"id": 74, "name": "Tech debt", "tags": ["okr:Delight users"]},
+				{"id": 75, "name": "Old idea", "parked": true}
+			]`),
+		},
+		{
+			Roadmap: api.Roadmap{ID: "8", Name: "Apps"},
+			Bars:    decodeBars(t, `[{"id": 80, "name": "Dark mode"}]`),
+		},
+	}
+
+	report := JoinCoverage(objectives, keyResults, roadmaps, CoverageOptions{Links: DefaultLinkConventions()})
+
+	if report.BarCount != 6 || report.LinkedBarCount != 4 {
+		t.Errorf("expected 4 of 6 bars linked, got %d of %d", report.LinkedBarCount, report.BarCount)
+	}
+	if report.CoveragePct != 66.7 {
+		t.Errorf("expected 66.7%% coverage, got %v", report.CoveragePct)
+	}
+
+	grow := report.Objectives[0]
+	want := []string{"Billing/api", "Invoices/parent", "Usage pricing/api", "Health score/custom_field"}
+	if got := barNames(grow.Bars); !slices.Equal(got, want) {
+		t.Errorf("Grow revenue bars = %v, want %v", got, want)
+	}
+	if got := barNames(grow.KeyResults[0].Bars); !slices.Equal(got, []string{"Usage pricing/api"}) {
+		t.Errorf("MRR bars = %v", got)
+	}
+	if got := barNames(report.Objectives[1].KeyResults[0].Bars); !slices.Equal(got, []string{"Health score/custom_field"}) {
+		t.Errorf("Churn bars = %v", got)
+	}
+
+	if len(report.UncoveredObjectives) != 1 || report.UncoveredObjectives[0].Name != "Cut costs" {
+		t.Errorf("expected Cut costs uncovered, got %+v", report.UncoveredObjectives)
+	}
+	if got := barNames(report.UnlinkedBars); !slices.Equal(got, []string{"Dark mode/", "Tech debt/"}) {
+		t.Errorf("unlinked bars = %v", got)
+	}
+	if len(report.UnmatchedLinks) != 1 || report.UnmatchedLinks[0].Value != "Delight users" || report.UnmatchedLinks[0].Source != LinkViaTag {
+		t.Errorf("expected the Delight users tag unmatched, got %+v", report.UnmatchedLinks)
+	}
+}
+
+func TestJoinCoverageIncludeParked(t *testing.T) {
+	objectives := []api.Objective{{ID: "1", Name: "Grow"}}
+	roadmaps := []RoadmapBars{{
+		Roadmap: api.Roadmap{ID: "7", Name: "Platform"},
+		Bars:    decodeBars(t, `[{"id": 70, "name": "Someday", "parked": true, "tags": ["okr:1"]}]`),
+	}}
+
+	report := JoinCoverage(objectives, [][]api.KeyResult{nil}, roadmaps, CoverageOptions{Links: DefaultLinkConventions()})
+	if report.BarCount != 0 || len(report.UncoveredObjectives) != 1 {
+		t.Errorf("parked bars should be ignored by default, got %+v", report)
+	}
+
+	report = JoinCoverage(objectives, [][]api.KeyResult{nil}, roadmaps, CoverageOptions{Links: DefaultLinkConventions(), IncludeParked: true})
+	if report.LinkedBarCount != 1 || report.Objectives[0].BarCount != 1 {
+		t.Errorf("expected the parked bar linked by ID tag, got %+v", report)
+	}
+}
+
+func TestJoinCoverageDisabledConventions(t *testing.T) {
+	objectives := []api.Objective{{ID: "1", Name: "Grow"}}
+	roadmaps := []RoadmapBars{{
+		Roadmap: api.Roadmap{ID: "7", Name: "Platform"},
+		Bars:    decodeBars(t, `[{"id": 70, "name": "Billing", "tags": ["okr:Grow"]}]`),
+	}}
+
+	report := JoinCoverage(objectives, [][]api.KeyResult{nil}, roadmaps, CoverageOptions{})
+	if report.LinkedBarCount != 0 || len(report.UnmatchedLinks) != 0 {
+		t.Errorf("expected no links without conventions, got %+v", report)
+	}
+}
+
+func TestInheritedLinksCycle(t *testing.T) {
+	bars := decodeBars(t, `[
+		{"id": 1, "name": "A", "parent_id": 2},
+		{"id": 2, "name": "B", "parent_id": 1}
+	]`)
+	byID := map[api.ID]api.Bar{bars[0].ID: bars[0], bars[1].ID: bars[1]}
+	if links := inheritedLinks(bars[0], byID, map[api.ID][]link{}); links != nil {
+		t.Errorf("expected no links from a parent cycle, got %v", links)
+	}
+}
+
+func TestStrategicCoverage(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/strategy/objectives":               `[{"id": 1, "name": "Grow"}]`,
+		"/strategy/objectives/1/key_results": `[]`,
+		"/roadmaps":                          `[{"id": 7, "name": "Platform"}, {"id": 8, "name": "Apps"}]`,
+		"/roadmaps/7/bars":                   `[{"id": 70, "name": "Billing", "tags": ["okr:grow"]}]`,
+		"/roadmaps/8/bars":                   `[{"id": 80, "name": "Dark mode"}]`,
+	})
+
+	report, err := StrategicCoverage(context.Background(), client, CoverageOptions{Links: DefaultLinkConventions()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.BarCount != 2 || report.LinkedBarCount != 1 {
+		t.Errorf("expected 1 of 2 bars linked across all roadmaps, got %d of %d", report.LinkedBarCount, report.BarCount)
+	}
+	if len(report.UnlinkedBars) != 1 || report.UnlinkedBars[0].Roadmap != "Apps" {
+		t.Errorf("expected Dark mode on Apps unlinked, got %+v", report.UnlinkedBars)
+	}
+}
@@ -0,0 +1,253 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+)
+
+// DefaultDemandLimit is how many entries each demand ranking returns.
+const DefaultDemandLimit = 20
+
+// demandTopCustomers is how many customers an opportunity or tag entry names.
+const demandTopCustomers = 5
+
+// DemandOptions tunes RankCustomerDemand.
+type DemandOptions struct {
+	// CustomerValues weights customers by name, matched case-insensitively,
+	// e.g. by ARR or tier. Empty means every customer is worth DefaultValue.
+	CustomerValues map[string]float64
+	// DefaultValue is the weight of customers missing from CustomerValues.
+	// Zero means 1.
+	DefaultValue float64
+	// Limit caps each ranking. Zero means DefaultDemandLimit.
+	Limit int
+	// IncludeClosed keeps closed ideas and opportunities (done, rejected, ...).
+	IncludeClosed bool
+}
+
+// DemandEntry is one ranked opportunity, tag or customer.
+type DemandEntry struct {
+	// ID is set for opportunities.
+	ID   api.ID `json:"id,omitempty"`
+	Name string `json:"name"`
+	// Ideas counts the ideas behind the entry.
+	Ideas int `json:"ideas"`
+	// Requests counts idea-customer pairs: one customer on three ideas is
+	// three requests. For a customer entry it equals Ideas.
+	Requests int `json:"requests"`
+	// Customers counts distinct customers (1 for a customer entry).
+	Customers int `json:"customers"`
+	// Score is the summed value of the distinct customers asking; for a
+	// customer entry it is the customer's value times its requests.
+	Score float64 `json:"score"`
+	// TopCustomers names the most valuable customers asking (opportunities
+	// and tags only).
+	TopCustomers []string `json:"top_customers,omitempty"`
+}
+
+// DemandReport is the result of RankCustomerDemand.
+type DemandReport struct {
+	Ideas         int           `json:"ideas"`
+	Weighted      bool          `json:"weighted"`
+	Opportunities []DemandEntry `json:"opportunities"`
+	Tags          []DemandEntry `json:"tags"`
+	Customers     []DemandEntry `json:"customers"`
+	// UnmatchedValues lists CustomerValues keys that matched no customer,
+	// which usually means a typo.
+	UnmatchedValues []string `json:"unmatched_values"`
+}
+
+// RankCustomerDemand fetches ideas and opportunities and ranks demand.
+func RankCustomerDemand(ctx context.Context, client *api.Client, opts DemandOptions) (*DemandReport, error) {
+	ideas, err := client.FetchIdeas(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ideas: %w", err)
+	}
+	opps, err := client.FetchOpportunities(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("opportunities: %w", err)
+	}
+	return RankDemand(ideas, opps, opts), nil
+}
+
+// demandTally accumulates one entry's ideas and customers.
+type demandTally struct {
+	entry     DemandEntry
+	ideas     map[api.ID]bool
+	customers map[string]string // folded name -> first spelling
+}
+
+func newDemandTally(id api.ID, name string) *demandTally {
+	return &demandTally{entry: DemandEntry{ID: id, Name: name}, ideas: map[api.ID]bool{}, customers: map[string]string{}}
+}
+
+func (t *demandTally) add(idea api.Idea) {
+	if t.ideas[idea.ID] {
+		return
+	}
+	t.ideas[idea.ID] = true
+	for _, c := range idea.Customers {
+		if c = strings.TrimSpace(c); c == "" {
+			continue
+		}
+		t.entry.Requests++
+		if _, ok := t.customers[strings.ToLower(c)]; !ok {
+			t.customers[strings.ToLower(c)] = c
+		}
+	}
+}
+
+// RankDemand joins ideas to customers and tags, and opportunities to ideas
+// through links on either side, then ranks each by weighted score, distinct
+// customers and requests.
+func RankDemand(ideas []api.Idea, opps []api.Opportunity, opts DemandOptions) *DemandReport {
+	defaultValue := opts.DefaultValue
+	if defaultValue <= 0 {
+		defaultValue = 1
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultDemandLimit
+	}
+	values := make(map[string]float64, len(opts.CustomerValues))
+	for name, v := range opts.CustomerValues {
+		values[strings.ToLower(strings.TrimSpace(name))] = v
+	}
+	valueOf := func(folded string) float64 {
+		if v, ok := values[folded]; ok {
+			return v
+		}
+		return defaultValue
+	}
+
+	oppTallies := make(map[api.ID]*demandTally)
+	oppByID := make(map[api.ID]api.Opportunity, len(opps))
+	for _, o := range opps {
+		if opts.IncludeClosed || !isClosed(o.WorkflowStatus) {
+			oppByID[o.ID] = o
+		}
+	}
+	ideaOpps := make(map[api.ID][]api.ID)
+	for _, o := range oppByID {
+		for _, id := range o.IdeaIDs {
+			ideaOpps[id] = append(ideaOpps[id], o.ID)
+		}
+	}
+
+	report := &DemandReport{Weighted: len(values) > 0, UnmatchedValues: []string{}}
+	tagTallies := make(map[string]*demandTally)
+	custTallies := make(map[string]*demandTally)
+	for _, idea := range ideas {
+		if !opts.IncludeClosed && isClosed(idea.Status) {
+			continue
+		}
+		report.Ideas++
+		linked := append(append([]api.ID{}, idea.OpportunityIDs...), ideaOpps[idea.ID]...)
+		for _, id := range linked {
+			o, ok := oppByID[id]
+			if !ok {
+				continue
+			}
+			if oppTallies[id] == nil {
+				oppTallies[id] = newDemandTally(id, o.ProblemStatement)
+			}
+			oppTallies[id].add(idea)
+		}
+		for _, tag := range idea.Tags {
+			key := strings.ToLower(strings.TrimSpace(tag))
+			if key == "" {
+				continue
+			}
+			if tagTallies[key] == nil {
+				tagTallies[key] = newDemandTally("", strings.TrimSpace(tag))
+			}
+			tagTallies[key].add(idea)
+		}
+		for _, c := range idea.Customers {
+			key := strings.ToLower(strings.TrimSpace(c))
+			if key == "" {
+				continue
+			}
+			if custTallies[key] == nil {
+				custTallies[key] = newDemandTally("", strings.TrimSpace(c))
+			}
+			custTallies[key].add(idea)
+		}
+	}
+
+	finish := func(t *demandTally) DemandEntry {
+		e := t.entry
+		e.Ideas = len(t.ideas)
+		e.Customers = len(t.customers)
+		folded := make([]string, 0, len(t.customers))
+		for key := range t.customers {
+			folded = append(folded, key)
+			e.Score += valueOf(key)
+		}
+		sort.Slice(folded, func(i, j int) bool {
+			if vi, vj := valueOf(folded[i]), valueOf(folded[j]); vi != vj {
+				return vi > vj
+			}
+			return folded[i] < folded[j]
+		})
+		for _, key := range folded[:min(demandTopCustomers, len(folded))] {
+			e.TopCustomers = append(e.TopCustomers, t.customers[key])
+		}
+		e.Score = math.Round(e.Score*100) / 100
+		return e
+	}
+	for _, t := range oppTallies {
+		report.Opportunities = append(report.Opportunities, finish(t))
+	}
+	for _, t := range tagTallies {
+		report.Tags = append(report.Tags, finish(t))
+	}
+	// A customer's requests are the ideas it is on; co-customers on those
+	// ideas are not its demand.
+	for key, t := range custTallies {
+		e := t.entry
+		e.Ideas = len(t.ideas)
+		e.Requests = e.Ideas
+		e.Customers = 1
+		e.Score = math.Round(valueOf(key)*float64(e.Requests)*100) / 100
+		report.Customers = append(report.Customers, e)
+	}
+	report.Opportunities = rankDemand(report.Opportunities, limit)
+	report.Tags = rankDemand(report.Tags, limit)
+	report.Customers = rankDemand(report.Customers, limit)
+
+	for name := range opts.CustomerValues {
+		if custTallies[strings.ToLower(strings.TrimSpace(name))] == nil {
+			report.UnmatchedValues = append(report.UnmatchedValues, name)
+		}
+	}
+	sort.Strings(report.UnmatchedValues)
+	return report
+}
+
+// rankDemand sorts entries by score, distinct customers, requests and name,
+// and keeps the first limit. It never returns nil.
+func rankDemand(entries []DemandEntry, limit int) []DemandEntry {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Customers != b.Customers {
+			return a.Customers > b.Customers
+		}
+		if a.Requests != b.Requests {
+			return a.Requests > b.Requests
+		}
+		return a.Name < b.Name
+	})
+	if entries == nil {
+		return []DemandEntry{}
+	}
+	return entries[:min(limit, len(entries))]
+}
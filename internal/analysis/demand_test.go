@@ -0,0 +1,103 @@
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+)
+
+func demandFixture(t *testing.T) ([]api.Idea, []api.Opportunity) {
+	t.Helper()
+	var ideas []api.Idea
+	if err := json.Unmarshal([]byte(`[
+		{"id": 1, "name": "SSO", "customers": ["Acme", "Globex"], "tags": ["Security"], "opportunities": [{"id": 10}]},
+		{"id": 2, "name": "SAML", "customers": ["acme", "Initech"], "tags": ["security", "Enterprise"]},
+		{"id": 3, "name": "Dark mode", "customers": ["Hooli"], "tags": ["UI"], "opportunity_ids": [11]},
+		{"id": 4, "name": "Old", "status": "Rejected", "customers": ["Acme"], "tags": ["UI"], "opportunity_ids": [11]}
+	]`), &ideas); err != nil {
+		t.Fatalf("decode ideas: %v", err)
+	}
+	var opps []api.Opportunity
+	if err := json.Unmarshal([]byte(`[
+		{"id": 10, "problem_statement": "Enterprise login", "ideas": [{"id": 2}, {"id": 1}]},
+		{"id": 11, "problem_statement": "Eye strain", "idea_ids": []},
+		{"id": 12, "problem_statement": "Closed", "workflow_status": "archived", "idea_ids": [1]}
+	]`), &opps); err != nil {
+		t.Fatalf("decode opportunities: %v", err)
+	}
+	return ideas, opps
+}
+
+func names(entries []DemandEntry) string {
+	out := make([]string, len(entries))
+	for i, e := range entries {
+		out[i] = e.Name
+	}
+	return strings.Join(out, ",")
+}
+
+func TestRankDemandUnweighted(t *testing.T) {
+	ideas, opps := demandFixture(t)
+	report := RankDemand(ideas, opps, DemandOptions{})
+
+	if report.Ideas != 3 || report.Weighted {
+		t.Errorf("ideas/weighted = %d/%v", report.Ideas, report.Weighted)
+	}
+	if names(report.Opportunities) != "Enterprise login,Eye strain" {
+		t.Errorf("opportunities = %s", names(report.Opportunities))
+	}
+	login := report.Opportunities[0]
+	if login.ID != "10" || login.Ideas != 2 || login.Requests != 4 || login.Customers != 3 || login.Score != 3 {
+		t.Errorf("unexpected login entry %+v", login)
+	}
+	if names(report.Tags) != "Security,Enterprise,UI" {
+		t.Errorf("tags = %s", names(report.Tags))
+	}
+	if c := report.Customers[0]; c.Name != "Acme" || c.Requests != 2 || c.Customers != 1 || c.Score != 2 {
+		t.Errorf("unexpected top customer %+v", c)
+	}
+}
+
+func TestRankDemandWeighted(t *testing.T) {
+	ideas, opps := demandFixture(t)
+	report := RankDemand(ideas, opps, DemandOptions{
+		CustomerValues: map[string]float64{"HOOLI": 10, "Initech": 2, "Umbrella": 5},
+		DefaultValue:   0.5,
+		Limit:          1,
+		IncludeClosed:  true,
+	})
+
+	if !report.Weighted || report.Ideas != 4 {
+		t.Errorf("ideas/weighted = %d/%v", report.Ideas, report.Weighted)
+	}
+	if len(report.Opportunities) != 1 || report.Opportunities[0].Name != "Eye strain" || report.Opportunities[0].Score != 10.5 {
+		t.Errorf("opportunities = %+v", report.Opportunities)
+	}
+	if got := strings.Join(report.Tags[0].TopCustomers, ","); report.Tags[0].Name != "UI" || got != "Hooli,Acme" {
+		t.Errorf("tags = %+v", report.Tags)
+	}
+	if c := report.Customers[0]; c.Name != "Hooli" || c.Score != 10 {
+		t.Errorf("customers = %+v", report.Customers)
+	}
+	if strings.Join(report.UnmatchedValues, ",") != "Umbrella" {
+		t.Errorf("unmatched = %v", report.UnmatchedValues)
+	}
+}
+
+func TestRankCustomerDemand(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/discovery/ideas":         `[{"id": 1, "customers": ["Acme"], "opportunity_ids": [5]}]`,
+		"/discovery/opportunities": `[{"id": 5, "problem_statement": "Slow"}]`,
+	})
+	report, err := RankCustomerDemand(context.Background(), client, DemandOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Opportunities) != 1 || report.Opportunities[0].Customers != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
@@ -0,0 +1,409 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/pkg/productplan"
+)
+
+// DependencyBar is a bar as a node in the dependency graph. External bars
+// are on another roadmap; only their ID is known.
+type DependencyBar struct {
+	ID       api.ID `json:"id"`
+	Name     string `json:"name"`
+	StartsOn string `json:"starts_on"`
+	EndsOn   string `json:"ends_on"`
+	External bool   `json:"external,omitempty"`
+}
+
+// DependencyEdge is a connection: To depends on From.
+type DependencyEdge struct {
+	ConnectionID api.ID `json:"connection_id"`
+	From         api.ID `json:"from"`
+	To           api.ID `json:"to"`
+	// InCycle marks edges that close a dependency cycle; they are left out
+	// of the critical path.
+	InCycle bool `json:"in_cycle,omitempty"`
+}
+
+// DependencyViolation is a dependent bar scheduled to start before its
+// predecessor ends. End dates are inclusive, so starting on the day the
+// predecessor ends counts as a one-day overlap.
+type DependencyViolation struct {
+	Predecessor DependencyBar `json:"predecessor"`
+	Dependent   DependencyBar `json:"dependent"`
+	OverlapDays int           `json:"overlap_days"`
+}
+
+// CriticalPath is the chain of dependent bars with the greatest total
+// duration.
+type CriticalPath struct {
+	Bars         []DependencyBar `json:"bars"`
+	DurationDays int             `json:"duration_days"`
+	StartsOn     string          `json:"starts_on"`
+	EndsOn       string          `json:"ends_on"`
+}
+
+// DependencyReport is the result of AnalyzeDependencies.
+type DependencyReport struct {
+	RoadmapID    api.ID                `json:"roadmap_id"`
+	Roadmap      string                `json:"roadmap"`
+	BarCount     int                   `json:"bar_count"`
+	Bars         []DependencyBar       `json:"bars"`
+	Edges        []DependencyEdge      `json:"edges"`
+	Cycles       [][]DependencyBar     `json:"cycles"`
+	CriticalPath CriticalPath          `json:"critical_path"`
+	Violations   []DependencyViolation `json:"violations"`
+	Mermaid      string                `json:"mermaid"`
+}
+
+// AnalyzeDependencies builds the dependency graph of a roadmap from its
+// bars' connections and reports cycles, the critical path and scheduling
+// violations.
+func AnalyzeDependencies(ctx context.Context, client *api.Client, roadmapID string) (*DependencyReport, error) {
+	roadmaps, err := fetchRoadmapBars(ctx, client, []string{roadmapID})
+	if err != nil {
+		return nil, err
+	}
+	rm := roadmaps[0]
+
+	fns := make([]func(ctx context.Context) ([]api.Connection, error), len(rm.Bars))
+	for i, b := range rm.Bars {
+		fns[i] = func(ctx context.Context) ([]api.Connection, error) {
+			conns, err := client.FetchBarConnections(ctx, b.ID.String())
+			if err != nil {
+				return nil, fmt.Errorf("bar %s connections: %w", b.ID, err)
+			}
+			return conns, nil
+		}
+	}
+	result := productplan.Execute(ctx, productplan.DefaultBatchConfig(), fns)
+	if result.HasErrors() {
+		return nil, result.Errors[0].Err
+	}
+	var conns []api.Connection
+	for _, c := range result.Results {
+		conns = append(conns, c...)
+	}
+	return BuildDependencyGraph(rm.Roadmap, rm.Bars, conns), nil
+}
+
+// depNode is a graph node with parsed dates.
+type depNode struct {
+	bar        DependencyBar
+	start, end time.Time
+	dated      bool
+}
+
+// days is the node's inclusive duration, or zero when undated.
+func (n depNode) days() int {
+	if !n.dated {
+		return 0
+	}
+	return daysBetween(n.start, n.end) + 1
+}
+
+// depGraph is an adjacency-list graph over node indices.
+type depGraph struct {
+	nodes []depNode
+	index map[api.ID]int
+	edges []DependencyEdge
+	out   [][]int // node -> edge indices
+}
+
+// BuildDependencyGraph computes the report from already-fetched data.
+// Connections are deduplicated by source and target; connections to bars on
+// other roadmaps become external nodes.
+func BuildDependencyGraph(roadmap api.Roadmap, bars []api.Bar, conns []api.Connection) *DependencyReport {
+	g := &depGraph{index: make(map[api.ID]int, len(bars))}
+	for _, b := range bars {
+		g.addNode(DependencyBar{ID: b.ID, Name: b.Name, StartsOn: b.StartsOn, EndsOn: b.EndsOn})
+	}
+	seen := make(map[[2]api.ID]bool)
+	for _, c := range conns {
+		key := [2]api.ID{c.SourceBarID, c.TargetBarID}
+		if c.SourceBarID == "" || c.TargetBarID == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		for _, id := range key {
+			if _, ok := g.index[id]; !ok {
+				g.addNode(DependencyBar{ID: id, Name: "Bar " + id.String(), External: true})
+			}
+		}
+		g.edges = append(g.edges, DependencyEdge{ConnectionID: c.ID, From: c.SourceBarID, To: c.TargetBarID})
+	}
+	g.out = make([][]int, len(g.nodes))
+	for i, e := range g.edges {
+		from := g.index[e.From]
+		g.out[from] = append(g.out[from], i)
+	}
+
+	report := &DependencyReport{
+		RoadmapID:  roadmap.ID,
+		Roadmap:    roadmap.Name,
+		BarCount:   len(bars),
+		Bars:       []DependencyBar{},
+		Edges:      g.edges,
+		Cycles:     [][]DependencyBar{},
+		Violations: []DependencyViolation{},
+	}
+	if report.Edges == nil {
+		report.Edges = []DependencyEdge{}
+	}
+
+	g.markCycles(report)
+	report.CriticalPath = g.criticalPath()
+	for _, e := range g.edges {
+		from, to := g.nodes[g.index[e.From]], g.nodes[g.index[e.To]]
+		if from.dated && to.dated && !to.start.After(from.end) {
+			report.Violations = append(report.Violations, DependencyViolation{
+				Predecessor: from.bar,
+				Dependent:   to.bar,
+				OverlapDays: daysBetween(to.start, from.end) + 1,
+			})
+		}
+	}
+	connected := make([]bool, len(g.nodes))
+	for _, e := range g.edges {
+		connected[g.index[e.From]] = true
+		connected[g.index[e.To]] = true
+	}
+	for i, n := range g.nodes {
+		if connected[i] {
+			report.Bars = append(report.Bars, n.bar)
+		}
+	}
+	report.Mermaid = g.mermaid(connected, report)
+	return report
+}
+
+func (g *depGraph) addNode(bar DependencyBar) {
+	n := depNode{bar: bar}
+	start, okStart := api.ParseDate(bar.StartsOn)
+	end, okEnd := api.ParseDate(bar.EndsOn)
+	if okStart && okEnd && !end.Before(start) {
+		n.start, n.end, n.dated = start, end, true
+	}
+	g.index[bar.ID] = len(g.nodes)
+	g.nodes = append(g.nodes, n)
+}
+
+// markCycles finds strongly connected components (Tarjan's algorithm),
+// records each one with more than one bar, or a bar depending on itself, as
+// a cycle, and flags the edges inside it.
+func (g *depGraph) markCycles(report *DependencyReport) {
+	n := len(g.nodes)
+	index := make([]int, n)
+	low := make([]int, n)
+	onStack := make([]bool, n)
+	component := make([]int, n)
+	for i := range index {
+		index[i] = -1
+	}
+	var stack []int
+	next, components := 0, 0
+
+	var strongConnect func(v int)
+	strongConnect = func(v int) {
+		index[v], low[v] = next, next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+		for _, ei := range g.out[v] {
+			w := g.index[g.edges[ei].To]
+			if index[w] < 0 {
+				strongConnect(w)
+				low[v] = min(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = min(low[v], index[w])
+			}
+		}
+		if low[v] != index[v] {
+			return
+		}
+		var members []int
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			component[w] = components
+			members = append(members, w)
+			if w == v {
+				break
+			}
+		}
+		components++
+		if len(members) > 1 || g.hasSelfLoop(v) {
+			sort.Ints(members)
+			cycle := make([]DependencyBar, len(members))
+			for i, m := range members {
+				cycle[i] = g.nodes[m].bar
+			}
+			report.Cycles = append(report.Cycles, cycle)
+		}
+	}
+	for v := range g.nodes {
+		if index[v] < 0 {
+			strongConnect(v)
+		}
+	}
+
+	for i, e := range g.edges {
+		from, to := g.index[e.From], g.index[e.To]
+		if component[from] == component[to] {
+			g.edges[i].InCycle = true
+		}
+	}
+	sort.SliceStable(report.Cycles, func(i, j int) bool {
+		return g.index[report.Cycles[i][0].ID] < g.index[report.Cycles[j][0].ID]
+	})
+}
+
+func (g *depGraph) hasSelfLoop(v int) bool {
+	for _, ei := range g.out[v] {
+		if g.index[g.edges[ei].To] == v {
+			return true
+		}
+	}
+	return false
+}
+
+// criticalPath finds the chain with the greatest total duration over the
+// edges outside cycles, which form a DAG. Undated bars count as zero days.
+// Ties go to the chain that ends later, then to roadmap order.
+func (g *depGraph) criticalPath() CriticalPath {
+	n := len(g.nodes)
+	indegree := make([]int, n)
+	for _, e := range g.edges {
+		if !e.InCycle {
+			indegree[g.index[e.To]]++
+		}
+	}
+	var queue []int
+	for v := range g.nodes {
+		if indegree[v] == 0 {
+			queue = append(queue, v)
+		}
+	}
+	dist := make([]int, n)
+	prev := make([]int, n)
+	for v := range g.nodes {
+		dist[v] = g.nodes[v].days()
+		prev[v] = -1
+	}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, ei := range g.out[v] {
+			if g.edges[ei].InCycle {
+				continue
+			}
+			w := g.index[g.edges[ei].To]
+			if d := dist[v] + g.nodes[w].days(); prev[w] < 0 || d > dist[w] {
+				dist[w], prev[w] = d, v
+			}
+			if indegree[w]--; indegree[w] == 0 {
+				queue = append(queue, w)
+			}
+		}
+	}
+
+	best := -1
+	for v := range g.nodes {
+		if prev[v] < 0 {
+			continue // not the end of a chain
+		}
+		if best < 0 || dist[v] > dist[best] || (dist[v] == dist[best] && g.nodes[v].end.After(g.nodes[best].end)) {
+			best = v
+		}
+	}
+	path := CriticalPath{Bars: []DependencyBar{}}
+	if best < 0 {
+		return path
+	}
+	var chain []int
+	for v := best; v >= 0; v = prev[v] {
+		chain = append(chain, v)
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		node := g.nodes[chain[i]]
+		path.Bars = append(path.Bars, node.bar)
+		if node.dated && path.StartsOn == "" {
+			path.StartsOn = node.start.Format(time.DateOnly)
+		}
+		if node.dated {
+			path.EndsOn = node.end.Format(time.DateOnly)
+		}
+	}
+	path.DurationDays = dist[best]
+	return path
+}
+
+// mermaid renders the connected bars as a left-to-right flowchart. Critical
+// path bars are outlined, cycle bars filled red, and violating edges dashed.
+func (g *depGraph) mermaid(connected []bool, report *DependencyReport) string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for i, n := range g.nodes {
+		if !connected[i] {
+			continue
+		}
+		label := mermaidEscape(n.bar.Name)
+		if n.dated {
+			label += "<br/>" + n.start.Format(time.DateOnly) + " → " + n.end.Format(time.DateOnly)
+		}
+		fmt.Fprintf(&b, "  n%d[\"%s\"]\n", i, label)
+	}
+
+	violating := make(map[[2]api.ID]bool, len(report.Violations))
+	for _, v := range report.Violations {
+		violating[[2]api.ID{v.Predecessor.ID, v.Dependent.ID}] = true
+	}
+	for _, e := range g.edges {
+		from, to := g.index[e.From], g.index[e.To]
+		if violating[[2]api.ID{e.From, e.To}] {
+			fmt.Fprintf(&b, "  n%d -.->|overlaps| n%d\n", from, to)
+		} else {
+			fmt.Fprintf(&b, "  n%d --> n%d\n", from, to)
+		}
+	}
+
+	classes := []struct {
+		name, style string
+		bars        []DependencyBar
+	}{
+		{"critical", "stroke:#d9480f,stroke-width:3px", report.CriticalPath.Bars},
+		{"cycle", "fill:#ffe3e3,stroke:#c92a2a", flatten(report.Cycles)},
+	}
+	for _, c := range classes {
+		if len(c.bars) == 0 {
+			continue
+		}
+		ids := make([]string, len(c.bars))
+		for i, bar := range c.bars {
+			ids[i] = fmt.Sprintf("n%d", g.index[bar.ID])
+		}
+		fmt.Fprintf(&b, "  classDef %s %s\n", c.name, c.style)
+		fmt.Fprintf(&b, "  class %s %s\n", strings.Join(ids, ","), c.name)
+	}
+	return b.String()
+}
+
+func flatten(cycles [][]DependencyBar) []DependencyBar {
+	var out []DependencyBar
+	for _, c := range cycles {
+		out = append(out, c...)
+	}
+	return out
+}
+
+// mermaidEscape makes a bar name safe inside a quoted Mermaid label.
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\n", " ", "\r", "").Replace(s)
+}
@@ -0,0 +1,153 @@
+package analysis
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+)
+
+func depNames(bars []DependencyBar) string {
+	names := make([]string, len(bars))
+	for i, b := range bars {
+		names[i] = b.Name
+	}
+	return strings.Join(names, ",")
+}
+
+func conn(id, from, to string) api.Connection {
+	return api.Connection{ID: api.ID(id), SourceBarID: api.ID(from), TargetBarID: api.ID(to)}
+}
+
+func TestBuildDependencyGraph(t *testing.T) {
+	bars := decodeBars(t, `[
+		{"id": 1, "name": "Design", "starts_on": "2026-01-01", "ends_on": "2026-01-31"},
+		{"id": 2, "name": "Build", "starts_on": "2026-02-01", "ends_on": "2026-03-31"},
+		{"id": 3, "name": "Docs", "starts_on": "2026-02-01", "ends_on": "2026-02-07"},
+		{"id": 4, "name": "Launch", "starts_on": "2026-03-15", "ends_on": "2026-03-31"},
+		{"id": 5, "name": "Unrelated", "starts_on": "2026-01-01", "ends_on": "2026-12-31"}
+	]`)
+	conns := []api.Connection{
+		conn("a", "1", "2"),
+		conn("b", "1", "3"),
+		conn("c", "2", "4"),
+		conn("d", "3", "4"),
+		conn("a2", "1", "2"), // listed again from the target's side
+	}
+
+	report := BuildDependencyGraph(api.Roadmap{ID: "9", Name: "Platform"}, bars, conns)
+
+	if len(report.Edges) != 4 {
+		t.Errorf("expected 4 deduplicated edges, got %d", len(report.Edges))
+	}
+	if len(report.Cycles) != 0 {
+		t.Errorf("expected no cycles, got %v", report.Cycles)
+	}
+	if got := depNames(report.Bars); got != "Design,Build,Docs,Launch" {
+		t.Errorf("connected bars = %s", got)
+	}
+	if got := depNames(report.CriticalPath.Bars); got != "Design,Build,Launch" {
+		t.Errorf("critical path = %s", got)
+	}
+	cp := report.CriticalPath
+	if cp.DurationDays != 31+59+17 || cp.StartsOn != "2026-01-01" || cp.EndsOn != "2026-03-31" {
+		t.Errorf("critical path span = %+v", cp)
+	}
+
+	if len(report.Violations) != 1 {
+		t.Fatalf("expected 1 violation, got %+v", report.Violations)
+	}
+	v := report.Violations[0]
+	if v.Predecessor.Name != "Build" || v.Dependent.Name != "Launch" || v.OverlapDays != 17 {
+		t.Errorf("unexpected violation %+v", v)
+	}
+
+	for _, want := range []string{
+		"flowchart LR",
+		`n0["Design<br/>2026-01-01 → 2026-01-31"]`,
+		"n0 --> n1",
+		"n1 -.->|overlaps| n3",
+		"class n0,n1,n3 critical",
+	} {
+		if !strings.Contains(report.Mermaid, want) {
+			t.Errorf("mermaid missing %q:\n%s", want, report.Mermaid)
+		}
+	}
+	if strings.Contains(report.Mermaid, "Unrelated") {
+		t.Error("mermaid should leave out bars without dependencies")
+	}
+}
+
+func TestBuildDependencyGraphCycles(t *testing.T) {
+	bars := decodeBars(t, `[
+		{"id": 1, "name": "A", "starts_on": "2026-01-01", "ends_on": "2026-01-10"},
+		{"id": 2, "name": "B", "starts_on": "2026-01-11", "ends_on": "2026-01-20"},
+		{"id": 3, "name": "C", "starts_on": "2026-01-21", "ends_on": "2026-01-30"},
+		{"id": 4, "name": "D \"quoted\""}
+	]`)
+	conns := []api.Connection{
+		conn("x", "1", "2"),
+		conn("y", "2", "3"),
+		conn("z", "3", "1"),
+		conn("s", "4", "4"),
+		conn("e", "3", "99"),
+	}
+
+	report := BuildDependencyGraph(api.Roadmap{ID: "9"}, bars, conns)
+
+	if len(report.Cycles) != 2 {
+		t.Fatalf("expected 2 cycles, got %v", report.Cycles)
+	}
+	if got := depNames(report.Cycles[0]); got != "A,B,C" {
+		t.Errorf("first cycle = %s", got)
+	}
+	if got := depNames(report.Cycles[1]); got != `D "quoted"` {
+		t.Errorf("self-loop cycle = %s", got)
+	}
+	for _, e := range report.Edges {
+		if want := e.To != "99"; e.InCycle != want {
+			t.Errorf("edge %s->%s in_cycle = %v, want %v", e.From, e.To, e.InCycle, want)
+		}
+	}
+	if got := depNames(report.CriticalPath.Bars); got != "C,Bar 99" {
+		t.Errorf("critical path should skip cycle edges, got %s", got)
+	}
+	if last := report.Bars[len(report.Bars)-1]; !last.External || last.ID != "99" {
+		t.Errorf("expected external bar 99, got %+v", last)
+	}
+	if !strings.Contains(report.Mermaid, "#quot;quoted#quot;") || !strings.Contains(report.Mermaid, "cycle") {
+		t.Errorf("unexpected mermaid:\n%s", report.Mermaid)
+	}
+}
+
+func TestBuildDependencyGraphEmpty(t *testing.T) {
+	report := BuildDependencyGraph(api.Roadmap{ID: "9"}, decodeBars(t, `[{"id": 1, "name": "Solo"}]`), nil)
+	if len(report.Edges) != 0 || len(report.CriticalPath.Bars) != 0 || report.BarCount != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if report.Mermaid != "flowchart LR\n" {
+		t.Errorf("unexpected mermaid %q", report.Mermaid)
+	}
+}
+
+func TestAnalyzeDependencies(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/roadmaps/9":         `{"id": 9, "name": "Platform"}`,
+		"/roadmaps/9/bars":    `[{"id": 1, "name": "API"}, {"id": 2, "name": "UI"}]`,
+		"/bars/1/connections": `[{"id": 50, "target_bar_id": 2}]`,
+		"/bars/2/connections": `{"results": [{"id": 50, "source_bar_id": 1, "target_bar_id": 2}]}`,
+	})
+
+	report, err := AnalyzeDependencies(context.Background(), client, "9")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Roadmap != "Platform" || len(report.Edges) != 1 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if e := report.Edges[0]; e.From != "1" || e.To != "2" || e.ConnectionID != "50" {
+		t.Errorf("unexpected edge %+v", e)
+	}
+}
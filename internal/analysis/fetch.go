@@ -0,0 +1,77 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/pkg/productplan"
+)
+
+// RoadmapBars is a roadmap with its bars.
+type RoadmapBars struct {
+	Roadmap api.Roadmap
+	Bars    []api.Bar
+}
+
+// fetchRoadmapBars loads bars for the given roadmaps, or for every roadmap
+// when ids is empty. Roadmaps are fetched in parallel and returned in the
+// requested (or listed) order.
+func fetchRoadmapBars(ctx context.Context, client *api.Client, ids []string) ([]RoadmapBars, error) {
+	var roadmaps []api.Roadmap
+	if len(ids) == 0 {
+		var err error
+		if roadmaps, err = client.FetchRoadmaps(ctx); err != nil {
+			return nil, fmt.Errorf("roadmaps: %w", err)
+		}
+	} else {
+		roadmaps = make([]api.Roadmap, len(ids))
+		for i, id := range ids {
+			roadmaps[i] = api.Roadmap{ID: api.ID(id)}
+		}
+	}
+
+	fns := make([]func(ctx context.Context) (RoadmapBars, error), len(roadmaps))
+	for i, r := range roadmaps {
+		fns[i] = func(ctx context.Context) (RoadmapBars, error) {
+			id := r.ID.String()
+			if r.Name == "" {
+				full, err := client.FetchRoadmap(ctx, id)
+				if err != nil {
+					return RoadmapBars{}, fmt.Errorf("roadmap %s: %w", id, err)
+				}
+				r = full
+			}
+			bars, err := client.FetchRoadmapBars(ctx, id)
+			if err != nil {
+				return RoadmapBars{}, fmt.Errorf("roadmap %s bars: %w", id, err)
+			}
+			return RoadmapBars{Roadmap: r, Bars: bars}, nil
+		}
+	}
+	result := productplan.Execute(ctx, productplan.DefaultBatchConfig(), fns)
+	if result.HasErrors() {
+		return nil, result.Errors[0].Err
+	}
+	return result.Results, nil
+}
+
+// fetchKeyResults loads key results for each objective in parallel.
+// The result is aligned with objectives.
+func fetchKeyResults(ctx context.Context, client *api.Client, objectives []api.Objective) ([][]api.KeyResult, error) {
+	fns := make([]func(ctx context.Context) ([]api.KeyResult, error), len(objectives))
+	for i, o := range objectives {
+		fns[i] = func(ctx context.Context) ([]api.KeyResult, error) {
+			krs, err := client.FetchKeyResults(ctx, o.ID.String())
+			if err != nil {
+				return nil, fmt.Errorf("objective %s key results: %w", o.ID, err)
+			}
+			return krs, nil
+		}
+	}
+	result := productplan.Execute(ctx, productplan.DefaultBatchConfig(), fns)
+	if result.HasErrors() {
+		return nil, result.Errors[0].Err
+	}
+	return result.Results, nil
+}
@@ -0,0 +1,286 @@
+// Package analysis computes derived views over ProductPlan data — OKR
+// progress, dependency graphs, schedule conflicts and the like — so that
+// tools return consistent numbers instead of leaving arithmetic to the model.
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+)
+
+// Objective statuses reported by OKRProgress.
+const (
+	StatusComplete    = "complete"
+	StatusOnTrack     = "on_track"
+	StatusAtRisk      = "at_risk"
+	StatusNotStarted  = "not_started"
+	StatusNoData      = "no_data"
+	StatusNoTimeFrame = "no_time_frame"
+)
+
+// DefaultRiskMargin is how far, in percentage points, progress may trail
+// elapsed time before an objective is flagged at risk.
+const DefaultRiskMargin = 10.0
+
+// OKROptions selects and tunes an OKR progress rollup.
+type OKROptions struct {
+	// ObjectiveIDs limits the rollup to these objectives. Empty means all.
+	ObjectiveIDs []string
+	// TimeFrame limits the rollup to objectives with this time frame label
+	// (case-insensitive). Empty means all.
+	TimeFrame string
+	// RiskMargin is the allowed gap between elapsed and progress, in
+	// percentage points. Zero means DefaultRiskMargin.
+	RiskMargin float64
+	// Now is the evaluation date. Zero means today.
+	Now time.Time
+	// Calendar places the quarters, halves and years time frames name.
+	Calendar dates.Calendar
+}
+
+// KeyResultProgress is one key result with its computed progress.
+type KeyResultProgress struct {
+	ID           api.ID   `json:"id"`
+	Name         string   `json:"name"`
+	StartValue   *float64 `json:"start_value"`
+	CurrentValue *float64 `json:"current_value"`
+	TargetValue  *float64 `json:"target_value"`
+	ProgressPct  *float64 `json:"progress_pct"`
+	// Note explains why ProgressPct is missing.
+	Note string `json:"note,omitempty"`
+}
+
+// ObjectiveProgress is an objective's key results rolled up.
+type ObjectiveProgress struct {
+	ID          api.ID              `json:"id"`
+	Name        string              `json:"name"`
+	TimeFrame   string              `json:"time_frame"`
+	ProgressPct *float64            `json:"progress_pct"`
+	ElapsedPct  *float64            `json:"elapsed_pct"`
+	Status      string              `json:"status"`
+	KeyResults  []KeyResultProgress `json:"key_results"`
+}
+
+// TimeFrameProgress rolls objectives up by time frame label.
+type TimeFrameProgress struct {
+	TimeFrame   string   `json:"time_frame"`
+	Objectives  int      `json:"objectives"`
+	ProgressPct *float64 `json:"progress_pct"`
+	ElapsedPct  *float64 `json:"elapsed_pct"`
+	AtRisk      int      `json:"at_risk"`
+}
+
+// OKRReport is the result of OKRProgress.
+type OKRReport struct {
+	AsOf        string              `json:"as_of"`
+	RiskMargin  float64             `json:"risk_margin"`
+	AtRiskCount int                 `json:"at_risk_count"`
+	Objectives  []ObjectiveProgress `json:"objectives"`
+	TimeFrames  []TimeFrameProgress `json:"time_frames"`
+}
+
+// OKRProgress fetches objectives and their key results and computes
+// progress per key result, objective and time frame.
+func OKRProgress(ctx context.Context, client *api.Client, opts OKROptions) (*OKRReport, error) {
+	objectives, err := client.FetchObjectives(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("objectives: %w", err)
+	}
+	objectives = filterObjectives(objectives, opts)
+
+	keyResults, err := fetchKeyResults(ctx, client, objectives)
+	if err != nil {
+		return nil, err
+	}
+	return RollUpOKRs(objectives, keyResults, opts), nil
+}
+
+// filterObjectives applies the ID and time frame filters.
+func filterObjectives(objectives []api.Objective, opts OKROptions) []api.Objective {
+	ids := make(map[string]bool, len(opts.ObjectiveIDs))
+	for _, id := range opts.ObjectiveIDs {
+		ids[id] = true
+	}
+	var out []api.Objective
+	for _, o := range objectives {
+		if len(ids) > 0 && !ids[o.ID.String()] {
+			continue
+		}
+		if opts.TimeFrame != "" && !strings.EqualFold(strings.TrimSpace(o.TimeFrame), strings.TrimSpace(opts.TimeFrame)) {
+			continue
+		}
+		out = append(out, o)
+	}
+	return out
+}
+
+// RollUpOKRs computes the report from already-fetched data. keyResults[i]
+// belongs to objectives[i].
+func RollUpOKRs(objectives []api.Objective, keyResults [][]api.KeyResult, opts OKROptions) *OKRReport {
+	today := opts.Now
+	if today.IsZero() {
+		today = time.Now()
+	}
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	margin := opts.RiskMargin
+	if margin == 0 {
+		margin = DefaultRiskMargin
+	}
+
+	report := &OKRReport{AsOf: today.Format(time.DateOnly), RiskMargin: margin, Objectives: []ObjectiveProgress{}}
+	for i, o := range objectives {
+		op := objectiveProgress(o, keyResults[i], today, margin, opts.Calendar)
+		if op.Status == StatusAtRisk {
+			report.AtRiskCount++
+		}
+		report.Objectives = append(report.Objectives, op)
+	}
+	report.TimeFrames = rollUpTimeFrames(report.Objectives, opts.Calendar)
+	return report
+}
+
+// objectiveProgress averages key result progress and compares it with the
+// time elapsed in the objective's time frame.
+func objectiveProgress(o api.Objective, krs []api.KeyResult, today time.Time, margin float64, cal dates.Calendar) ObjectiveProgress {
+	op := ObjectiveProgress{ID: o.ID, Name: o.Name, TimeFrame: o.TimeFrame, KeyResults: make([]KeyResultProgress, len(krs))}
+	var sum float64
+	var measured int
+	for i, kr := range krs {
+		op.KeyResults[i] = keyResultProgress(kr)
+		if p := op.KeyResults[i].ProgressPct; p != nil {
+			sum += *p
+			measured++
+		}
+	}
+	if measured > 0 {
+		op.ProgressPct = round1(sum / float64(measured))
+	}
+
+	period, hasPeriod := ParseTimeFrame(o.TimeFrame, cal)
+	if hasPeriod {
+		op.ElapsedPct = round1(period.Elapsed(today) * 100)
+	}
+
+	switch {
+	case op.ProgressPct == nil:
+		op.Status = StatusNoData
+	case *op.ProgressPct >= 100:
+		op.Status = StatusComplete
+	case !hasPeriod:
+		op.Status = StatusNoTimeFrame
+	case today.Before(period.Start):
+		op.Status = StatusNotStarted
+	case *op.ElapsedPct-*op.ProgressPct > margin:
+		op.Status = StatusAtRisk
+	default:
+		op.Status = StatusOnTrack
+	}
+	return op
+}
+
+// keyResultProgress computes (current - start) / (target - start), clamped
+// to [0, 100]%. This handles decreasing targets (e.g. churn from 8% to 5%)
+// as well as increasing ones. A missing start value counts as zero.
+func keyResultProgress(kr api.KeyResult) KeyResultProgress {
+	p := KeyResultProgress{ID: kr.ID, Name: kr.Name}
+	p.StartValue, _ = parseNumber(string(kr.StartValue))
+	p.CurrentValue, _ = parseNumber(string(kr.CurrentValue))
+	p.TargetValue, _ = parseNumber(string(kr.TargetValue))
+
+	start := 0.0
+	if p.StartValue != nil {
+		start = *p.StartValue
+	}
+	switch {
+	case p.TargetValue == nil:
+		p.Note = "target_value is missing or not a number"
+	case p.CurrentValue == nil:
+		p.Note = "current_value is missing or not a number"
+	case *p.TargetValue == start:
+		p.Note = "target_value equals the starting value"
+	default:
+		p.ProgressPct = round1(clamp((*p.CurrentValue-start)/(*p.TargetValue-start), 0, 1) * 100)
+	}
+	return p
+}
+
+// rollUpTimeFrames averages objective progress per time frame label,
+// ordered by period start, with unparseable labels last.
+func rollUpTimeFrames(objectives []ObjectiveProgress, cal dates.Calendar) []TimeFrameProgress {
+	type acc struct {
+		tf       TimeFrameProgress
+		sum      float64
+		measured int
+	}
+	byLabel := make(map[string]*acc)
+	var labels []string
+	for _, o := range objectives {
+		label := strings.TrimSpace(o.TimeFrame)
+		a, ok := byLabel[label]
+		if !ok {
+			a = &acc{tf: TimeFrameProgress{TimeFrame: label, ElapsedPct: o.ElapsedPct}}
+			byLabel[label] = a
+			labels = append(labels, label)
+		}
+		a.tf.Objectives++
+		if o.ProgressPct != nil {
+			a.sum += *o.ProgressPct
+			a.measured++
+		}
+		if o.Status == StatusAtRisk {
+			a.tf.AtRisk++
+		}
+	}
+
+	sort.SliceStable(labels, func(i, j int) bool {
+		pi, oki := ParseTimeFrame(labels[i], cal)
+		pj, okj := ParseTimeFrame(labels[j], cal)
+		if oki != okj {
+			return oki
+		}
+		if !pi.Start.Equal(pj.Start) {
+			return pi.Start.Before(pj.Start)
+		}
+		return labels[i] < labels[j]
+	})
+
+	out := make([]TimeFrameProgress, len(labels))
+	for i, label := range labels {
+		a := byLabel[label]
+		if a.measured > 0 {
+			a.tf.ProgressPct = round1(a.sum / float64(a.measured))
+		}
+		out[i] = a.tf
+	}
+	return out
+}
+
+// parseNumber reads a key result value, tolerating the decorations people
+// type into value fields: "45%", "$1,200", " 3.5 ".
+func parseNumber(s string) (*float64, bool) {
+	s = strings.TrimSpace(s)
+	s = strings.NewReplacer(",", "", "%", "", "$", "", "€", "", "£", "").Replace(s)
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, false
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil, false
+	}
+	return &v, true
+}
+
+// round1 rounds to one decimal place.
+func round1(v float64) *float64 {
+	r := math.Round(v*10) / 10
+	return &r
+}
@@ -0,0 +1,135 @@
+package analysis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+)
+
+func pct(p *float64) float64 {
+	if p == nil {
+		return -1
+	}
+	return *p
+}
+
+func TestKeyResultProgress(t *testing.T) {
+	tests := []struct {
+		name                   string
+		start, current, target api.Value
+		want                   float64
+		note                   bool
+	}{
+		{"increasing", "", "50", "200", 25, false},
+		{"with start", "100", "150", "200", 50, false},
+		{"decreasing", "8%", "6.5%", "5%", 50, false},
+		{"overshoot", "0", "300", "200", 100, false},
+		{"backwards", "100", "80", "200", 0, false},
+		{"formatted", "$1,000", "$1,500", "$2,000", 50, false},
+		{"no target", "", "5", "", -1, true},
+		{"no current", "", "", "10", -1, true},
+		{"flat", "10", "10", "10", -1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := keyResultProgress(api.KeyResult{StartValue: tt.start, CurrentValue: tt.current, TargetValue: tt.target})
+			if got := pct(p.ProgressPct); got != tt.want {
+				t.Errorf("progress = %v, want %v", got, tt.want)
+			}
+			if (p.Note != "") != tt.note {
+				t.Errorf("unexpected note %q", p.Note)
+			}
+		})
+	}
+}
+
+func TestRollUpOKRs(t *testing.T) {
+	// 2026-08-15 is 46 of 92 days into Q3: 50% elapsed.
+	now := time.Date(2026, 8, 15, 12, 0, 0, 0, time.UTC)
+	objectives := []api.Objective{
+		{ID: "1", Name: "Behind", TimeFrame: "Q3 2026"},
+		{ID: "2", Name: "Ahead", TimeFrame: "Q3 2026"},
+		{ID: "3", Name: "Future", TimeFrame: "Q1 2027"},
+		{ID: "4", Name: "Done", TimeFrame: "Q3 2026"},
+		{ID: "5", Name: "Empty", TimeFrame: "Q3 2026"},
+		{ID: "6", Name: "Vague", TimeFrame: "Someday"},
+	}
+	keyResults := [][]api.KeyResult{
+		{{ID: "a", CurrentValue: "20", TargetValue: "100"}, {ID: "b", CurrentValue: "40", TargetValue: "100"}},
+		{{ID: "c", CurrentValue: "45", TargetValue: "100"}},
+		{{ID: "d", CurrentValue: "0", TargetValue: "100"}},
+		{{ID: "e", CurrentValue: "100", TargetValue: "100"}},
+		{},
+		{{ID: "f", CurrentValue: "10", TargetValue: "100"}},
+	}
+
+	report := RollUpOKRs(objectives, keyResults, OKROptions{Now: now})
+
+	want := []struct {
+		status   string
+		progress float64
+	}{
+		{StatusAtRisk, 30},
+		{StatusOnTrack, 45},
+		{StatusNotStarted, 0},
+		{StatusComplete, 100},
+		{StatusNoData, -1},
+		{StatusNoTimeFrame, 10},
+	}
+	for i, w := range want {
+		o := report.Objectives[i]
+		if o.Status != w.status || pct(o.ProgressPct) != w.progress {
+			t.Errorf("%s: got %s/%v, want %s/%v", o.Name, o.Status, pct(o.ProgressPct), w.status, w.progress)
+		}
+	}
+	if report.AtRiskCount != 1 || report.AsOf != "2026-08-15" || report.RiskMargin != DefaultRiskMargin {
+		t.Errorf("unexpected report header %+v", report)
+	}
+	if pct(report.Objectives[0].ElapsedPct) != 50 {
+		t.Errorf("expected 50%% elapsed, got %v", pct(report.Objectives[0].ElapsedPct))
+	}
+
+	if len(report.TimeFrames) != 3 {
+		t.Fatalf("expected 3 time frames, got %+v", report.TimeFrames)
+	}
+	q3 := report.TimeFrames[0]
+	if q3.TimeFrame != "Q3 2026" || q3.Objectives != 4 || q3.AtRisk != 1 || pct(q3.ProgressPct) != 58.3 {
+		t.Errorf("unexpected Q3 rollup %+v (progress %v)", q3, pct(q3.ProgressPct))
+	}
+	if report.TimeFrames[1].TimeFrame != "Q1 2027" || report.TimeFrames[2].TimeFrame != "Someday" {
+		t.Errorf("expected chronological order with unparseable labels last, got %+v", report.TimeFrames)
+	}
+
+	// A wider margin tolerates the gap.
+	relaxed := RollUpOKRs(objectives, keyResults, OKROptions{Now: now, RiskMargin: 25})
+	if relaxed.Objectives[0].Status != StatusOnTrack {
+		t.Errorf("expected on_track with 25pt margin, got %s", relaxed.Objectives[0].Status)
+	}
+}
+
+func TestOKRProgress(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/strategy/objectives":               `[{"id": 1, "name": "Grow", "time_frame": "Q3 2026"}, {"id": 2, "name": "Other", "time_frame": "Q4 2026"}]`,
+		"/strategy/objectives/1/key_results": `{"results": [{"id": 11, "name": "MRR", "starting_value": 100, "current_value": 150, "target_value": 200}]}`,
+	})
+
+	report, err := OKRProgress(context.Background(), client, OKROptions{TimeFrame: "q3 2026", Now: time.Date(2026, 8, 15, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Objectives) != 1 || pct(report.Objectives[0].ProgressPct) != 50 {
+		t.Errorf("unexpected report %+v", report.Objectives)
+	}
+}
+
+func TestOKRProgressKeyResultError(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/strategy/objectives": `[{"id": 1, "name": "Grow"}]`,
+	})
+	if _, err := OKRProgress(context.Background(), client, OKROptions{}); err == nil {
+		t.Error("expected key result fetch error")
+	}
+}
@@ -0,0 +1,256 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+)
+
+// Readiness verdicts.
+const (
+	// ReadinessGo means every task is on track.
+	ReadinessGo = "go"
+	// ReadinessAtRisk means nothing is late yet, but something needs an owner
+	// or a date.
+	ReadinessAtRisk = "at_risk"
+	// ReadinessNoGo means tasks are overdue or the launch date has passed
+	// with work open.
+	ReadinessNoGo = "no_go"
+)
+
+// ReadinessVerdicts lists the verdicts from best to worst.
+var ReadinessVerdicts = []string{ReadinessGo, ReadinessAtRisk, ReadinessNoGo}
+
+// unsectioned names the group for tasks outside any known section.
+const unsectioned = "Unsectioned"
+
+// SectionReadiness is the completion of one checklist section.
+type SectionReadiness struct {
+	ID      api.ID   `json:"id,omitempty"`
+	Name    string   `json:"name"`
+	Tasks   int      `json:"tasks"`
+	Done    int      `json:"done"`
+	Percent *float64 `json:"percent"`
+}
+
+// ReadinessTask is an open task that needs attention.
+type ReadinessTask struct {
+	ID       api.ID `json:"id"`
+	Name     string `json:"name"`
+	Section  string `json:"section"`
+	Status   string `json:"status,omitempty"`
+	DueDate  string `json:"due_date,omitempty"`
+	Assignee string `json:"assignee,omitempty"`
+	// DaysOverdue is set for overdue tasks.
+	DaysOverdue int `json:"days_overdue,omitempty"`
+}
+
+// LaunchReadiness is the result of AssessLaunch.
+type LaunchReadiness struct {
+	AsOf   string `json:"as_of"`
+	ID     api.ID `json:"id"`
+	Name   string `json:"name"`
+	Date   string `json:"date,omitempty"`
+	Status string `json:"status,omitempty"`
+	// DaysUntilLaunch is negative once the date has passed, and nil when
+	// the launch has no date.
+	DaysUntilLaunch *int               `json:"days_until_launch"`
+	Tasks           int                `json:"tasks"`
+	Done            int                `json:"done"`
+	Percent         *float64           `json:"percent"`
+	Sections        []SectionReadiness `json:"sections"`
+	Overdue         []ReadinessTask    `json:"overdue"`
+	Unassigned      []ReadinessTask    `json:"unassigned"`
+	// DueAfterLaunch lists open tasks due after the launch date.
+	DueAfterLaunch []ReadinessTask `json:"due_after_launch"`
+	Verdict        string          `json:"verdict"`
+	Reasons        []string        `json:"reasons"`
+	// Warning explains why assignees show as IDs instead of names.
+	Warning string `json:"warning,omitempty"`
+}
+
+// LaunchReadinessReport fetches a launch, its sections, tasks and the
+// account's users in parallel and assesses readiness. When users cannot be
+// loaded, assignees show as IDs and the report carries a warning rather
+// than failing. A zero now means today.
+func LaunchReadinessReport(ctx context.Context, client *api.Client, launchID string, now time.Time) (*LaunchReadiness, error) {
+	var wg sync.WaitGroup
+	var launch api.Launch
+	var sections []api.LaunchSection
+	var tasks []api.LaunchTask
+	var names map[api.ID]string
+	var launchErr, sectionsErr, tasksErr, usersErr error
+
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		launch, launchErr = client.FetchLaunch(ctx, launchID)
+	}()
+	go func() {
+		defer wg.Done()
+		sections, sectionsErr = client.FetchLaunchSections(ctx, launchID)
+	}()
+	go func() {
+		defer wg.Done()
+		tasks, tasksErr = client.FetchLaunchTasks(ctx, launchID)
+	}()
+	go func() {
+		defer wg.Done()
+		names, usersErr = fetchUserNames(ctx, client)
+	}()
+	wg.Wait()
+
+	if launchErr != nil {
+		return nil, fmt.Errorf("launch %s: %w", launchID, launchErr)
+	}
+	if sectionsErr != nil {
+		return nil, fmt.Errorf("launch %s sections: %w", launchID, sectionsErr)
+	}
+	if tasksErr != nil {
+		return nil, fmt.Errorf("launch %s tasks: %w", launchID, tasksErr)
+	}
+	r := AssessLaunch(launch, sections, tasks, names, now)
+	if usersErr != nil {
+		r.Warning = fmt.Sprintf("assignees show as IDs: %v", usersErr)
+	}
+	return r, nil
+}
+
+// AssessLaunch computes per-section completion, lists overdue, unassigned
+// and late-scheduled open tasks, and gives a verdict: no_go when a task is
+// overdue or the launch date has passed with tasks open, at_risk when open
+// tasks lack an assignee or are due after launch, otherwise go. users maps
+// user IDs to names; unknown assignees show as their ID.
+func AssessLaunch(launch api.Launch, sections []api.LaunchSection, tasks []api.LaunchTask, users map[api.ID]string, now time.Time) *LaunchReadiness {
+	today := now
+	if today.IsZero() {
+		today = time.Now()
+	}
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+
+	r := &LaunchReadiness{
+		AsOf:           today.Format(time.DateOnly),
+		ID:             launch.ID,
+		Name:           launch.Name,
+		Date:           launch.Date,
+		Status:         launch.Status,
+		Sections:       []SectionReadiness{},
+		Overdue:        []ReadinessTask{},
+		Unassigned:     []ReadinessTask{},
+		DueAfterLaunch: []ReadinessTask{},
+		Reasons:        []string{},
+	}
+	launchDate, hasDate := api.ParseDate(launch.Date)
+	if hasDate {
+		d := daysBetween(today, launchDate)
+		r.DaysUntilLaunch = &d
+	}
+
+	sorted := append([]api.LaunchSection(nil), sections...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Position < sorted[j].Position })
+	bySection := make(map[api.ID]*SectionReadiness, len(sorted))
+	for _, s := range sorted {
+		r.Sections = append(r.Sections, SectionReadiness{ID: s.ID, Name: s.Name})
+	}
+	for i := range r.Sections {
+		bySection[r.Sections[i].ID] = &r.Sections[i]
+	}
+	var loose *SectionReadiness
+
+	for _, t := range tasks {
+		sec := bySection[t.SectionID]
+		if sec == nil {
+			if loose == nil {
+				loose = &SectionReadiness{Name: unsectioned}
+			}
+			sec = loose
+		}
+		sec.Tasks++
+		r.Tasks++
+		if isClosed(t.Status) {
+			sec.Done++
+			r.Done++
+			continue
+		}
+
+		ref := ReadinessTask{ID: t.ID, Name: t.Name, Section: sec.Name, Status: t.Status, DueDate: t.DueDate}
+		if t.AssignedUserID != "" {
+			ref.Assignee = ownerOr(users[t.AssignedUserID], t.AssignedUserID.String())
+		}
+		if due, ok := api.ParseDate(t.DueDate); ok {
+			if due.Before(today) {
+				over := ref
+				over.DaysOverdue = daysBetween(due, today)
+				r.Overdue = append(r.Overdue, over)
+			} else if hasDate && due.After(launchDate) {
+				r.DueAfterLaunch = append(r.DueAfterLaunch, ref)
+			}
+		}
+		if ref.Assignee == "" {
+			r.Unassigned = append(r.Unassigned, ref)
+		}
+	}
+	if loose != nil {
+		r.Sections = append(r.Sections, *loose)
+	}
+	for i := range r.Sections {
+		r.Sections[i].Percent = percentDone(r.Sections[i].Done, r.Sections[i].Tasks)
+	}
+	r.Percent = percentDone(r.Done, r.Tasks)
+	sort.SliceStable(r.Overdue, func(i, j int) bool { return r.Overdue[i].DaysOverdue > r.Overdue[j].DaysOverdue })
+
+	r.Verdict, r.Reasons = readinessVerdict(r)
+	return r
+}
+
+// readinessVerdict applies the go/no-go rules in order of severity.
+func readinessVerdict(r *LaunchReadiness) (string, []string) {
+	open := r.Tasks - r.Done
+	var noGo, atRisk []string
+	if n := len(r.Overdue); n > 0 {
+		noGo = append(noGo, fmt.Sprintf("%d %s overdue", n, pluralize("task", n)))
+	}
+	if r.DaysUntilLaunch != nil && *r.DaysUntilLaunch < 0 && open > 0 {
+		noGo = append(noGo, fmt.Sprintf("launch date passed %d %s ago with %d open %s", -*r.DaysUntilLaunch, pluralize("day", -*r.DaysUntilLaunch), open, pluralize("task", open)))
+	}
+	if n := len(r.Unassigned); n > 0 {
+		atRisk = append(atRisk, fmt.Sprintf("%d open %s unassigned", n, pluralize("task", n)))
+	}
+	if n := len(r.DueAfterLaunch); n > 0 {
+		atRisk = append(atRisk, fmt.Sprintf("%d open %s due after the launch date", n, pluralize("task", n)))
+	}
+	if r.Tasks == 0 {
+		atRisk = append(atRisk, "launch has no checklist tasks")
+	}
+	switch {
+	case len(noGo) > 0:
+		return ReadinessNoGo, append(noGo, atRisk...)
+	case len(atRisk) > 0:
+		return ReadinessAtRisk, atRisk
+	case open == 0:
+		return ReadinessGo, []string{"all tasks done"}
+	default:
+		return ReadinessGo, []string{fmt.Sprintf("%d open %s on schedule and assigned", open, pluralize("task", open))}
+	}
+}
+
+// percentDone returns done/total as a percentage to one decimal, or nil for
+// an empty total.
+func percentDone(done, total int) *float64 {
+	if total == 0 {
+		return nil
+	}
+	return round1(float64(done) * 100 / float64(total))
+}
+
+// pluralize appends "s" to word unless n is 1.
+func pluralize(word string, n int) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
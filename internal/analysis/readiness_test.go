@@ -0,0 +1,90 @@
+package analysis
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+)
+
+func TestAssessLaunch(t *testing.T) {
+	now := time.Date(2026, 6, 10, 9, 0, 0, 0, time.UTC)
+	launch := api.Launch{ID: "1", Name: "v2", Date: "2026-06-20"}
+	sections := []api.LaunchSection{{ID: "11", Name: "Marketing", Position: 2}, {ID: "10", Name: "Engineering", Position: 1}}
+	tasks := []api.LaunchTask{
+		{ID: "100", Name: "Code freeze", SectionID: "10", Status: "completed", AssignedUserID: "7"},
+		{ID: "101", Name: "Load test", SectionID: "10", DueDate: "2026-06-05", AssignedUserID: "7"},
+		{ID: "102", Name: "Blog post", SectionID: "11", DueDate: "2026-06-25"},
+		{ID: "103", Name: "Press kit", SectionID: "99", AssignedUserID: "8"},
+	}
+
+	r := AssessLaunch(launch, sections, tasks, map[api.ID]string{"7": "Ana Lee"}, now)
+	if r.DaysUntilLaunch == nil || *r.DaysUntilLaunch != 10 {
+		t.Errorf("days until launch = %v, want 10", r.DaysUntilLaunch)
+	}
+	if r.Tasks != 4 || r.Done != 1 || pct(r.Percent) != 25 {
+		t.Errorf("totals = %d/%d %v", r.Done, r.Tasks, r.Percent)
+	}
+	var names []string
+	for _, s := range r.Sections {
+		names = append(names, s.Name)
+	}
+	if strings.Join(names, ",") != "Engineering,Marketing,Unsectioned" || pct(r.Sections[0].Percent) != 50 {
+		t.Errorf("unexpected sections %+v", r.Sections)
+	}
+	if len(r.Overdue) != 1 || r.Overdue[0].DaysOverdue != 5 || r.Overdue[0].Assignee != "Ana Lee" {
+		t.Errorf("unexpected overdue %+v", r.Overdue)
+	}
+	if len(r.Unassigned) != 1 || r.Unassigned[0].ID != "102" {
+		t.Errorf("unexpected unassigned %+v", r.Unassigned)
+	}
+	if len(r.DueAfterLaunch) != 1 || r.DueAfterLaunch[0].ID != "102" {
+		t.Errorf("unexpected due after launch %+v", r.DueAfterLaunch)
+	}
+	if r.Verdict != ReadinessNoGo || len(r.Reasons) != 3 || r.Reasons[0] != "1 task overdue" {
+		t.Errorf("verdict = %s %v", r.Verdict, r.Reasons)
+	}
+
+	// With the overdue task done, only the unassigned and late task remain.
+	tasks[1].Status = "done"
+	if r = AssessLaunch(launch, sections, tasks, nil, now); r.Verdict != ReadinessAtRisk {
+		t.Errorf("verdict = %s %v, want at_risk", r.Verdict, r.Reasons)
+	}
+	if r.Unassigned[0].Assignee != "" || len(r.Overdue) != 0 {
+		t.Errorf("unexpected report %+v", r)
+	}
+
+	if r = AssessLaunch(launch, sections, tasks[:2], nil, now); r.Verdict != ReadinessGo || r.Reasons[0] != "all tasks done" {
+		t.Errorf("verdict = %s %v, want go", r.Verdict, r.Reasons)
+	}
+}
+
+func TestLaunchReadinessReport(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/launches/1":                    `{"id": 1, "name": "v2", "date": "2026-06-01"}`,
+		"/launches/1/checklist_sections": `[{"id": 10, "name": "Engineering"}]`,
+		"/launches/1/tasks":              `[{"id": 100, "name": "Docs", "section_id": 10, "assigned_user_id": 8}]`,
+	})
+
+	// /users is missing: assignees fall back to their IDs.
+	r, err := LaunchReadinessReport(context.Background(), client, "1", time.Date(2026, 6, 3, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *r.DaysUntilLaunch != -2 || r.Verdict != ReadinessNoGo || r.Reasons[0] != "launch date passed 2 days ago with 1 open task" {
+		t.Errorf("unexpected report %+v", r)
+	}
+	if r.Sections[0].Tasks != 1 || len(r.Unassigned) != 0 {
+		t.Errorf("unexpected sections %+v", r.Sections)
+	}
+	if !strings.HasPrefix(r.Warning, "assignees show as IDs: users:") {
+		t.Errorf("expected a warning about the missing users, got %q", r.Warning)
+	}
+
+	if _, err = LaunchReadinessReport(context.Background(), client, "2", time.Time{}); err == nil {
+		t.Error("expected error for a missing launch")
+	}
+}
@@ -0,0 +1,357 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/estimates"
+)
+
+// Scoring models for ScoreOpportunities.
+const (
+	ModelRICE     = "rice"
+	ModelICE      = "ice"
+	ModelWeighted = "weighted"
+)
+
+// ScoringModels lists the supported models.
+var ScoringModels = []string{ModelRICE, ModelICE, ModelWeighted}
+
+// Criteria the weighted model derives from ProductPlan data. Any other
+// criterion name is read from the opportunity's estimates.
+const (
+	CriterionIdeas  = "ideas"
+	CriterionDemand = "demand"
+)
+
+// Reach sources, in order of preference.
+const (
+	ReachEstimate  = "estimate"
+	ReachCustomers = "customers"
+	ReachIdeas     = "ideas"
+)
+
+// ScoringOptions selects the model and what to score.
+type ScoringOptions struct {
+	Model string
+	// Weights are criterion weights for the weighted model. A negative
+	// weight marks a cost, such as effort.
+	Weights map[string]float64
+	// OpportunityIDs limits the ranking to these opportunities. Empty means
+	// all open ones.
+	OpportunityIDs []string
+	// CustomerValues weights customer demand as in RankDemand.
+	CustomerValues map[string]float64
+	IncludeClosed  bool
+	// WriteBack writes each score into its opportunity's description.
+	WriteBack bool
+	// Now dates the written score. Zero means today.
+	Now time.Time
+}
+
+// ScoreInputs are the values a score was computed from.
+type ScoreInputs struct {
+	Ideas     int     `json:"ideas"`
+	Customers int     `json:"customers"`
+	Demand    float64 `json:"demand"`
+	// Reach is the RICE reach and ReachSource where it came from.
+	Reach       *float64           `json:"reach,omitempty"`
+	ReachSource string             `json:"reach_source,omitempty"`
+	Impact      *float64           `json:"impact,omitempty"`
+	Confidence  *float64           `json:"confidence,omitempty"`
+	Effort      *float64           `json:"effort,omitempty"`
+	Ease        *float64           `json:"ease,omitempty"`
+	Criteria    map[string]float64 `json:"criteria,omitempty"`
+}
+
+// OpportunityScore is one opportunity in the ranked backlog.
+type OpportunityScore struct {
+	// Rank is 1-based among scored opportunities; unscored ones have none.
+	Rank             int    `json:"rank,omitempty"`
+	ID               api.ID `json:"id"`
+	ProblemStatement string `json:"problem_statement"`
+	Status           string `json:"status,omitempty"`
+	// Score is nil when an input is missing; Missing names the inputs.
+	Score   *float64    `json:"score"`
+	Missing []string    `json:"missing,omitempty"`
+	Inputs  ScoreInputs `json:"inputs"`
+}
+
+// ScoringReport is the result of ScoreOpportunities.
+type ScoringReport struct {
+	Model         string             `json:"model"`
+	Formula       string             `json:"formula"`
+	Weights       map[string]float64 `json:"weights,omitempty"`
+	Scored        int                `json:"scored"`
+	Unscored      int                `json:"unscored"`
+	Opportunities []OpportunityScore `json:"opportunities"`
+	// Written lists opportunities whose description was updated.
+	Written []api.ID `json:"written,omitempty"`
+}
+
+// formulas documents each model in the report.
+var formulas = map[string]string{
+	ModelRICE:     "reach × impact × confidence ÷ effort",
+	ModelICE:      "impact × (confidence × 10) × ease",
+	ModelWeighted: "Σ(weight × criterion) ÷ Σ|weight|; ideas and demand are scaled 0-10 against the highest",
+}
+
+// ScoreOpportunities fetches ideas and opportunities, scores them with the
+// stored estimates, and optionally writes the scores back.
+func ScoreOpportunities(ctx context.Context, client *api.Client, est map[string]estimates.Estimate, opts ScoringOptions) (*ScoringReport, error) {
+	ideas, err := client.FetchIdeas(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ideas: %w", err)
+	}
+	opps, err := client.FetchOpportunities(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("opportunities: %w", err)
+	}
+	report, err := ScoreBacklog(ideas, opps, est, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.WriteBack {
+		if report.Written, err = WriteScores(ctx, client, report, opps, opts.Now); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// ScoreBacklog scores opportunities from their linked ideas, customer
+// demand and estimates, and ranks them. Opportunities missing an input the
+// model needs are listed after the ranked ones with the inputs named.
+func ScoreBacklog(ideas []api.Idea, opps []api.Opportunity, est map[string]estimates.Estimate, opts ScoringOptions) (*ScoringReport, error) {
+	model := strings.ToLower(opts.Model)
+	if model == "" {
+		model = ModelRICE
+	}
+	if _, ok := formulas[model]; !ok {
+		return nil, fmt.Errorf("unknown model %q (use %s)", opts.Model, strings.Join(ScoringModels, ", "))
+	}
+	var weightSum float64
+	for _, w := range opts.Weights {
+		weightSum += math.Abs(w)
+	}
+	if model == ModelWeighted && weightSum == 0 {
+		return nil, fmt.Errorf("the weighted model needs weights, e.g. {%q: 1, %q: 2}", CriterionDemand, "strategic_fit")
+	}
+
+	demand := RankDemand(ideas, opps, DemandOptions{CustomerValues: opts.CustomerValues, Limit: len(opps) + 1, IncludeClosed: opts.IncludeClosed})
+	byOpp := make(map[api.ID]DemandEntry, len(demand.Opportunities))
+	for _, e := range demand.Opportunities {
+		byOpp[e.ID] = e
+	}
+
+	var rows []OpportunityScore
+	var maxIdeas int
+	var maxDemand float64
+	for _, o := range opps {
+		if !opts.IncludeClosed && isClosed(o.WorkflowStatus) {
+			continue
+		}
+		d := byOpp[o.ID]
+		e := est[o.ID.String()]
+		in := ScoreInputs{
+			Ideas:      max(d.Ideas, o.IdeasCount),
+			Customers:  d.Customers,
+			Demand:     d.Score,
+			Impact:     e.Impact,
+			Confidence: e.Confidence,
+			Effort:     e.Effort,
+			Ease:       e.Ease,
+			Criteria:   e.Criteria,
+		}
+		switch {
+		case e.Reach != nil:
+			in.Reach, in.ReachSource = e.Reach, ReachEstimate
+		case in.Customers > 0:
+			in.Reach, in.ReachSource = floatPtr(float64(in.Customers)), ReachCustomers
+		default:
+			in.Reach, in.ReachSource = floatPtr(float64(in.Ideas)), ReachIdeas
+		}
+		maxIdeas = max(maxIdeas, in.Ideas)
+		maxDemand = max(maxDemand, in.Demand)
+		rows = append(rows, OpportunityScore{ID: o.ID, ProblemStatement: o.ProblemStatement, Status: o.WorkflowStatus, Inputs: in})
+	}
+
+	if len(opts.OpportunityIDs) > 0 {
+		keep := make(map[string]bool, len(opts.OpportunityIDs))
+		for _, id := range opts.OpportunityIDs {
+			keep[id] = true
+		}
+		filtered := rows[:0]
+		for _, r := range rows {
+			if keep[r.ID.String()] {
+				filtered = append(filtered, r)
+			}
+		}
+		rows = filtered
+	}
+
+	for i := range rows {
+		in := rows[i].Inputs
+		var score float64
+		var missing []string
+		need := func(name string, v *float64) float64 {
+			if v == nil {
+				missing = append(missing, name)
+				return 0
+			}
+			return *v
+		}
+		switch model {
+		case ModelRICE:
+			reach, impact, confidence, effort := *in.Reach, need("impact", in.Impact), need("confidence", in.Confidence), need("effort", in.Effort)
+			if in.Effort != nil && effort <= 0 {
+				missing = append(missing, "effort")
+			}
+			if len(missing) == 0 {
+				score = reach * impact * confidence / effort
+			}
+		case ModelICE:
+			score = need("impact", in.Impact) * need("confidence", in.Confidence) * 10 * need("ease", in.Ease)
+		case ModelWeighted:
+			for _, name := range sortedKeys(opts.Weights) {
+				var v float64
+				switch name {
+				case CriterionIdeas:
+					v = scaleTo10(float64(in.Ideas), float64(maxIdeas))
+				case CriterionDemand:
+					v = scaleTo10(in.Demand, maxDemand)
+				default:
+					c, ok := in.Criteria[name]
+					if !ok {
+						missing = append(missing, name)
+						continue
+					}
+					v = c
+				}
+				score += opts.Weights[name] * v
+			}
+			score /= weightSum
+		}
+		if len(missing) > 0 {
+			rows[i].Missing = missing
+			continue
+		}
+		rows[i].Score = floatPtr(math.Round(score*100) / 100)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if (a.Score == nil) != (b.Score == nil) {
+			return a.Score != nil
+		}
+		if a.Score != nil && *a.Score != *b.Score {
+			return *a.Score > *b.Score
+		}
+		if a.Inputs.Demand != b.Inputs.Demand {
+			return a.Inputs.Demand > b.Inputs.Demand
+		}
+		return a.ID < b.ID
+	})
+	report := &ScoringReport{Model: model, Formula: formulas[model], Opportunities: []OpportunityScore{}}
+	if model == ModelWeighted {
+		report.Weights = opts.Weights
+	}
+	for _, r := range rows {
+		if r.Score != nil {
+			report.Scored++
+			r.Rank = report.Scored
+		} else {
+			report.Unscored++
+		}
+		report.Opportunities = append(report.Opportunities, r)
+	}
+	return report, nil
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func scaleTo10(v, highest float64) float64 {
+	if highest <= 0 {
+		return 0
+	}
+	return v / highest * 10
+}
+
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ScoreLinePrefix starts the description line WriteScores maintains.
+const ScoreLinePrefix = "Priority score"
+
+var (
+	scoreLineText = regexp.MustCompile(`(?m)^` + ScoreLinePrefix + ` \([^)]*\):.*$`)
+	scoreLineHTML = regexp.MustCompile(`<p>` + ScoreLinePrefix + ` \([^)]*\):.*?</p>`)
+)
+
+// ScoreLine renders the line written into a scored opportunity's description.
+func ScoreLine(report *ScoringReport, s OpportunityScore, today time.Time) string {
+	return fmt.Sprintf("%s (%s): %g, rank %d of %d (scored %s)",
+		ScoreLinePrefix, strings.ToUpper(report.Model), *s.Score, s.Rank, report.Scored, today.Format(time.DateOnly))
+}
+
+// WithScoreLine replaces the score line in an opportunity description, or
+// appends one. HTML descriptions get the line as a paragraph.
+func WithScoreLine(description, line string) string {
+	if strings.Contains(description, "<p>") {
+		para := "<p>" + line + "</p>"
+		if scoreLineHTML.MatchString(description) {
+			return scoreLineHTML.ReplaceAllLiteralString(description, para)
+		}
+		return description + para
+	}
+	if scoreLineText.MatchString(description) {
+		return scoreLineText.ReplaceAllLiteralString(description, line)
+	}
+	if strings.TrimSpace(description) == "" {
+		return line
+	}
+	return strings.TrimRight(description, "\n") + "\n\n" + line
+}
+
+// WriteScores writes each scored opportunity's score line into its
+// description, skipping descriptions that would not change, and returns the
+// opportunities it updated.
+func WriteScores(ctx context.Context, client *api.Client, report *ScoringReport, opps []api.Opportunity, now time.Time) ([]api.ID, error) {
+	if now.IsZero() {
+		now = time.Now()
+	}
+	descriptions := make(map[api.ID]string, len(opps))
+	for _, o := range opps {
+		descriptions[o.ID] = o.Description
+	}
+	written := []api.ID{}
+	for _, s := range report.Opportunities {
+		if s.Score == nil {
+			continue
+		}
+		old := descriptions[s.ID]
+		updated := WithScoreLine(old, ScoreLine(report, s, now))
+		if updated == old {
+			continue
+		}
+		if _, err := client.UpdateOpportunity(ctx, s.ID.String(), map[string]any{"description": updated}); err != nil {
+			return nil, fmt.Errorf("write score to opportunity %s (after %d written): %w", s.ID, len(written), err)
+		}
+		written = append(written, s.ID)
+	}
+	return written, nil
+}
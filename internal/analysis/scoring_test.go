@@ -0,0 +1,174 @@
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/estimates"
+)
+
+func f64(v float64) *float64 { return &v }
+
+func scoringFixture(t *testing.T) ([]api.Idea, []api.Opportunity) {
+	t.Helper()
+	var ideas []api.Idea
+	if err := json.Unmarshal([]byte(`[
+		{"id": 1, "customers": ["Acme", "Globex"], "opportunity_ids": [10]},
+		{"id": 2, "customers": ["Initech"], "opportunity_ids": [10]},
+		{"id": 3, "customers": ["Hooli"], "opportunity_ids": [11]}
+	]`), &ideas); err != nil {
+		t.Fatalf("decode ideas: %v", err)
+	}
+	var opps []api.Opportunity
+	if err := json.Unmarshal([]byte(`[
+		{"id": 10, "problem_statement": "Login is slow"},
+		{"id": 11, "problem_statement": "Reports are hard to share"},
+		{"id": 12, "problem_statement": "No mobile app", "ideas_count": 4},
+		{"id": 13, "problem_statement": "Done already", "workflow_status": "closed"}
+	]`), &opps); err != nil {
+		t.Fatalf("decode opportunities: %v", err)
+	}
+	return ideas, opps
+}
+
+func ranking(r *ScoringReport) string {
+	out := make([]string, len(r.Opportunities))
+	for i, o := range r.Opportunities {
+		out[i] = o.ID.String()
+	}
+	return strings.Join(out, ",")
+}
+
+func TestScoreBacklogRICE(t *testing.T) {
+	ideas, opps := scoringFixture(t)
+	est := map[string]estimates.Estimate{
+		"10": {Impact: f64(1), Confidence: f64(0.5), Effort: f64(2)},
+		"11": {Reach: f64(50), Impact: f64(2), Confidence: f64(0.8), Effort: f64(4)},
+		"12": {Impact: f64(3), Confidence: f64(1)},
+	}
+	report, err := ScoreBacklog(ideas, opps, est, ScoringOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Model != ModelRICE || report.Scored != 2 || report.Unscored != 1 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if ranking(report) != "11,10,12" {
+		t.Errorf("ranking = %s", ranking(report))
+	}
+	top, second, unscored := report.Opportunities[0], report.Opportunities[1], report.Opportunities[2]
+	if *top.Score != 20 || top.Rank != 1 || top.Inputs.ReachSource != ReachEstimate {
+		t.Errorf("unexpected top %+v", top)
+	}
+	if *second.Score != 0.75 || second.Inputs.ReachSource != ReachCustomers || *second.Inputs.Reach != 3 || second.Inputs.Ideas != 2 {
+		t.Errorf("unexpected second %+v", second)
+	}
+	if unscored.Score != nil || unscored.Rank != 0 || strings.Join(unscored.Missing, ",") != "effort" || unscored.Inputs.ReachSource != ReachIdeas {
+		t.Errorf("unexpected unscored %+v", unscored)
+	}
+}
+
+func TestScoreBacklogICEAndWeighted(t *testing.T) {
+	ideas, opps := scoringFixture(t)
+	est := map[string]estimates.Estimate{
+		"10": {Impact: f64(8), Confidence: f64(0.5), Ease: f64(2), Criteria: map[string]float64{"fit": 2}},
+		"11": {Impact: f64(5), Confidence: f64(0.9), Ease: f64(5), Criteria: map[string]float64{"fit": 10}},
+	}
+
+	ice, err := ScoreBacklog(ideas, opps, est, ScoringOptions{Model: "ICE", OpportunityIDs: []string{"10", "11"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ranking(ice) != "11,10" || *ice.Opportunities[0].Score != 225 || *ice.Opportunities[1].Score != 80 {
+		t.Errorf("unexpected ICE report %+v", ice.Opportunities)
+	}
+
+	weighted, err := ScoreBacklog(ideas, opps, est, ScoringOptions{
+		Model:   ModelWeighted,
+		Weights: map[string]float64{CriterionDemand: 1, "fit": 1},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// demand: 10 has 3 customers (10 after scaling), 11 has 1 (3.33).
+	if ranking(weighted) != "11,10,12" || *weighted.Opportunities[0].Score != 6.67 || *weighted.Opportunities[1].Score != 6 {
+		t.Errorf("unexpected weighted report %+v", weighted.Opportunities)
+	}
+	if strings.Join(weighted.Opportunities[2].Missing, ",") != "fit" {
+		t.Errorf("expected 12 to miss fit, got %+v", weighted.Opportunities[2])
+	}
+
+	if _, err := ScoreBacklog(ideas, opps, est, ScoringOptions{Model: ModelWeighted}); err == nil {
+		t.Error("expected error for weighted model without weights")
+	}
+	if _, err := ScoreBacklog(ideas, opps, est, ScoringOptions{Model: "wsjf"}); err == nil {
+		t.Error("expected error for unknown model")
+	}
+}
+
+func TestWithScoreLine(t *testing.T) {
+	line := "Priority score (RICE): 4, rank 1 of 2 (scored 2026-06-01)"
+	tests := []struct{ in, want string }{
+		{"", line},
+		{"Users wait 10s.\n", "Users wait 10s.\n\n" + line},
+		{"Users wait.\n\nPriority score (ICE): 80, rank 2 of 2 (scored 2026-05-01)\nMore notes", "Users wait.\n\n" + line + "\nMore notes"},
+		{"<p>Users wait.</p>", "<p>Users wait.</p><p>" + line + "</p>"},
+		{"<p>Users wait.</p><p>Priority score (RICE): 1, rank 2 of 2 (scored 2026-05-01)</p>", "<p>Users wait.</p><p>" + line + "</p>"},
+	}
+	for _, tt := range tests {
+		if got := WithScoreLine(tt.in, line); got != tt.want {
+			t.Errorf("WithScoreLine(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestScoreOpportunitiesWriteBack(t *testing.T) {
+	var patched []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPatch:
+			body, _ := io.ReadAll(r.Body)
+			patched = append(patched, r.URL.Path+" "+string(body))
+			_, _ = w.Write([]byte(`{}`))
+		case r.URL.Path == "/discovery/ideas":
+			_, _ = w.Write([]byte(`[]`))
+		case r.URL.Path == "/discovery/opportunities":
+			_, _ = w.Write([]byte(`[
+				{"id": 1, "problem_statement": "A", "description": "Context"},
+				{"id": 2, "problem_statement": "B", "description": "Priority score (ICE): 150, rank 1 of 2 (scored 2026-06-01)"},
+				{"id": 3, "problem_statement": "C"}
+			]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := api.New(api.Config{Token: "test-token", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	est := map[string]estimates.Estimate{
+		"1": {Impact: f64(5), Confidence: f64(0.5), Ease: f64(2)},
+		"2": {Impact: f64(5), Confidence: f64(1), Ease: f64(3)},
+	}
+	report, err := ScoreOpportunities(context.Background(), client, est, ScoringOptions{
+		Model: ModelICE, WriteBack: true, Now: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Written) != 1 || report.Written[0] != "1" || len(patched) != 1 {
+		t.Fatalf("expected only opportunity 1 written, got %v / %v", report.Written, patched)
+	}
+	if !strings.Contains(patched[0], `Context\n\nPriority score (ICE): 50, rank 2 of 2 (scored 2026-06-01)`) {
+		t.Errorf("unexpected patch %s", patched[0])
+	}
+}
@@ -0,0 +1,345 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/pkg/productplan"
+)
+
+// Item kinds FindStaleItems can check.
+const (
+	KindBar         = "bar"
+	KindIdea        = "idea"
+	KindOpportunity = "opportunity"
+	KindLaunchTask  = "launch_task"
+)
+
+// StaleKinds lists every kind in report order.
+var StaleKinds = []string{KindBar, KindIdea, KindOpportunity, KindLaunchTask}
+
+// DefaultStaleDays is how long an item may go untouched before it is stale.
+const DefaultStaleDays = 90
+
+// closedStatuses are statuses of finished work, which is not stale however
+// long it has been left alone.
+var closedStatuses = map[string]bool{
+	"done": true, "complete": true, "completed": true, "closed": true,
+	"archived": true, "rejected": true, "shipped": true, "released": true,
+}
+
+func isClosed(status string) bool {
+	return closedStatuses[strings.ToLower(strings.TrimSpace(status))]
+}
+
+// StaleOptions selects what FindStaleItems checks.
+type StaleOptions struct {
+	// Days is the age, in days since the last update, from which an item is
+	// stale. Zero means DefaultStaleDays.
+	Days int
+	// Kinds limits the check to these kinds. Empty means all.
+	Kinds []string
+	// RoadmapIDs limits bars to these roadmaps. Empty means all.
+	RoadmapIDs []string
+	// Now is the evaluation date. Zero means today.
+	Now time.Time
+}
+
+// StaleItem is one item untouched for at least the threshold.
+type StaleItem struct {
+	Kind      string `json:"kind"`
+	ID        api.ID `json:"id"`
+	Name      string `json:"name"`
+	Status    string `json:"status,omitempty"`
+	UpdatedAt string `json:"updated_at"`
+	AgeDays   int    `json:"age_days"`
+}
+
+// StaleGroup collects a kind's stale items by owner or lane, oldest first.
+type StaleGroup struct {
+	Kind string `json:"kind"`
+	// Group is "Roadmap / Lane" for bars, the assignee for launch tasks and
+	// the owner for ideas and opportunities.
+	Group      string      `json:"group"`
+	Count      int         `json:"count"`
+	OldestDays int         `json:"oldest_days"`
+	Items      []StaleItem `json:"items"`
+}
+
+// StaleKindCount summarises one kind.
+type StaleKindCount struct {
+	Kind    string `json:"kind"`
+	Checked int    `json:"checked"`
+	Stale   int    `json:"stale"`
+	// Undated items carry no usable timestamp and could not be checked.
+	Undated int `json:"undated"`
+}
+
+// StaleReport is the result of FindStaleItems.
+type StaleReport struct {
+	AsOf   string           `json:"as_of"`
+	Days   int              `json:"days"`
+	Total  int              `json:"total"`
+	Kinds  []StaleKindCount `json:"kinds"`
+	Groups []StaleGroup     `json:"groups"`
+}
+
+// StaleData is the fetched data FindStaleItems checks. Nil slices are kinds
+// that were not fetched.
+type StaleData struct {
+	Roadmaps []RoadmapBars
+	// Lanes maps lane IDs to names across all fetched roadmaps.
+	Lanes         map[api.ID]string
+	Ideas         []api.Idea
+	Opportunities []api.Opportunity
+	Launches      []api.Launch
+	// Tasks[i] belongs to Launches[i].
+	Tasks [][]api.LaunchTask
+	// Users maps user IDs to display names for task assignees.
+	Users map[api.ID]string
+}
+
+// FindStaleItems lists bars, ideas, opportunities and launch tasks not
+// updated for opts.Days, grouped by lane, owner or assignee.
+func FindStaleItems(ctx context.Context, client *api.Client, opts StaleOptions) (*StaleReport, error) {
+	kinds, err := staleKinds(opts.Kinds)
+	if err != nil {
+		return nil, err
+	}
+	var data StaleData
+	if kinds[KindBar] {
+		if data.Roadmaps, err = fetchRoadmapBars(ctx, client, opts.RoadmapIDs); err != nil {
+			return nil, err
+		}
+		if data.Lanes, err = fetchLaneNames(ctx, client, data.Roadmaps); err != nil {
+			return nil, err
+		}
+	}
+	if kinds[KindIdea] {
+		if data.Ideas, err = client.FetchIdeas(ctx); err != nil {
+			return nil, fmt.Errorf("ideas: %w", err)
+		}
+	}
+	if kinds[KindOpportunity] {
+		if data.Opportunities, err = client.FetchOpportunities(ctx); err != nil {
+			return nil, fmt.Errorf("opportunities: %w", err)
+		}
+	}
+	if kinds[KindLaunchTask] {
+		if data.Launches, data.Tasks, err = fetchLaunchTasks(ctx, client); err != nil {
+			return nil, err
+		}
+		if data.Users, err = fetchUserNames(ctx, client); err != nil {
+			return nil, err
+		}
+	}
+	return StaleItems(data, opts), nil
+}
+
+// staleKinds validates the requested kinds into a set.
+func staleKinds(requested []string) (map[string]bool, error) {
+	set := make(map[string]bool, len(StaleKinds))
+	if len(requested) == 0 {
+		requested = StaleKinds
+	}
+	for _, k := range requested {
+		switch k {
+		case KindBar, KindIdea, KindOpportunity, KindLaunchTask:
+			set[k] = true
+		default:
+			return nil, fmt.Errorf("unknown kind %q (use %s)", k, strings.Join(StaleKinds, ", "))
+		}
+	}
+	return set, nil
+}
+
+// fetchLaneNames loads lane names for each roadmap in parallel.
+func fetchLaneNames(ctx context.Context, client *api.Client, roadmaps []RoadmapBars) (map[api.ID]string, error) {
+	fns := make([]func(ctx context.Context) ([]api.Lane, error), len(roadmaps))
+	for i, rm := range roadmaps {
+		fns[i] = func(ctx context.Context) ([]api.Lane, error) {
+			lanes, err := client.FetchRoadmapLanes(ctx, rm.Roadmap.ID.String())
+			if err != nil {
+				return nil, fmt.Errorf("roadmap %s lanes: %w", rm.Roadmap.ID, err)
+			}
+			return lanes, nil
+		}
+	}
+	result := productplan.Execute(ctx, productplan.DefaultBatchConfig(), fns)
+	if result.HasErrors() {
+		return nil, result.Errors[0].Err
+	}
+	names := make(map[api.ID]string)
+	for _, lanes := range result.Results {
+		for _, l := range lanes {
+			names[l.ID] = l.Name
+		}
+	}
+	return names, nil
+}
+
+// fetchLaunchTasks loads every launch and, in parallel, its tasks.
+func fetchLaunchTasks(ctx context.Context, client *api.Client) ([]api.Launch, [][]api.LaunchTask, error) {
+	launches, err := client.FetchLaunches(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("launches: %w", err)
+	}
+	fns := make([]func(ctx context.Context) ([]api.LaunchTask, error), len(launches))
+	for i, l := range launches {
+		fns[i] = func(ctx context.Context) ([]api.LaunchTask, error) {
+			tasks, err := client.FetchLaunchTasks(ctx, l.ID.String())
+			if err != nil {
+				return nil, fmt.Errorf("launch %s tasks: %w", l.ID, err)
+			}
+			return tasks, nil
+		}
+	}
+	result := productplan.Execute(ctx, productplan.DefaultBatchConfig(), fns)
+	if result.HasErrors() {
+		return nil, nil, result.Errors[0].Err
+	}
+	return launches, result.Results, nil
+}
+
+// fetchUserNames maps user IDs to display names.
+func fetchUserNames(ctx context.Context, client *api.Client) (map[api.ID]string, error) {
+	users, err := client.FetchUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("users: %w", err)
+	}
+	names := make(map[api.ID]string, len(users))
+	for _, u := range users {
+		names[u.ID] = u.DisplayName()
+	}
+	return names, nil
+}
+
+// staleCollector accumulates items into groups and per-kind counts.
+type staleCollector struct {
+	today  time.Time
+	days   int
+	counts map[string]*StaleKindCount
+	groups map[[2]string]*StaleGroup
+}
+
+// add checks one item. timestamps are tried in order; the first usable
+// one is the item's last update.
+func (c *staleCollector) add(kind, group string, item StaleItem, timestamps ...string) {
+	count := c.counts[kind]
+	count.Checked++
+	var updated time.Time
+	var ok bool
+	for _, ts := range timestamps {
+		if updated, ok = api.ParseDate(ts); ok {
+			item.UpdatedAt = updated.Format(time.DateOnly)
+			break
+		}
+	}
+	if !ok {
+		count.Undated++
+		return
+	}
+	item.AgeDays = daysBetween(updated, c.today)
+	if item.AgeDays < c.days {
+		return
+	}
+	count.Stale++
+
+	key := [2]string{kind, group}
+	g, exists := c.groups[key]
+	if !exists {
+		g = &StaleGroup{Kind: kind, Group: group}
+		c.groups[key] = g
+	}
+	item.Kind = kind
+	g.Items = append(g.Items, item)
+	g.Count++
+	g.OldestDays = max(g.OldestDays, item.AgeDays)
+}
+
+// StaleItems computes the report from already-fetched data. Finished work
+// (closed statuses, bars 100% done) is skipped; container bars are skipped
+// since their children carry the work.
+func StaleItems(data StaleData, opts StaleOptions) *StaleReport {
+	today := opts.Now
+	if today.IsZero() {
+		today = time.Now()
+	}
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	days := opts.Days
+	if days <= 0 {
+		days = DefaultStaleDays
+	}
+
+	c := &staleCollector{today: today, days: days, counts: map[string]*StaleKindCount{}, groups: map[[2]string]*StaleGroup{}}
+	for _, k := range StaleKinds {
+		c.counts[k] = &StaleKindCount{Kind: k}
+	}
+
+	for _, rm := range data.Roadmaps {
+		for _, b := range rm.Bars {
+			if b.Container || (b.PercentDone != nil && *b.PercentDone >= 100) {
+				continue
+			}
+			lane := data.Lanes[b.LaneID]
+			if lane == "" {
+				lane = "(no lane)"
+			}
+			c.add(KindBar, rm.Roadmap.Name+" / "+lane, StaleItem{ID: b.ID, Name: b.Name}, b.UpdatedAt)
+		}
+	}
+	for _, i := range data.Ideas {
+		if !isClosed(i.Status) {
+			c.add(KindIdea, ownerOr(i.OwnerName, "(no owner)"), StaleItem{ID: i.ID, Name: i.Name, Status: i.Status}, i.UpdatedAt, i.CreatedAt)
+		}
+	}
+	for _, o := range data.Opportunities {
+		if !isClosed(o.WorkflowStatus) {
+			c.add(KindOpportunity, ownerOr(o.OwnerName, "(no owner)"), StaleItem{ID: o.ID, Name: o.ProblemStatement, Status: o.WorkflowStatus}, o.UpdatedAt, o.CreatedAt)
+		}
+	}
+	for i, l := range data.Launches {
+		for _, t := range data.Tasks[i] {
+			if isClosed(t.Status) {
+				continue
+			}
+			assignee := "(unassigned)"
+			if t.AssignedUserID != "" {
+				assignee = ownerOr(data.Users[t.AssignedUserID], "User "+t.AssignedUserID.String())
+			}
+			c.add(KindLaunchTask, assignee, StaleItem{ID: t.ID, Name: l.Name + ": " + t.Name, Status: t.Status}, t.UpdatedAt)
+		}
+	}
+
+	report := &StaleReport{AsOf: today.Format(time.DateOnly), Days: days, Groups: []StaleGroup{}}
+	for _, k := range StaleKinds {
+		report.Kinds = append(report.Kinds, *c.counts[k])
+		report.Total += c.counts[k].Stale
+	}
+	for _, g := range c.groups {
+		sort.SliceStable(g.Items, func(i, j int) bool { return g.Items[i].AgeDays > g.Items[j].AgeDays })
+		report.Groups = append(report.Groups, *g)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if a.OldestDays != b.OldestDays {
+			return a.OldestDays > b.OldestDays
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Group < b.Group
+	})
+	return report
+}
+
+func ownerOr(name, fallback string) string {
+	if strings.TrimSpace(name) == "" {
+		return fallback
+	}
+	return name
+}
@@ -0,0 +1,128 @@
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+)
+
+func TestStaleItems(t *testing.T) {
+	now := time.Date(2026, 6, 30, 15, 0, 0, 0, time.UTC)
+	var ideas []api.Idea
+	if err := json.Unmarshal([]byte(`[
+		{"id": 1, "name": "Dark mode", "status": "new", "owner": {"name": "Ana"}, "updated_at": "2026-01-01T10:00:00Z"},
+		{"id": 2, "name": "Exports", "status": "new", "owner_name": "Ana", "created_at": "2026-03-02"},
+		{"id": 3, "name": "Fresh", "status": "new", "owner": "Ana", "updated_at": "2026-06-20"},
+		{"id": 4, "name": "Old but done", "status": "Done", "updated_at": "2025-01-01"},
+		{"id": 5, "name": "No dates", "status": "new"}
+	]`), &ideas); err != nil {
+		t.Fatalf("decode ideas: %v", err)
+	}
+	data := StaleData{
+		Roadmaps: []RoadmapBars{{
+			Roadmap: api.Roadmap{ID: "9", Name: "Web"},
+			Bars: decodeBars(t, `[
+				{"id": 10, "name": "Checkout", "lane_id": 100, "updated_at": "2026-02-01"},
+				{"id": 11, "name": "Shipped", "lane_id": 100, "percent_done": 100, "updated_at": "2025-01-01"},
+				{"id": 12, "name": "Epic", "container": true, "updated_at": "2025-01-01"},
+				{"id": 13, "name": "Orphan", "updated_at": "2026-03-01"}
+			]`),
+		}},
+		Lanes:         map[api.ID]string{"100": "Payments"},
+		Ideas:         ideas,
+		Opportunities: []api.Opportunity{{ID: "20", ProblemStatement: "Slow onboarding", WorkflowStatus: "draft", UpdatedAt: "2026-03-31"}},
+		Launches:      []api.Launch{{ID: "30", Name: "v2"}},
+		Tasks: [][]api.LaunchTask{{
+			{ID: "31", Name: "Docs", AssignedUserID: "7", UpdatedAt: "2026-01-31"},
+			{ID: "32", Name: "Blog", AssignedUserID: "8", UpdatedAt: "2026-02-28"},
+			{ID: "33", Name: "Press", UpdatedAt: "2026-01-15", Status: "completed"},
+		}},
+		Users: map[api.ID]string{"7": "Bo"},
+	}
+
+	report := StaleItems(data, StaleOptions{Now: now})
+
+	if report.AsOf != "2026-06-30" || report.Days != DefaultStaleDays {
+		t.Errorf("as_of/days = %s/%d", report.AsOf, report.Days)
+	}
+	if report.Total != 7 {
+		t.Errorf("total = %d, want 7", report.Total)
+	}
+	wantKinds := map[string][3]int{
+		KindBar:         {2, 2, 0},
+		KindIdea:        {4, 2, 1},
+		KindOpportunity: {1, 1, 0},
+		KindLaunchTask:  {2, 2, 0},
+	}
+	for _, k := range report.Kinds {
+		if got := [3]int{k.Checked, k.Stale, k.Undated}; got != wantKinds[k.Kind] {
+			t.Errorf("%s checked/stale/undated = %v, want %v", k.Kind, got, wantKinds[k.Kind])
+		}
+	}
+
+	type group struct {
+		kind, name string
+		oldest     int
+		ids        string
+	}
+	want := []group{
+		{KindIdea, "Ana", 180, "1,2"},
+		{KindLaunchTask, "Bo", 150, "31"},
+		{KindBar, "Web / Payments", 149, "10"},
+		{KindLaunchTask, "User 8", 122, "32"},
+		{KindBar, "Web / (no lane)", 121, "13"},
+		{KindOpportunity, "(no owner)", 91, "20"},
+	}
+	if len(report.Groups) != len(want) {
+		t.Fatalf("got %d groups, want %d: %+v", len(report.Groups), len(want), report.Groups)
+	}
+	for i, w := range want {
+		g := report.Groups[i]
+		ids := ""
+		for j, item := range g.Items {
+			if j > 0 {
+				ids += ","
+			}
+			ids += item.ID.String()
+		}
+		if g.Kind != w.kind || g.Group != w.name || g.OldestDays != w.oldest || ids != w.ids || g.Count != len(g.Items) {
+			t.Errorf("group %d = %s %q oldest %d items %s, want %+v", i, g.Kind, g.Group, g.OldestDays, ids, w)
+		}
+	}
+	if item := report.Groups[1].Items[0]; item.Name != "v2: Docs" || item.UpdatedAt != "2026-01-31" {
+		t.Errorf("unexpected task item %+v", item)
+	}
+}
+
+func TestStaleItemsThreshold(t *testing.T) {
+	data := StaleData{Opportunities: []api.Opportunity{{ID: "1", UpdatedAt: "2026-06-20"}}}
+	now := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+	if r := StaleItems(data, StaleOptions{Now: now, Days: 10}); r.Total != 1 {
+		t.Errorf("expected the item at exactly 10 days to be stale, got %d", r.Total)
+	}
+	if r := StaleItems(data, StaleOptions{Now: now, Days: 11}); r.Total != 0 || len(r.Groups) != 0 {
+		t.Errorf("expected nothing stale at 11 days, got %+v", r)
+	}
+}
+
+func TestFindStaleItems(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/discovery/ideas":         `[{"id": 1, "name": "Old", "updated_at": "2020-01-01"}]`,
+		"/discovery/opportunities": `{"results": [{"id": 2, "problem_statement": "Old", "updated_at": "2020-01-01"}]}`,
+	})
+	report, err := FindStaleItems(context.Background(), client, StaleOptions{Kinds: []string{KindIdea, KindOpportunity}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Total != 2 || len(report.Groups) != 2 {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	if _, err := FindStaleItems(context.Background(), client, StaleOptions{Kinds: []string{"widget"}}); err == nil {
+		t.Error("expected error for unknown kind")
+	}
+}
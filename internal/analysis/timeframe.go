@@ -0,0 +1,54 @@
+package analysis
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+)
+
+var (
+	quarterPattern = regexp.MustCompile(`^(?:Q([1-4])[\s/-]*(?:FY)?(\d{4})|(?:FY)?(\d{4})[\s/-]*Q([1-4]))$`)
+	halfPattern    = regexp.MustCompile(`^(?:H([12])[\s/-]*(?:FY)?(\d{4})|(?:FY)?(\d{4})[\s/-]*H([12]))$`)
+	yearPattern    = regexp.MustCompile(`^(?:FY)?\s*(\d{4})$`)
+)
+
+// ParseTimeFrame parses an OKR time frame label such as "Q3 2026",
+// "2026-Q3", "H1 2026" or "FY2026" into the period it covers on cal, so
+// with a February fiscal year "Q1 2027" starts in February 2026. Labels
+// it does not recognise return false.
+func ParseTimeFrame(label string, cal dates.Calendar) (dates.Period, bool) {
+	s := strings.ToUpper(strings.TrimSpace(label))
+	if m := quarterPattern.FindStringSubmatch(s); m != nil {
+		return cal.Quarter(pick(m[2], m[3]), pick(m[1], m[4])), true
+	}
+	if m := halfPattern.FindStringSubmatch(s); m != nil {
+		return cal.Half(pick(m[2], m[3]), pick(m[1], m[4])), true
+	}
+	if m := yearPattern.FindStringSubmatch(s); m != nil {
+		return cal.Year(pick(m[1], "")), true
+	}
+	return dates.Period{}, false
+}
+
+// pick returns the first non-empty capture as an int.
+func pick(a, b string) int {
+	if a == "" {
+		a = b
+	}
+	n, _ := strconv.Atoi(a)
+	return n
+}
+
+// clamp limits v to [lo, hi].
+func clamp(v, lo, hi float64) float64 {
+	return min(max(v, lo), hi)
+}
+
+// daysBetween returns the whole days from a to b; both are UTC dates.
+func daysBetween(a, b time.Time) int {
+	return int(math.Round(b.Sub(a).Hours() / 24))
+}
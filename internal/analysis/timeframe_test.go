@@ -0,0 +1,54 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+)
+
+func TestParseTimeFrame(t *testing.T) {
+	tests := []struct {
+		label      string
+		start, end string
+	}{
+		{"Q3 2026", "2026-07-01", "2026-09-30"},
+		{"2026-Q1", "2026-01-01", "2026-03-31"},
+		{"q4 fy2026", "2026-10-01", "2026-12-31"},
+		{"H1 2026", "2026-01-01", "2026-06-30"},
+		{"2026 H2", "2026-07-01", "2026-12-31"},
+		{"2027", "2027-01-01", "2027-12-31"},
+		{"FY2027", "2027-01-01", "2027-12-31"},
+	}
+	for _, tt := range tests {
+		p, ok := ParseTimeFrame(tt.label, dates.Calendar{})
+		if !ok {
+			t.Errorf("%q: expected to parse", tt.label)
+			continue
+		}
+		if got := p.Start.Format(time.DateOnly) + ".." + p.End.Format(time.DateOnly); got != tt.start+".."+tt.end {
+			t.Errorf("%q: got %s, want %s..%s", tt.label, got, tt.start, tt.end)
+		}
+	}
+
+	for _, bad := range []string{"", "Next year", "Q5 2026", "Sprint 12"} {
+		if _, ok := ParseTimeFrame(bad, dates.Calendar{}); ok {
+			t.Errorf("%q: expected no match", bad)
+		}
+	}
+}
+
+func TestParseTimeFrameFiscal(t *testing.T) {
+	cal := dates.Calendar{FiscalYearStart: time.February}
+	tests := map[string]string{
+		"Q1 2027": "2026-02-01..2026-04-30",
+		"H2 2027": "2026-08-01..2027-01-31",
+		"FY2027":  "2026-02-01..2027-01-31",
+	}
+	for label, want := range tests {
+		p, ok := ParseTimeFrame(label, cal)
+		if got := p.Start.Format(time.DateOnly) + ".." + p.End.Format(time.DateOnly); !ok || got != want {
+			t.Errorf("%q: got %s, %v; want %s", label, got, ok, want)
+		}
+	}
+}
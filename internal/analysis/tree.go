@@ -0,0 +1,234 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/pkg/productplan"
+)
+
+// DefaultTreeDepth is how many levels below the root GetBarTree loads by
+// default.
+const DefaultTreeDepth = 10
+
+// BarNode is a bar and its descendants, with rolled-up totals.
+type BarNode struct {
+	ID          api.ID   `json:"id"`
+	Name        string   `json:"name"`
+	Container   bool     `json:"container"`
+	StartsOn    string   `json:"starts_on,omitempty"`
+	EndsOn      string   `json:"ends_on,omitempty"`
+	Effort      *float64 `json:"effort"`
+	PercentDone *float64 `json:"percent_done"`
+	// SpanStart and SpanEnd cover the bar's own dates and every
+	// descendant's.
+	SpanStart string `json:"span_start,omitempty"`
+	SpanEnd   string `json:"span_end,omitempty"`
+	// TotalEffort sums effort over the leaves below the bar, falling back
+	// to the bar's own effort when no leaf has one.
+	TotalEffort *float64 `json:"total_effort"`
+	// AvgPercentDone averages percent done over the leaves below the bar
+	// that report it, falling back to the bar's own.
+	AvgPercentDone *float64 `json:"avg_percent_done"`
+	Descendants    int      `json:"descendants"`
+	// Cycle marks a bar already shown elsewhere in the tree; it is not
+	// expanded again and not counted in rollups.
+	Cycle bool `json:"cycle,omitempty"`
+	// Truncated marks a bar at the depth limit whose children were not
+	// loaded.
+	Truncated bool       `json:"truncated,omitempty"`
+	Children  []*BarNode `json:"children"`
+}
+
+// BarTree is the result of GetBarTree.
+type BarTree struct {
+	Root *BarNode `json:"root"`
+	// Bars counts distinct bars in the tree, the root included.
+	Bars      int `json:"bars"`
+	Depth     int `json:"depth"`
+	Cycles    int `json:"cycles"`
+	Truncated int `json:"truncated"`
+	// Outline renders the tree as indented text.
+	Outline string `json:"outline"`
+}
+
+// GetBarTree loads a bar and walks its children level by level, fetching
+// each level's children in parallel with bounded concurrency. Bars already
+// in the tree are marked as cycles rather than expanded, and bars at
+// maxDepth are marked truncated. maxDepth <= 0 means DefaultTreeDepth.
+func GetBarTree(ctx context.Context, client *api.Client, barID string, maxDepth int) (*BarTree, error) {
+	if maxDepth <= 0 {
+		maxDepth = DefaultTreeDepth
+	}
+	bar, err := client.FetchBar(ctx, barID)
+	if err != nil {
+		return nil, fmt.Errorf("bar %s: %w", barID, err)
+	}
+	if bar.ID == "" {
+		bar.ID = api.ID(barID)
+	}
+
+	root := newBarNode(bar)
+	seen := map[api.ID]bool{root.ID: true}
+	level := []*BarNode{root}
+	for depth := 0; len(level) > 0; depth++ {
+		if depth == maxDepth {
+			for _, n := range level {
+				n.Truncated = true
+			}
+			break
+		}
+		ids := make([]string, len(level))
+		for i, n := range level {
+			ids[i] = n.ID.String()
+		}
+		result := productplan.ExecuteWithKeys(ctx, productplan.DefaultBatchConfig(), ids, client.FetchBarChildren)
+		if result.HasErrors() {
+			return nil, fmt.Errorf("bar %s children: %w", result.Errors[0].Key, result.Errors[0].Err)
+		}
+		for i, children := range result.Results {
+			for _, c := range children {
+				level[i].Children = append(level[i].Children, newBarNode(c))
+			}
+		}
+
+		var next []*BarNode
+		for _, n := range level {
+			for _, c := range n.Children {
+				if seen[c.ID] {
+					c.Cycle = true
+					continue
+				}
+				seen[c.ID] = true
+				next = append(next, c)
+			}
+		}
+		level = next
+	}
+	return NewBarTree(root), nil
+}
+
+func newBarNode(b api.Bar) *BarNode {
+	return &BarNode{
+		ID:          b.ID,
+		Name:        b.Name,
+		Container:   b.Container,
+		StartsOn:    b.StartsOn,
+		EndsOn:      b.EndsOn,
+		Effort:      b.Effort,
+		PercentDone: b.PercentDone,
+		Children:    []*BarNode{},
+	}
+}
+
+// NewBarTree computes rollups for a loaded tree and renders its outline.
+func NewBarTree(root *BarNode) *BarTree {
+	t := &BarTree{Root: root}
+	rollUp(root, 0, t)
+	var b strings.Builder
+	writeOutline(&b, root, 0)
+	t.Outline = b.String()
+	return t
+}
+
+// leafStats accumulates leaf values during a rollup.
+type leafStats struct {
+	effort       float64
+	effortLeaves int
+	pctSum       float64
+	pctLeaves    int
+}
+
+// rollUp fills in a node's span, totals and descendant count, and tallies
+// the tree, returning the leaf stats below (or at) the node.
+func rollUp(n *BarNode, depth int, t *BarTree) leafStats {
+	t.Bars++
+	t.Depth = max(t.Depth, depth)
+	if n.Truncated {
+		t.Truncated++
+	}
+	n.SpanStart, n.SpanEnd = normDate(n.StartsOn), normDate(n.EndsOn)
+
+	var stats leafStats
+	expanded := false
+	for _, c := range n.Children {
+		if c.Cycle {
+			t.Cycles++
+			continue
+		}
+		expanded = true
+		cs := rollUp(c, depth+1, t)
+		stats.effort += cs.effort
+		stats.effortLeaves += cs.effortLeaves
+		stats.pctSum += cs.pctSum
+		stats.pctLeaves += cs.pctLeaves
+		n.Descendants += 1 + c.Descendants
+		if c.SpanStart != "" && (n.SpanStart == "" || c.SpanStart < n.SpanStart) {
+			n.SpanStart = c.SpanStart
+		}
+		if c.SpanEnd > n.SpanEnd {
+			n.SpanEnd = c.SpanEnd
+		}
+	}
+
+	if !expanded || stats.effortLeaves == 0 {
+		if n.Effort != nil {
+			stats.effort, stats.effortLeaves = *n.Effort, 1
+		}
+	}
+	if !expanded || stats.pctLeaves == 0 {
+		if n.PercentDone != nil {
+			stats.pctSum, stats.pctLeaves = *n.PercentDone, 1
+		}
+	}
+	if stats.effortLeaves > 0 {
+		total := stats.effort
+		n.TotalEffort = &total
+	}
+	if stats.pctLeaves > 0 {
+		n.AvgPercentDone = round1(stats.pctSum / float64(stats.pctLeaves))
+	}
+	return stats
+}
+
+// normDate returns s as YYYY-MM-DD, or empty when it does not parse.
+func normDate(s string) string {
+	d, ok := api.ParseDate(s)
+	if !ok {
+		return ""
+	}
+	return d.Format(time.DateOnly)
+}
+
+// writeOutline renders a node and its children, two spaces per level.
+func writeOutline(b *strings.Builder, n *BarNode, depth int) {
+	b.WriteString(strings.Repeat("  ", depth))
+	fmt.Fprintf(b, "- %s (#%s)", n.Name, n.ID)
+	if n.Cycle {
+		b.WriteString(" [cycle: already in the tree]\n")
+		return
+	}
+	var details []string
+	if n.SpanStart != "" || n.SpanEnd != "" {
+		details = append(details, n.SpanStart+".."+n.SpanEnd)
+	}
+	if n.AvgPercentDone != nil {
+		details = append(details, fmt.Sprintf("%g%% done", *n.AvgPercentDone))
+	}
+	if n.TotalEffort != nil {
+		details = append(details, fmt.Sprintf("effort %g", *n.TotalEffort))
+	}
+	if n.Truncated {
+		details = append(details, "children not loaded (depth limit)")
+	}
+	if len(details) > 0 {
+		b.WriteString(" " + strings.Join(details, ", "))
+	}
+	b.WriteString("\n")
+	for _, c := range n.Children {
+		writeOutline(b, c, depth+1)
+	}
+}
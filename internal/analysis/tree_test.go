@@ -0,0 +1,59 @@
+package analysis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+)
+
+func TestGetBarTree(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/bars/1":             `{"id": 1, "name": "Epic", "container": true, "starts_on": "2026-02-01", "ends_on": "2026-03-01"}`,
+		"/bars/1/child_bars":  `[{"id": 2, "name": "Story A", "starts_on": "2026-01-15", "ends_on": "2026-02-10", "effort": 3, "percent_done": 100}, {"id": 3, "name": "Story B", "container": true}]`,
+		"/bars/2/child_bars":  `[]`,
+		"/bars/3/child_bars":  `[{"id": 4, "name": "Task", "ends_on": "2026-04-30", "effort": 2, "percent_done": 50}, {"id": 1, "name": "Epic"}]`,
+		"/bars/4/child_bars":  `[]`,
+		"/bars/9":             `{"id": 9, "name": "Deep"}`,
+		"/bars/9/child_bars":  `[{"id": 10, "name": "Level 1"}]`,
+		"/bars/10/child_bars": `[{"id": 11, "name": "Level 2", "effort": 5}]`,
+	})
+
+	tree, err := GetBarTree(context.Background(), client, "1", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	root := tree.Root
+	if tree.Bars != 4 || tree.Depth != 2 || tree.Cycles != 1 || root.Descendants != 3 {
+		t.Errorf("unexpected tree totals %+v", tree)
+	}
+	if root.SpanStart != "2026-01-15" || root.SpanEnd != "2026-04-30" {
+		t.Errorf("span = %s..%s", root.SpanStart, root.SpanEnd)
+	}
+	if root.TotalEffort == nil || *root.TotalEffort != 5 || pct(root.AvgPercentDone) != 75 {
+		t.Errorf("rollups = %v %v", root.TotalEffort, root.AvgPercentDone)
+	}
+	if b := root.Children[1]; !b.Children[1].Cycle || pct(b.AvgPercentDone) != 50 {
+		t.Errorf("unexpected Story B %+v", b)
+	}
+	want := "- Epic (#1) 2026-01-15..2026-04-30, 75% done, effort 5\n" +
+		"  - Story A (#2) 2026-01-15..2026-02-10, 100% done, effort 3\n" +
+		"  - Story B (#3) ..2026-04-30, 50% done, effort 2\n" +
+		"    - Task (#4) ..2026-04-30, 50% done, effort 2\n" +
+		"    - Epic (#1) [cycle: already in the tree]\n"
+	if tree.Outline != want {
+		t.Errorf("outline =\n%s\nwant\n%s", tree.Outline, want)
+	}
+
+	// A depth limit stops the walk and marks the cut-off bars.
+	if tree, err = GetBarTree(context.Background(), client, "9", 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tree.Bars != 2 || tree.Truncated != 1 || !tree.Root.Children[0].Truncated || tree.Root.TotalEffort != nil {
+		t.Errorf("unexpected truncated tree %+v", tree)
+	}
+
+	if _, err = GetBarTree(context.Background(), client, "2", 0); err == nil {
+		t.Error("expected error for a missing bar")
+	}
+}
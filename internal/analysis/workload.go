@@ -0,0 +1,292 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+)
+
+// DefaultWeeklyTaskLimit is how many open tasks due in one week mark a
+// user as overloaded.
+const DefaultWeeklyTaskLimit = 5
+
+// WorkloadOptions tunes UserWorkload.
+type WorkloadOptions struct {
+	// WeeklyLimit is the number of open tasks due in the same week from
+	// which a user is overloaded. Zero means DefaultWeeklyTaskLimit.
+	WeeklyLimit int
+	// RoadmapIDs limits bar ownership to these roadmaps. Empty means all.
+	RoadmapIDs []string
+	// SkipBars leaves bar ownership out, saving a fetch per roadmap.
+	SkipBars bool
+	// Now is the evaluation date. Zero means today.
+	Now time.Time
+	// Calendar sets the day weeks start on.
+	Calendar dates.Calendar
+}
+
+// WorkloadTask is an open launch task in a user's load.
+type WorkloadTask struct {
+	ID         api.ID `json:"id"`
+	Name       string `json:"name"`
+	LaunchID   api.ID `json:"launch_id"`
+	LaunchName string `json:"launch_name"`
+	DueDate    string `json:"due_date,omitempty"`
+	Overdue    bool   `json:"overdue,omitempty"`
+}
+
+// WorkloadWeek counts open tasks due in one week.
+type WorkloadWeek struct {
+	// Week is the ISO week holding most of the week's days, e.g.
+	// "2026-W25"; Start is its first day.
+	Week  string `json:"week"`
+	Start string `json:"start"`
+	Tasks int    `json:"tasks"`
+}
+
+// UserLoad is one user's open work.
+type UserLoad struct {
+	UserID    api.ID   `json:"user_id"`
+	Name      string   `json:"name"`
+	Email     string   `json:"email,omitempty"`
+	Teams     []string `json:"teams"`
+	OpenTasks int      `json:"open_tasks"`
+	Overdue   int      `json:"overdue"`
+	Undated   int      `json:"undated"`
+	// Bars counts active bars the user owns, when the API exposes owners.
+	Bars int `json:"bars"`
+	// Weeks lists weeks with tasks due, earliest first. Overdue tasks count
+	// in the current week, since they are due now.
+	Weeks      []WorkloadWeek `json:"weeks"`
+	PeakWeek   *WorkloadWeek  `json:"peak_week"`
+	Overloaded bool           `json:"overloaded"`
+	Tasks      []WorkloadTask `json:"tasks"`
+}
+
+// TeamLoad sums its members' open work.
+type TeamLoad struct {
+	ID        api.ID `json:"id"`
+	Name      string `json:"name"`
+	Members   int    `json:"members"`
+	OpenTasks int    `json:"open_tasks"`
+	Overdue   int    `json:"overdue"`
+	Bars      int    `json:"bars"`
+	// Overloaded names the overloaded members.
+	Overloaded []string `json:"overloaded"`
+}
+
+// WorkloadReport is the result of UserWorkload.
+type WorkloadReport struct {
+	AsOf        string `json:"as_of"`
+	WeeklyLimit int    `json:"weekly_limit"`
+	// Users with open tasks or bars, overloaded first, then by open tasks.
+	Users []UserLoad `json:"users"`
+	Teams []TeamLoad `json:"teams"`
+	// Unassigned counts open launch tasks with no assignee.
+	Unassigned int `json:"unassigned"`
+	// BarOwners is false when no bar carried owner data, so bar counts are
+	// not meaningful.
+	BarOwners bool `json:"bar_owners"`
+}
+
+// WorkloadData is the input to Workload.
+type WorkloadData struct {
+	Users    []api.User
+	Teams    []api.Team
+	Launches []api.Launch
+	// Tasks[i] belongs to Launches[i].
+	Tasks    [][]api.LaunchTask
+	Roadmaps []RoadmapBars
+}
+
+// UserWorkload fetches users, teams, launch tasks and, unless skipped, bars,
+// and aggregates open work per user and team.
+func UserWorkload(ctx context.Context, client *api.Client, opts WorkloadOptions) (*WorkloadReport, error) {
+	var data WorkloadData
+	var err error
+	if data.Users, err = client.FetchUsers(ctx); err != nil {
+		return nil, fmt.Errorf("users: %w", err)
+	}
+	if data.Teams, err = client.FetchTeams(ctx); err != nil {
+		return nil, fmt.Errorf("teams: %w", err)
+	}
+	if data.Launches, data.Tasks, err = fetchLaunchTasks(ctx, client); err != nil {
+		return nil, err
+	}
+	if !opts.SkipBars {
+		if data.Roadmaps, err = fetchRoadmapBars(ctx, client, opts.RoadmapIDs); err != nil {
+			return nil, err
+		}
+	}
+	return Workload(data, opts), nil
+}
+
+// Workload aggregates open launch tasks by assignee and active bars by
+// owner, per user and per team, and flags users with at least the weekly
+// limit of tasks due in one week. Assignees missing from Users still get
+// an entry, named by ID.
+func Workload(data WorkloadData, opts WorkloadOptions) *WorkloadReport {
+	today := opts.Now
+	if today.IsZero() {
+		today = time.Now()
+	}
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	limit := opts.WeeklyLimit
+	if limit <= 0 {
+		limit = DefaultWeeklyTaskLimit
+	}
+
+	report := &WorkloadReport{AsOf: today.Format(time.DateOnly), WeeklyLimit: limit, Users: []UserLoad{}, Teams: []TeamLoad{}}
+	loads := make(map[api.ID]*UserLoad, len(data.Users))
+	byName := make(map[string]api.ID, len(data.Users))
+	for _, u := range data.Users {
+		loads[u.ID] = &UserLoad{UserID: u.ID, Name: u.DisplayName(), Email: u.Email, Teams: []string{}}
+		byName[strings.ToLower(u.DisplayName())] = u.ID
+	}
+	load := func(id api.ID) *UserLoad {
+		if loads[id] == nil {
+			loads[id] = &UserLoad{UserID: id, Name: id.String(), Teams: []string{}}
+		}
+		return loads[id]
+	}
+	weeks := make(map[api.ID]map[string]*WorkloadWeek)
+
+	for i, l := range data.Launches {
+		for _, t := range data.Tasks[i] {
+			if isClosed(t.Status) {
+				continue
+			}
+			if t.AssignedUserID == "" {
+				report.Unassigned++
+				continue
+			}
+			u := load(t.AssignedUserID)
+			u.OpenTasks++
+			task := WorkloadTask{ID: t.ID, Name: t.Name, LaunchID: l.ID, LaunchName: l.Name, DueDate: t.DueDate}
+			due, ok := api.ParseDate(t.DueDate)
+			if !ok {
+				u.Undated++
+				u.Tasks = append(u.Tasks, task)
+				continue
+			}
+			if due.Before(today) {
+				task.Overdue = true
+				u.Overdue++
+				due = today
+			}
+			start := opts.Calendar.Week(due).Start
+			key := start.Format(time.DateOnly)
+			if weeks[u.UserID] == nil {
+				weeks[u.UserID] = map[string]*WorkloadWeek{}
+			}
+			if weeks[u.UserID][key] == nil {
+				weeks[u.UserID][key] = &WorkloadWeek{Week: opts.Calendar.WeekLabel(start), Start: key}
+			}
+			weeks[u.UserID][key].Tasks++
+			u.Tasks = append(u.Tasks, task)
+		}
+	}
+
+	for _, rm := range data.Roadmaps {
+		for _, b := range rm.Bars {
+			if b.OwnerID == "" && b.OwnerName == "" {
+				continue
+			}
+			report.BarOwners = true
+			if b.Container || (b.PercentDone != nil && *b.PercentDone >= 100) {
+				continue
+			}
+			if end, ok := api.ParseDate(b.EndsOn); ok && end.Before(today) {
+				continue
+			}
+			id := b.OwnerID
+			if id == "" {
+				if id = byName[strings.ToLower(strings.TrimSpace(b.OwnerName))]; id == "" {
+					id = api.ID(b.OwnerName)
+				}
+			}
+			load(id).Bars++
+			if loads[id].Name == id.String() && b.OwnerName != "" {
+				loads[id].Name = b.OwnerName
+			}
+		}
+	}
+
+	for id, u := range loads {
+		u.Weeks = []WorkloadWeek{}
+		for _, w := range weeks[id] {
+			u.Weeks = append(u.Weeks, *w)
+		}
+		sort.Slice(u.Weeks, func(i, j int) bool { return u.Weeks[i].Start < u.Weeks[j].Start })
+		for i := range u.Weeks {
+			if u.PeakWeek == nil || u.Weeks[i].Tasks > u.PeakWeek.Tasks {
+				peak := u.Weeks[i]
+				u.PeakWeek = &peak
+			}
+		}
+		u.Overloaded = u.PeakWeek != nil && u.PeakWeek.Tasks >= limit
+		sort.SliceStable(u.Tasks, func(i, j int) bool { return dueKey(u.Tasks[i]) < dueKey(u.Tasks[j]) })
+		if u.Tasks == nil {
+			u.Tasks = []WorkloadTask{}
+		}
+	}
+
+	for _, t := range data.Teams {
+		team := TeamLoad{ID: t.ID, Name: t.Name, Members: len(t.UserIDs), Overloaded: []string{}}
+		for _, id := range t.UserIDs {
+			u := loads[id]
+			if u == nil {
+				continue
+			}
+			u.Teams = append(u.Teams, t.Name)
+			team.OpenTasks += u.OpenTasks
+			team.Overdue += u.Overdue
+			team.Bars += u.Bars
+			if u.Overloaded {
+				team.Overloaded = append(team.Overloaded, u.Name)
+			}
+		}
+		sort.Strings(team.Overloaded)
+		report.Teams = append(report.Teams, team)
+	}
+	sort.Slice(report.Teams, func(i, j int) bool {
+		a, b := report.Teams[i], report.Teams[j]
+		if a.OpenTasks != b.OpenTasks {
+			return a.OpenTasks > b.OpenTasks
+		}
+		return a.Name < b.Name
+	})
+
+	for _, u := range loads {
+		if u.OpenTasks > 0 || u.Bars > 0 {
+			report.Users = append(report.Users, *u)
+		}
+	}
+	sort.Slice(report.Users, func(i, j int) bool {
+		a, b := report.Users[i], report.Users[j]
+		if a.Overloaded != b.Overloaded {
+			return a.Overloaded
+		}
+		if a.OpenTasks != b.OpenTasks {
+			return a.OpenTasks > b.OpenTasks
+		}
+		if a.Bars != b.Bars {
+			return a.Bars > b.Bars
+		}
+		return a.Name < b.Name
+	})
+	return report
+}
+
+// dueKey sorts dated tasks by date and undated ones last.
+func dueKey(t WorkloadTask) string {
+	if t.DueDate == "" {
+		return "~"
+	}
+	return t.DueDate
+}
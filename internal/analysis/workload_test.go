@@ -0,0 +1,91 @@
+package analysis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+)
+
+func TestWorkload(t *testing.T) {
+	now := time.Date(2026, 6, 10, 12, 0, 0, 0, time.UTC) // Wednesday, 2026-W24
+	data := WorkloadData{
+		Users:    []api.User{{ID: "7", Name: "Ana"}, {ID: "8", Name: "Bo"}},
+		Teams:    []api.Team{{ID: "1", Name: "Web", UserIDs: api.IDs{"7", "8"}}},
+		Launches: []api.Launch{{ID: "30", Name: "v2"}, {ID: "31", Name: "v3"}},
+		Tasks: [][]api.LaunchTask{
+			{
+				{ID: "1", Name: "Late", AssignedUserID: "7", DueDate: "2026-06-01"},
+				{ID: "2", Name: "Docs", AssignedUserID: "7", DueDate: "2026-06-12"},
+				{ID: "3", Name: "Done", AssignedUserID: "7", DueDate: "2026-06-12", Status: "done"},
+				{ID: "4", Name: "Nobody", DueDate: "2026-06-12"},
+			},
+			{
+				{ID: "5", Name: "QA", AssignedUserID: "7", DueDate: "2026-06-14"},
+				{ID: "6", Name: "Blog", AssignedUserID: "8", DueDate: "2026-06-15"},
+				{ID: "7", Name: "Someday", AssignedUserID: "8"},
+				{ID: "8", Name: "Ghost", AssignedUserID: "99", DueDate: "2026-06-20"},
+			},
+		},
+		Roadmaps: []RoadmapBars{{Bars: []api.Bar{
+			{ID: "40", OwnerID: "8", EndsOn: "2026-09-30"},
+			{ID: "41", OwnerName: "ana"},
+			{ID: "42", OwnerID: "8", EndsOn: "2026-01-31"},
+			{ID: "43"},
+		}}},
+	}
+
+	r := Workload(data, WorkloadOptions{WeeklyLimit: 3, Now: now})
+	if !r.BarOwners || r.Unassigned != 1 || len(r.Users) != 3 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	ana := r.Users[0]
+	if ana.Name != "Ana" || !ana.Overloaded || ana.OpenTasks != 3 || ana.Overdue != 1 || ana.Bars != 1 {
+		t.Errorf("unexpected Ana %+v", ana)
+	}
+	if ana.PeakWeek == nil || ana.PeakWeek.Week != "2026-W24" || ana.PeakWeek.Start != "2026-06-08" || ana.PeakWeek.Tasks != 3 {
+		t.Errorf("unexpected peak week %+v", ana.PeakWeek)
+	}
+	if ana.Tasks[0].ID != "1" || !ana.Tasks[0].Overdue || ana.Tasks[2].LaunchName != "v3" {
+		t.Errorf("unexpected Ana tasks %+v", ana.Tasks)
+	}
+	bo := r.Users[1]
+	if bo.Name != "Bo" || bo.Overloaded || bo.Undated != 1 || bo.Bars != 1 || bo.Weeks[0].Week != "2026-W25" || bo.Teams[0] != "Web" {
+		t.Errorf("unexpected Bo %+v", bo)
+	}
+	if ghost := r.Users[2]; ghost.Name != "99" || ghost.OpenTasks != 1 {
+		t.Errorf("unexpected unknown assignee %+v", ghost)
+	}
+	if team := r.Teams[0]; team.OpenTasks != 5 || team.Bars != 2 || len(team.Overloaded) != 1 || team.Overloaded[0] != "Ana" {
+		t.Errorf("unexpected team %+v", team)
+	}
+
+	sunday := Workload(data, WorkloadOptions{WeeklyLimit: 3, Now: now, Calendar: dates.Calendar{}.WithWeekStart(time.Sunday)})
+	if ana = sunday.Users[0]; ana.Overloaded || ana.PeakWeek.Start != "2026-06-07" || ana.PeakWeek.Week != "2026-W24" || ana.PeakWeek.Tasks != 2 {
+		t.Errorf("unexpected Sunday-week peak %+v", ana.PeakWeek)
+	}
+
+	if r = Workload(WorkloadData{}, WorkloadOptions{Now: now}); r.BarOwners || r.WeeklyLimit != DefaultWeeklyTaskLimit || len(r.Users) != 0 {
+		t.Errorf("unexpected empty report %+v", r)
+	}
+}
+
+func TestUserWorkload(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/users":             `[{"id": 7, "first_name": "Ana", "last_name": "Lee"}]`,
+		"/teams":             `[{"id": 1, "name": "Web", "members": [{"id": 7}]}]`,
+		"/launches":          `[{"id": 30, "name": "v2"}]`,
+		"/launches/30/tasks": `[{"id": 1, "name": "Docs", "assigned_user_id": 7, "due_date": "2026-06-12"}]`,
+	})
+
+	r, err := UserWorkload(context.Background(), client, WorkloadOptions{SkipBars: true, Now: time.Date(2026, 6, 10, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(r.Users) != 1 || r.Users[0].Name != "Ana Lee" || r.Users[0].Teams[0] != "Web" || r.Teams[0].OpenTasks != 1 {
+		t.Errorf("unexpected report %+v", r)
+	}
+}
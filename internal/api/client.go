@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/olgasafonova/productplan-mcp-server/internal/logging"
@@ -55,6 +56,10 @@ const (
 
 	// DefaultTimeout for HTTP requests.
 	DefaultTimeout = 30 * time.Second
+
+	// maxLoggedPayload bounds the bodies logged with Config.LogPayloads;
+	// larger ones are logged by size only.
+	maxLoggedPayload = 64 << 10
 )
 
 // Config holds API client configuration.
@@ -63,25 +68,45 @@ type Config struct {
 	Token   string
 	Timeout time.Duration
 	Logger  logging.Logger
+	// RateLimit tunes the adaptive rate limiter. The zero value means
+	// productplan.DefaultRateLimiterConfig.
+	RateLimit productplan.RateLimiterConfig
+	// ReloadToken, when set, is called after a 401 response to fetch the
+	// current token, so a rotated token is picked up without a restart.
+	// The request is retried once if the token changed.
+	ReloadToken func(ctx context.Context) (string, error)
+	// LogPayloads adds request and response bodies to the debug log
+	// entries of each request. They pass through the logger's redaction
+	// rules like every other field.
+	LogPayloads bool
 }
 
 // DefaultConfig returns a Config with sensible defaults.
 func DefaultConfig(token string) Config {
 	return Config{
-		BaseURL: DefaultBaseURL,
-		Token:   token,
-		Timeout: DefaultTimeout,
-		Logger:  logging.Nop(),
+		BaseURL:   DefaultBaseURL,
+		Token:     token,
+		Timeout:   DefaultTimeout,
+		Logger:    logging.Nop(),
+		RateLimit: productplan.DefaultRateLimiterConfig(),
 	}
 }
 
 // Client is the ProductPlan API client.
 type Client struct {
 	baseURL     string
-	token       string
 	httpClient  *http.Client
 	rateLimiter *productplan.AdaptiveRateLimiter
 	logger      logging.Logger
+	reloadToken func(ctx context.Context) (string, error)
+	logPayloads bool
+
+	tokenMu sync.RWMutex
+	token   string
+	// reloadMu lets one request at a time reload the token, without
+	// holding tokenMu, so requests that do not need a new token never wait
+	// on a slow token source.
+	reloadMu sync.Mutex
 }
 
 // New creates a new API client with the given configuration.
@@ -105,9 +130,19 @@ func New(cfg Config) (*Client, error) {
 		logger = logging.Nop()
 	}
 
+	rateLimit := cfg.RateLimit
+	if rateLimit == (productplan.RateLimiterConfig{}) {
+		rateLimit = productplan.DefaultRateLimiterConfig()
+	}
+
+	// The token must never reach a log line, wherever it is quoted from.
+	logging.AddSecret(cfg.Token)
+
 	return &Client{
-		baseURL: baseURL,
-		token:   cfg.Token,
+		baseURL:     baseURL,
+		token:       cfg.Token,
+		reloadToken: cfg.ReloadToken,
+		logPayloads: cfg.LogPayloads,
 		httpClient: &http.Client{
 			Timeout: timeout,
 			// SECURITY: Refuse all redirects. The configured BaseURL
@@ -123,18 +158,58 @@ func New(cfg Config) (*Client, error) {
 				return http.ErrUseLastResponse
 			},
 		},
-		rateLimiter: productplan.NewAdaptiveRateLimiter(productplan.DefaultRateLimiterConfig()),
+		rateLimiter: productplan.NewAdaptiveRateLimiter(rateLimit),
 		logger:      logger,
 	}, nil
 }
 
+func (c *Client) currentToken() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.token
+}
+
+// refreshToken reloads the token after a 401 on a request sent with used.
+// It reports whether the request is worth retrying: the token changed,
+// either now or since used was read by a concurrent request.
+func (c *Client) refreshToken(ctx context.Context, used string) bool {
+	if c.reloadToken == nil {
+		return false
+	}
+	c.reloadMu.Lock()
+	defer c.reloadMu.Unlock()
+	if c.currentToken() != used {
+		return true
+	}
+	token, err := c.reloadToken(ctx)
+	if err != nil {
+		c.logger.Warn("API token reload failed", logging.Error(err))
+		return false
+	}
+	if token == "" || token == used {
+		return false
+	}
+	logging.AddSecret(token)
+	c.tokenMu.Lock()
+	c.token = token
+	c.tokenMu.Unlock()
+	c.logger.Info("API token reloaded after 401")
+	return true
+}
+
 // NewSimple creates a client with just a token (uses defaults).
 func NewSimple(token string) (*Client, error) {
 	return New(DefaultConfig(token))
 }
 
+// WebURL returns the web app address of path, e.g. "/discovery/ideas/42",
+// on the host the client talks to.
+func (c *Client) WebURL(path string) string {
+	return strings.TrimSuffix(c.baseURL, "/api/v2") + path
+}
+
 // buildRequest constructs an HTTP request with auth and content-type headers attached.
-func (c *Client) buildRequest(ctx context.Context, method, endpoint string, body any) (*http.Request, error) {
+func (c *Client) buildRequest(ctx context.Context, method, endpoint, token string, body any) (*http.Request, error) {
 	var reqBody io.Reader
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
@@ -152,7 +227,7 @@ func (c *Client) buildRequest(ctx context.Context, method, endpoint string, body
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Content-Type", "application/json")
 	return req, nil
 }
@@ -172,23 +247,42 @@ func handleResponse(resp *http.Response, respBody []byte) (json.RawMessage, erro
 	return respBody, nil
 }
 
-// Request performs an HTTP request to the API.
+// Request performs an HTTP request to the API. A 401 is retried once when
+// the client can reload a rotated token.
 func (c *Client) Request(ctx context.Context, method, endpoint string, body any) (json.RawMessage, error) {
+	token := c.currentToken()
+	resp, respBody, err := c.do(ctx, method, endpoint, token, body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && c.refreshToken(ctx, token) {
+		if resp, respBody, err = c.do(ctx, method, endpoint, c.currentToken(), body); err != nil {
+			return nil, err
+		}
+	}
+	return handleResponse(resp, respBody)
+}
+
+// do sends one request with token and reads the response.
+func (c *Client) do(ctx context.Context, method, endpoint, token string, body any) (*http.Response, []byte, error) {
 	start := time.Now()
 
 	if c.rateLimiter != nil {
 		c.rateLimiter.Wait()
 	}
 
-	req, err := c.buildRequest(ctx, method, endpoint, body)
+	req, err := c.buildRequest(ctx, method, endpoint, token, body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	c.logger.Debug("API request",
-		logging.Endpoint(endpoint),
-		logging.F("method", method),
-	)
+	reqFields := []logging.Field{logging.Endpoint(endpoint), logging.F("method", method)}
+	if c.logPayloads && body != nil {
+		if data, marshalErr := json.Marshal(body); marshalErr == nil {
+			reqFields = append(reqFields, payloadField("request_body", data))
+		}
+	}
+	c.logger.Debug("API request", reqFields...)
 
 	resp, err := c.httpClient.Do(req) // #nosec G704 -- URL is the configured ProductPlan API endpoint, not user-controlled
 	if err != nil {
@@ -197,7 +291,7 @@ func (c *Client) Request(ctx context.Context, method, endpoint string, body any)
 			logging.Error(err),
 			logging.Duration(time.Since(start)),
 		)
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
@@ -207,16 +301,29 @@ func (c *Client) Request(ctx context.Context, method, endpoint string, body any)
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	c.logger.Debug("API response",
+	respFields := []logging.Field{
 		logging.Endpoint(endpoint),
 		logging.StatusCode(resp.StatusCode),
 		logging.Duration(time.Since(start)),
-	)
+	}
+	if c.logPayloads && len(respBody) > 0 {
+		respFields = append(respFields, payloadField("response_body", respBody))
+	}
+	c.logger.Debug("API response", respFields...)
 
-	return handleResponse(resp, respBody)
+	return resp, respBody, nil
+}
+
+// payloadField logs body under key, or only its size when it is too large
+// to log whole.
+func payloadField(key string, body []byte) logging.Field {
+	if len(body) > maxLoggedPayload {
+		return logging.F(key+"_bytes", len(body))
+	}
+	return logging.Payload(key, body)
 }
 
 // Get performs a GET request.
@@ -239,6 +346,11 @@ func (c *Client) Delete(ctx context.Context, endpoint string) (json.RawMessage,
 	return c.Request(ctx, http.MethodDelete, endpoint, nil)
 }
 
+// BaseURL returns the API base URL the client sends requests to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
 // RateLimiter returns the client's rate limiter for external use.
 func (c *Client) RateLimiter() *productplan.AdaptiveRateLimiter {
 	return c.rateLimiter
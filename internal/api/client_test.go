@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/olgasafonova/productplan-mcp-server/internal/logging"
+	"github.com/olgasafonova/productplan-mcp-server/pkg/productplan"
 )
 
 func TestNewClient(t *testing.T) {
@@ -102,6 +104,9 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.Timeout != DefaultTimeout {
 		t.Errorf("expected Timeout %v, got %v", DefaultTimeout, cfg.Timeout)
 	}
+	if cfg.RateLimit != productplan.DefaultRateLimiterConfig() {
+		t.Errorf("expected default rate limits, got %+v", cfg.RateLimit)
+	}
 }
 
 func TestClientRequest(t *testing.T) {
@@ -316,6 +321,123 @@ func TestClientSetLogger(t *testing.T) {
 	// No assertion needed; just verify it doesn't panic
 }
 
+func TestClientReloadTokenOn401(t *testing.T) {
+	valid := "rotated-token-2"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+valid {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	reloads := 0
+	var buf strings.Builder
+	client, err := New(Config{
+		Token:   "original-token-1",
+		BaseURL: server.URL,
+		Logger:  logging.NewWithWriter(&buf, logging.LevelDebug),
+		ReloadToken: func(context.Context) (string, error) {
+			reloads++
+			return valid, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = client.Get(context.Background(), "/test"); err != nil {
+		t.Fatalf("expected the request to succeed with the reloaded token, got %v", err)
+	}
+	if _, err = client.Get(context.Background(), "/test"); err != nil || reloads != 1 {
+		t.Errorf("expected one reload, got %d (%v)", reloads, err)
+	}
+
+	// A reload that returns the same token does not retry.
+	valid = "rotated-token-3"
+	client.reloadToken = func(context.Context) (string, error) { reloads++; return "rotated-token-2", nil }
+	if _, err = client.Get(context.Background(), "/test"); err == nil || reloads != 2 {
+		t.Errorf("expected a 401 error after one more reload, got %d (%v)", reloads, err)
+	}
+	if out := buf.String(); strings.Contains(out, "original-token-1") || strings.Contains(out, "rotated-token-2") {
+		t.Errorf("token logged: %s", out)
+	}
+}
+
+func TestClientReloadTokenDoesNotBlockRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/denied" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	reloading, release := make(chan struct{}), make(chan struct{})
+	client, err := New(Config{
+		Token:   "original-token-1",
+		BaseURL: server.URL,
+		ReloadToken: func(ctx context.Context) (string, error) {
+			close(reloading)
+			<-release
+			return "", errors.New("no new token")
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error)
+	go func() {
+		_, getErr := client.Get(context.Background(), "/denied")
+		done <- getErr
+	}()
+	<-reloading
+	// The reload is still running; other requests go ahead meanwhile.
+	if _, err = client.Get(context.Background(), "/ok"); err != nil {
+		t.Errorf("unexpected error during a reload: %v", err)
+	}
+	close(release)
+	if err = <-done; err == nil {
+		t.Error("expected the denied request to fail")
+	}
+}
+
+func TestClientLogPayloads(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": 5, "owner": "ana@example.com"}`))
+	}))
+	defer server.Close()
+
+	for _, logPayloads := range []bool{false, true} {
+		var buf strings.Builder
+		client, err := New(Config{
+			Token:       "test-token",
+			BaseURL:     server.URL,
+			Logger:      logging.NewWithWriter(&buf, logging.LevelDebug),
+			LogPayloads: logPayloads,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = client.Post(context.Background(), "/ideas", map[string]any{"name": "Dark mode", "email": "bo@example.com"}); err != nil {
+			t.Fatal(err)
+		}
+
+		out := buf.String()
+		if strings.Contains(out, "@example.com") {
+			t.Errorf("unredacted payload logged: %s", out)
+		}
+		logged := strings.Contains(out, `"request_body":{"email":"[REDACTED]","name":"Dark mode"}`) &&
+			strings.Contains(out, `"response_body":{"id":5,"owner":"[REDACTED]"}`)
+		if logged != logPayloads {
+			t.Errorf("LogPayloads=%v: unexpected log output %s", logPayloads, out)
+		}
+	}
+}
+
 func TestClientContextCancellation(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		time.Sleep(100 * time.Millisecond)
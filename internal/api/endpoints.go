@@ -385,9 +385,9 @@ func (c *Client) DeleteKeyResult(ctx context.Context, objectiveID, keyResultID s
 // Ideas
 // ============================================================================
 
-// ListIdeas returns all ideas.
+// ListIdeas returns all ideas, following pagination.
 func (c *Client) ListIdeas(ctx context.Context) (json.RawMessage, error) {
-	data, err := c.Get(ctx, "/discovery/ideas")
+	data, err := c.getAllPages(ctx, "/discovery/ideas")
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,904 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The Fetch* methods in this file return typed, uncapped records for the
+// server-side reports and analyses. The List*/Get* methods in endpoints.go
+// project and cap their payloads for AI consumption (see formatters.go), which
+// is the wrong shape for code that needs every item and every field.
+
+// ID is a ProductPlan identifier. Most resources carry numeric IDs and a few
+// carry strings; ID accepts both so callers can compare and print them
+// uniformly. JSON null decodes to the empty ID.
+type ID string
+
+// UnmarshalJSON accepts a JSON string, number, or null.
+func (id *ID) UnmarshalJSON(data []byte) error {
+	s, err := decodeScalar(data)
+	*id = ID(s)
+	return err
+}
+
+// String returns the ID as a plain string.
+func (id ID) String() string {
+	return string(id)
+}
+
+// Value is a free-form scalar field, such as a key result target, that the
+// API may send as either a string or a number. JSON null decodes to "".
+type Value string
+
+// UnmarshalJSON accepts a JSON string, number, or null.
+func (v *Value) UnmarshalJSON(data []byte) error {
+	s, err := decodeScalar(data)
+	*v = Value(s)
+	return err
+}
+
+// decodeScalar returns a JSON string, number or null as a Go string.
+func decodeScalar(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return "", nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		err := json.Unmarshal(data, &s)
+		return s, err
+	default:
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return "", fmt.Errorf("invalid scalar %s", data)
+		}
+		return n.String(), nil
+	}
+}
+
+// IDs is a list of references that arrive either as bare IDs or as objects
+// with an "id" field.
+type IDs []ID
+
+// UnmarshalJSON accepts an array of scalars and/or objects with an id, or null.
+func (ids *IDs) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	out := make(IDs, 0, len(raw))
+	for _, item := range raw {
+		var id ID
+		if err := json.Unmarshal(item, &id); err == nil {
+			out = append(out, id)
+			continue
+		}
+		var obj struct {
+			ID ID `json:"id"`
+		}
+		if err := json.Unmarshal(item, &obj); err != nil {
+			return fmt.Errorf("invalid id entry %s", item)
+		}
+		out = append(out, obj.ID)
+	}
+	*ids = out
+	return nil
+}
+
+// Names is a list of display names. Tags and customers arrive either as
+// plain strings or as objects with a "name" (or "label") field; Names
+// flattens both shapes to strings.
+type Names []string
+
+// UnmarshalJSON accepts an array of strings and/or named objects, or null.
+func (n *Names) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	out := make(Names, 0, len(raw))
+	for _, item := range raw {
+		var s string
+		if err := json.Unmarshal(item, &s); err == nil {
+			out = append(out, s)
+			continue
+		}
+		var obj struct {
+			Name  string `json:"name"`
+			Label string `json:"label"`
+		}
+		if err := json.Unmarshal(item, &obj); err != nil {
+			return fmt.Errorf("invalid name entry %s", item)
+		}
+		if obj.Name != "" {
+			out = append(out, obj.Name)
+		} else if obj.Label != "" {
+			out = append(out, obj.Label)
+		}
+	}
+	*n = out
+	return nil
+}
+
+// Roadmap is a roadmap as returned by GET /roadmaps/{id}. Legends are
+// embedded in the roadmap response.
+type Roadmap struct {
+	ID        ID       `json:"id"`
+	Name      string   `json:"name"`
+	UpdatedAt string   `json:"updated_at"`
+	Legends   []Legend `json:"legends"`
+}
+
+// Legend is a roadmap legend entry (bar color).
+type Legend struct {
+	ID    ID     `json:"id"`
+	Label string `json:"label"`
+	Color string `json:"color"`
+}
+
+// Lane is a roadmap lane.
+type Lane struct {
+	ID    ID     `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// CustomField is a named custom text or dropdown value on a bar.
+type CustomField struct {
+	Name  string `json:"name"`
+	Value Value  `json:"value"`
+}
+
+// Bar is a roadmap bar with every field the analyses use.
+type Bar struct {
+	ID                   ID            `json:"id"`
+	Name                 string        `json:"name"`
+	Description          string        `json:"description"`
+	LaneID               ID            `json:"lane_id"`
+	LegendID             ID            `json:"legend_id"`
+	ParentID             ID            `json:"parent_id"`
+	StartsOn             string        `json:"starts_on"`
+	EndsOn               string        `json:"ends_on"`
+	Effort               *float64      `json:"effort"`
+	PercentDone          *float64      `json:"percent_done"`
+	Container            bool          `json:"container"`
+	Parked               bool          `json:"parked"`
+	StrategicValue       string        `json:"strategic_value"`
+	Notes                string        `json:"notes"`
+	Tags                 Names         `json:"tags"`
+	CustomTextFields     []CustomField `json:"custom_text_fields"`
+	CustomDropdownFields []CustomField `json:"custom_dropdown_fields"`
+	// ObjectiveIDs and KeyResultIDs are strategy links, when the account
+	// exposes them on bars.
+	ObjectiveIDs IDs `json:"objective_ids"`
+	KeyResultIDs IDs `json:"key_result_ids"`
+	// OwnerID and OwnerName come from "owner_id"/"owner_name" or an
+	// "owner" object or string, when the account exposes bar owners.
+	OwnerID   ID     `json:"owner_id"`
+	OwnerName string `json:"owner_name"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// UnmarshalJSON decodes a bar, accepting start_date/end_date as aliases for
+// starts_on/ends_on, and objectives/key_results as aliases for the ID
+// lists. Read endpoints have returned both spellings. An "owner" object or
+// string fills in the owner fields.
+func (b *Bar) UnmarshalJSON(data []byte) error {
+	type plain Bar
+	aux := struct {
+		*plain
+		StartDate  string          `json:"start_date"`
+		EndDate    string          `json:"end_date"`
+		Objectives IDs             `json:"objectives"`
+		KeyResults IDs             `json:"key_results"`
+		Owner      json.RawMessage `json:"owner"`
+	}{plain: (*plain)(b)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if b.StartsOn == "" {
+		b.StartsOn = aux.StartDate
+	}
+	if b.EndsOn == "" {
+		b.EndsOn = aux.EndDate
+	}
+	if len(b.ObjectiveIDs) == 0 {
+		b.ObjectiveIDs = aux.Objectives
+	}
+	if len(b.KeyResultIDs) == 0 {
+		b.KeyResultIDs = aux.KeyResults
+	}
+	if b.OwnerName == "" {
+		b.OwnerName = personName(aux.Owner)
+	}
+	if b.OwnerID == "" {
+		b.OwnerID = personID(aux.Owner)
+	}
+	return nil
+}
+
+// CustomFields returns text and dropdown custom fields keyed by name.
+func (b Bar) CustomFields() map[string]string {
+	out := make(map[string]string, len(b.CustomTextFields)+len(b.CustomDropdownFields))
+	for _, f := range b.CustomTextFields {
+		out[f.Name] = string(f.Value)
+	}
+	for _, f := range b.CustomDropdownFields {
+		out[f.Name] = string(f.Value)
+	}
+	return out
+}
+
+// Connection is a dependency between two bars: the target bar depends on
+// the source bar. The source arrives as "source_bar_id", "bar_id" or
+// "from_bar_id" and the target as "target_bar_id" or "to_bar_id";
+// FetchBarConnections fills in a missing source with the requested bar.
+type Connection struct {
+	ID          ID `json:"id"`
+	SourceBarID ID `json:"source_bar_id"`
+	TargetBarID ID `json:"target_bar_id"`
+}
+
+// UnmarshalJSON decodes a connection, accepting the alternative field names.
+func (c *Connection) UnmarshalJSON(data []byte) error {
+	type plain Connection
+	aux := struct {
+		*plain
+		BarID     ID `json:"bar_id"`
+		FromBarID ID `json:"from_bar_id"`
+		ToBarID   ID `json:"to_bar_id"`
+	}{plain: (*plain)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	for _, id := range []ID{aux.BarID, aux.FromBarID} {
+		if c.SourceBarID == "" {
+			c.SourceBarID = id
+		}
+	}
+	if c.TargetBarID == "" {
+		c.TargetBarID = aux.ToBarID
+	}
+	return nil
+}
+
+// BarLink is an external link on a bar. The URL has arrived as "url" and
+// "link"; the label as "name" and "title".
+type BarLink struct {
+	ID   ID     `json:"id"`
+	URL  string `json:"url"`
+	Name string `json:"name"`
+}
+
+// UnmarshalJSON decodes a link, accepting the alternative field names.
+func (l *BarLink) UnmarshalJSON(data []byte) error {
+	type plain BarLink
+	aux := struct {
+		*plain
+		Link  string `json:"link"`
+		Title string `json:"title"`
+	}{plain: (*plain)(l)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if l.URL == "" {
+		l.URL = aux.Link
+	}
+	if l.Name == "" {
+		l.Name = aux.Title
+	}
+	return nil
+}
+
+// Milestone is a roadmap milestone. The API has used both "title" and
+// "name" for the label; Label returns whichever is set.
+type Milestone struct {
+	ID    ID     `json:"id"`
+	Title string `json:"title"`
+	Name  string `json:"name"`
+	Date  string `json:"date"`
+}
+
+// Label returns the milestone's display name.
+func (m Milestone) Label() string {
+	if m.Title != "" {
+		return m.Title
+	}
+	return m.Name
+}
+
+// Comment is a comment on a roadmap or bar. The body arrives as "body" or
+// "text" and the author as "author_name" or an "author"/"user" object or
+// string; Comment normalises them.
+type Comment struct {
+	ID         ID     `json:"id"`
+	Body       string `json:"body"`
+	AuthorName string `json:"author_name"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// UnmarshalJSON decodes a comment, accepting the alternative field names.
+func (c *Comment) UnmarshalJSON(data []byte) error {
+	type plain Comment
+	aux := struct {
+		*plain
+		Text   string          `json:"text"`
+		Author json.RawMessage `json:"author"`
+		User   json.RawMessage `json:"user"`
+	}{plain: (*plain)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if c.Body == "" {
+		c.Body = aux.Text
+	}
+	for _, raw := range []json.RawMessage{aux.Author, aux.User} {
+		if c.AuthorName != "" {
+			break
+		}
+		c.AuthorName = personName(raw)
+	}
+	return nil
+}
+
+// personName extracts a display name from a string or an object with
+// "name", or "first_name"/"last_name", or "email".
+func personName(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var obj struct {
+		Name      string `json:"name"`
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+		Email     string `json:"email"`
+	}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return ""
+	}
+	if obj.Name != "" {
+		return obj.Name
+	}
+	if full := strings.TrimSpace(obj.FirstName + " " + obj.LastName); full != "" {
+		return full
+	}
+	return obj.Email
+}
+
+// personID returns the id of an owner-style object, or "" for strings,
+// null and objects without one.
+func personID(raw json.RawMessage) ID {
+	var obj struct {
+		ID ID `json:"id"`
+	}
+	if len(raw) == 0 || json.Unmarshal(raw, &obj) != nil {
+		return ""
+	}
+	return obj.ID
+}
+
+// Launch is a launch as returned by GET /launches.
+type Launch struct {
+	ID          ID     `json:"id"`
+	Name        string `json:"name"`
+	Date        string `json:"date"`
+	Status      string `json:"status"`
+	Description string `json:"description"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// Idea is a discovery idea.
+type Idea struct {
+	ID                 ID     `json:"id"`
+	Name               string `json:"name"`
+	Description        string `json:"description"`
+	Channel            string `json:"channel"`
+	Status             string `json:"status"`
+	Customers          Names  `json:"customers"`
+	Tags               Names  `json:"tags"`
+	OpportunitiesCount int    `json:"opportunities_count"`
+	// OpportunityIDs are the linked opportunities, from "opportunity_ids"
+	// or an "opportunities" list, when the response includes them.
+	OpportunityIDs IDs `json:"opportunity_ids"`
+	// OwnerName comes from "owner_name" or an "owner" object or string.
+	OwnerName string `json:"owner_name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// UnmarshalJSON decodes an idea, resolving the owner's display name and
+// the opportunities alias.
+func (i *Idea) UnmarshalJSON(data []byte) error {
+	type plain Idea
+	aux := struct {
+		*plain
+		Owner         json.RawMessage `json:"owner"`
+		Opportunities IDs             `json:"opportunities"`
+	}{plain: (*plain)(i)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if i.OwnerName == "" {
+		i.OwnerName = personName(aux.Owner)
+	}
+	if len(i.OpportunityIDs) == 0 {
+		i.OpportunityIDs = aux.Opportunities
+	}
+	return nil
+}
+
+// Opportunity is a discovery opportunity: a problem statement that ideas
+// attach to.
+type Opportunity struct {
+	ID               ID     `json:"id"`
+	ProblemStatement string `json:"problem_statement"`
+	Description      string `json:"description"`
+	WorkflowStatus   string `json:"workflow_status"`
+	IdeasCount       int    `json:"ideas_count"`
+	Tags             Names  `json:"tags"`
+	// IdeaIDs are the linked ideas, from "idea_ids" or an "ideas" list,
+	// when the response includes them.
+	IdeaIDs IDs `json:"idea_ids"`
+	// OwnerName comes from "owner_name" or an "owner" object or string.
+	OwnerName string `json:"owner_name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// UnmarshalJSON decodes an opportunity, resolving the owner's display name
+// and the ideas alias.
+func (o *Opportunity) UnmarshalJSON(data []byte) error {
+	type plain Opportunity
+	aux := struct {
+		*plain
+		Owner json.RawMessage `json:"owner"`
+		Ideas IDs             `json:"ideas"`
+	}{plain: (*plain)(o)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if o.OwnerName == "" {
+		o.OwnerName = personName(aux.Owner)
+	}
+	if len(o.IdeaIDs) == 0 {
+		o.IdeaIDs = aux.Ideas
+	}
+	return nil
+}
+
+// User is an account user.
+type User struct {
+	ID        ID     `json:"id"`
+	Name      string `json:"name"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+}
+
+// Team is an account team.
+type Team struct {
+	ID   ID     `json:"id"`
+	Name string `json:"name"`
+	// UserIDs are the members, from "user_ids" or a "members" or "users"
+	// list, when the response includes them.
+	UserIDs IDs `json:"user_ids"`
+}
+
+// UnmarshalJSON decodes a team, accepting members and users as aliases for
+// user_ids.
+func (t *Team) UnmarshalJSON(data []byte) error {
+	type plain Team
+	aux := struct {
+		*plain
+		Members IDs `json:"members"`
+		Users   IDs `json:"users"`
+	}{plain: (*plain)(t)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if len(t.UserIDs) == 0 {
+		t.UserIDs = aux.Members
+	}
+	if len(t.UserIDs) == 0 {
+		t.UserIDs = aux.Users
+	}
+	return nil
+}
+
+// DisplayName returns the user's name, falling back to first and last
+// name, then email.
+func (u User) DisplayName() string {
+	if u.Name != "" {
+		return u.Name
+	}
+	if full := strings.TrimSpace(u.FirstName + " " + u.LastName); full != "" {
+		return full
+	}
+	return u.Email
+}
+
+// Objective is a strategy objective (OKR).
+type Objective struct {
+	ID          ID     `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	TimeFrame   string `json:"time_frame"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// KeyResult is a key result on an objective.
+type KeyResult struct {
+	ID           ID     `json:"id"`
+	Name         string `json:"name"`
+	TargetValue  Value  `json:"target_value"`
+	CurrentValue Value  `json:"current_value"`
+	StartValue   Value  `json:"starting_value"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+// LaunchSection is a checklist section on a launch.
+type LaunchSection struct {
+	ID        ID     `json:"id"`
+	Name      string `json:"name"`
+	Position  int    `json:"position"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// LaunchTask is a checklist task on a launch.
+type LaunchTask struct {
+	ID             ID     `json:"id"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	SectionID      ID     `json:"section_id"`
+	DueDate        string `json:"due_date"`
+	Status         string `json:"status"`
+	AssignedUserID ID     `json:"assigned_user_id"`
+	UpdatedAt      string `json:"updated_at"`
+}
+
+// decodeList decodes a list response into typed records. It accepts the same
+// bare-array and {"results": [...]} envelopes as unmarshalList.
+func decodeList[T any](data json.RawMessage, what string) ([]T, error) {
+	var list []T
+	if err := json.Unmarshal(data, &list); err == nil {
+		return list, nil
+	}
+	var wrapper struct {
+		Results []T `json:"results"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, fmt.Errorf("failed to parse %s response: %w", what, err)
+	}
+	return wrapper.Results, nil
+}
+
+// decodeItem decodes a single-object response into a typed record.
+func decodeItem[T any](data json.RawMessage, what string) (T, error) {
+	var item T
+	if err := json.Unmarshal(data, &item); err != nil {
+		return item, fmt.Errorf("failed to parse %s response: %w", what, err)
+	}
+	return item, nil
+}
+
+// CreatedID returns the id of the record in a create response, or an empty
+// ID when the response does not carry one.
+func CreatedID(data json.RawMessage) ID {
+	var created struct {
+		ID ID `json:"id"`
+	}
+	if err := json.Unmarshal(data, &created); err != nil {
+		return ""
+	}
+	return created.ID
+}
+
+// fetchList GETs endpoint and decodes the full list response.
+func fetchList[T any](ctx context.Context, c *Client, endpoint, what string) ([]T, error) {
+	data, err := c.Get(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return decodeList[T](data, what)
+}
+
+// Pagination limits for list endpoints that page their results.
+const (
+	pageSize = 100
+	// maxPages stops a walk over a server that keeps returning full pages.
+	maxPages = 200
+)
+
+// getAllPages GETs every page of a list endpoint and returns the items as a
+// single JSON array. A first page that is not a {"results": [...]}
+// envelope, such as a bare array, is taken as unpaginated and returned as
+// is. For an envelope the walk stops at "total_pages" when the server
+// reports it, otherwise at the first short page, or when a page repeats the
+// previous one because the server ignores the page parameters.
+func (c *Client) getAllPages(ctx context.Context, endpoint string) (json.RawMessage, error) {
+	var items []json.RawMessage
+	var prevFirst string
+	for page := 1; page <= maxPages; page++ {
+		data, err := c.Get(ctx, fmt.Sprintf("%s?page=%d&page_size=%d", endpoint, page, pageSize))
+		if err != nil {
+			return nil, err
+		}
+		if page == 1 {
+			var fields map[string]json.RawMessage
+			if json.Unmarshal(data, &fields) != nil {
+				return data, nil
+			}
+			if _, ok := fields["results"]; !ok {
+				return data, nil
+			}
+		}
+		var env struct {
+			Results    []json.RawMessage `json:"results"`
+			TotalPages int               `json:"total_pages"`
+		}
+		if err = json.Unmarshal(data, &env); err != nil {
+			return nil, fmt.Errorf("failed to parse page %d of %s: %w", page, endpoint, err)
+		}
+		if len(env.Results) == 0 || string(env.Results[0]) == prevFirst {
+			break
+		}
+		items = append(items, env.Results...)
+		prevFirst = string(env.Results[0])
+		if (env.TotalPages > 0 && page >= env.TotalPages) || (env.TotalPages == 0 && len(env.Results) < pageSize) {
+			break
+		}
+	}
+	if items == nil {
+		items = []json.RawMessage{}
+	}
+	return json.Marshal(items)
+}
+
+// ============================================================================
+// Roadmaps
+// ============================================================================
+
+// FetchRoadmaps returns every roadmap. Legends are not included in the
+// list response; use FetchRoadmap for those.
+func (c *Client) FetchRoadmaps(ctx context.Context) ([]Roadmap, error) {
+	return fetchList[Roadmap](ctx, c, "/roadmaps", "roadmaps")
+}
+
+// FetchRoadmap returns a single roadmap record.
+func (c *Client) FetchRoadmap(ctx context.Context, id string) (Roadmap, error) {
+	seg, err := safeSeg("roadmap_id", id)
+	if err != nil {
+		return Roadmap{}, err
+	}
+	data, err := c.Get(ctx, "/roadmaps/"+seg)
+	if err != nil {
+		return Roadmap{}, err
+	}
+	return decodeItem[Roadmap](data, "roadmap")
+}
+
+// FetchRoadmapMilestones returns every milestone on a roadmap.
+func (c *Client) FetchRoadmapMilestones(ctx context.Context, roadmapID string) ([]Milestone, error) {
+	seg, err := safeSeg("roadmap_id", roadmapID)
+	if err != nil {
+		return nil, err
+	}
+	return fetchList[Milestone](ctx, c, "/roadmaps/"+seg+"/milestones", "milestones")
+}
+
+// FetchRoadmapBars returns every bar on a roadmap.
+func (c *Client) FetchRoadmapBars(ctx context.Context, roadmapID string) ([]Bar, error) {
+	seg, err := safeSeg("roadmap_id", roadmapID)
+	if err != nil {
+		return nil, err
+	}
+	return fetchList[Bar](ctx, c, "/roadmaps/"+seg+"/bars", "bars")
+}
+
+// FetchRoadmapLanes returns every lane on a roadmap.
+func (c *Client) FetchRoadmapLanes(ctx context.Context, roadmapID string) ([]Lane, error) {
+	seg, err := safeSeg("roadmap_id", roadmapID)
+	if err != nil {
+		return nil, err
+	}
+	return fetchList[Lane](ctx, c, "/roadmaps/"+seg+"/lanes", "lanes")
+}
+
+// FetchRoadmapComments returns every comment on a roadmap.
+func (c *Client) FetchRoadmapComments(ctx context.Context, roadmapID string) ([]Comment, error) {
+	seg, err := safeSeg("roadmap_id", roadmapID)
+	if err != nil {
+		return nil, err
+	}
+	return fetchList[Comment](ctx, c, "/roadmaps/"+seg+"/comments", "comments")
+}
+
+// ============================================================================
+// Bars
+// ============================================================================
+
+// FetchBar returns a single bar record.
+func (c *Client) FetchBar(ctx context.Context, id string) (Bar, error) {
+	seg, err := safeSeg("bar_id", id)
+	if err != nil {
+		return Bar{}, err
+	}
+	data, err := c.Get(ctx, "/bars/"+seg)
+	if err != nil {
+		return Bar{}, err
+	}
+	return decodeItem[Bar](data, "bar")
+}
+
+// FetchBarChildren returns the bars directly under a container bar.
+func (c *Client) FetchBarChildren(ctx context.Context, barID string) ([]Bar, error) {
+	seg, err := safeSeg("bar_id", barID)
+	if err != nil {
+		return nil, err
+	}
+	return fetchList[Bar](ctx, c, "/bars/"+seg+"/child_bars", "child bars")
+}
+
+// FetchBarConnections returns the dependencies created from a bar.
+func (c *Client) FetchBarConnections(ctx context.Context, barID string) ([]Connection, error) {
+	seg, err := safeSeg("bar_id", barID)
+	if err != nil {
+		return nil, err
+	}
+	conns, err := fetchList[Connection](ctx, c, "/bars/"+seg+"/connections", "connections")
+	if err != nil {
+		return nil, err
+	}
+	for i := range conns {
+		if conns[i].SourceBarID == "" {
+			conns[i].SourceBarID = ID(barID)
+		}
+	}
+	return conns, nil
+}
+
+// FetchBarLinks returns the external links on a bar.
+func (c *Client) FetchBarLinks(ctx context.Context, barID string) ([]BarLink, error) {
+	seg, err := safeSeg("bar_id", barID)
+	if err != nil {
+		return nil, err
+	}
+	return fetchList[BarLink](ctx, c, "/bars/"+seg+"/links", "links")
+}
+
+// ============================================================================
+// Objectives
+// ============================================================================
+
+// FetchObjectives returns every objective.
+func (c *Client) FetchObjectives(ctx context.Context) ([]Objective, error) {
+	return fetchList[Objective](ctx, c, "/strategy/objectives", "objectives")
+}
+
+// FetchKeyResults returns every key result on an objective.
+func (c *Client) FetchKeyResults(ctx context.Context, objectiveID string) ([]KeyResult, error) {
+	seg, err := safeSeg("objective_id", objectiveID)
+	if err != nil {
+		return nil, err
+	}
+	return fetchList[KeyResult](ctx, c, "/strategy/objectives/"+seg+"/key_results", "key results")
+}
+
+// ============================================================================
+// Ideas
+// ============================================================================
+
+// FetchIdeas returns every idea, following pagination.
+func (c *Client) FetchIdeas(ctx context.Context) ([]Idea, error) {
+	data, err := c.getAllPages(ctx, "/discovery/ideas")
+	if err != nil {
+		return nil, err
+	}
+	return decodeList[Idea](data, "ideas")
+}
+
+// FetchIdea returns a single idea record.
+func (c *Client) FetchIdea(ctx context.Context, id string) (Idea, error) {
+	data, err := c.GetIdea(ctx, id)
+	if err != nil {
+		return Idea{}, err
+	}
+	return decodeItem[Idea](data, "idea")
+}
+
+// FetchOpportunities returns every opportunity, following pagination.
+func (c *Client) FetchOpportunities(ctx context.Context) ([]Opportunity, error) {
+	data, err := c.getAllPages(ctx, "/discovery/opportunities")
+	if err != nil {
+		return nil, err
+	}
+	return decodeList[Opportunity](data, "opportunities")
+}
+
+// FetchOpportunity returns a single opportunity record.
+func (c *Client) FetchOpportunity(ctx context.Context, id string) (Opportunity, error) {
+	data, err := c.GetOpportunity(ctx, id)
+	if err != nil {
+		return Opportunity{}, err
+	}
+	return decodeItem[Opportunity](data, "opportunity")
+}
+
+// ============================================================================
+// Launches
+// ============================================================================
+
+// FetchLaunches returns every launch.
+func (c *Client) FetchLaunches(ctx context.Context) ([]Launch, error) {
+	return fetchList[Launch](ctx, c, "/launches", "launches")
+}
+
+// FetchLaunch returns a single launch record.
+func (c *Client) FetchLaunch(ctx context.Context, id string) (Launch, error) {
+	data, err := c.GetLaunch(ctx, id)
+	if err != nil {
+		return Launch{}, err
+	}
+	return decodeItem[Launch](data, "launch")
+}
+
+// FetchLaunchSections returns every checklist section on a launch.
+func (c *Client) FetchLaunchSections(ctx context.Context, launchID string) ([]LaunchSection, error) {
+	seg, err := safeSeg("launch_id", launchID)
+	if err != nil {
+		return nil, err
+	}
+	return fetchList[LaunchSection](ctx, c, "/launches/"+seg+"/checklist_sections", "launch sections")
+}
+
+// FetchLaunchTasks returns every task on a launch.
+func (c *Client) FetchLaunchTasks(ctx context.Context, launchID string) ([]LaunchTask, error) {
+	seg, err := safeSeg("launch_id", launchID)
+	if err != nil {
+		return nil, err
+	}
+	return fetchList[LaunchTask](ctx, c, "/launches/"+seg+"/tasks", "launch tasks")
+}
+
+// ============================================================================
+// Admin
+// ============================================================================
+
+// FetchUsers returns every user in the account.
+func (c *Client) FetchUsers(ctx context.Context) ([]User, error) {
+	return fetchList[User](ctx, c, "/users", "users")
+}
+
+// FetchTeams returns every team in the account.
+func (c *Client) FetchTeams(ctx context.Context) ([]Team, error) {
+	return fetchList[Team](ctx, c, "/teams", "teams")
+}
+
+// ParseDate parses a date field as the API returns it: either a plain
+// YYYY-MM-DD date or an RFC 3339 timestamp. The result is truncated to the
+// calendar day in UTC. ok is false for empty or unparseable values.
+func ParseDate(s string) (t time.Time, ok bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,349 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIDUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want ID
+	}{
+		{"number", `123`, "123"},
+		{"string", `"abc-1"`, "abc-1"},
+		{"null", `null`, ""},
+		{"large number", `9007199254740993`, "9007199254740993"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var id ID
+			if err := json.Unmarshal([]byte(tt.in), &id); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if id != tt.want {
+				t.Errorf("got %q, want %q", id, tt.want)
+			}
+		})
+	}
+
+	var id ID
+	if err := json.Unmarshal([]byte(`true`), &id); err == nil {
+		t.Error("expected error for boolean id")
+	}
+}
+
+func TestBarStrategyLinks(t *testing.T) {
+	var bars []Bar
+	data := `[
+		{"id": 1, "objective_ids": [10, "11"], "key_result_ids": null},
+		{"id": 2, "objectives": [{"id": 12, "name": "Grow"}], "key_results": [{"id": 21}]}
+	]`
+	if err := json.Unmarshal([]byte(data), &bars); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := bars[0].ObjectiveIDs; len(got) != 2 || got[0] != "10" || got[1] != "11" {
+		t.Errorf("objective_ids = %v", got)
+	}
+	if len(bars[0].KeyResultIDs) != 0 {
+		t.Errorf("expected no key results, got %v", bars[0].KeyResultIDs)
+	}
+	if got := bars[1].ObjectiveIDs; len(got) != 1 || got[0] != "12" {
+		t.Errorf("objectives alias = %v", got)
+	}
+	if got := bars[1].KeyResultIDs; len(got) != 1 || got[0] != "21" {
+		t.Errorf("key_results alias = %v", got)
+	}
+
+	var ids IDs
+	if err := json.Unmarshal([]byte(`[true]`), &ids); err == nil {
+		t.Error("expected error for boolean id entry")
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"2026-03-15", "2026-03-15", true},
+		{"2026-03-15T23:30:00-05:00", "2026-03-15", true},
+		{" 2026-03-15 ", "2026-03-15", true},
+		{"", "", false},
+		{"next week", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseDate(tt.in)
+		if ok != tt.ok {
+			t.Errorf("ParseDate(%q) ok = %v, want %v", tt.in, ok, tt.ok)
+			continue
+		}
+		if ok && got.Format(time.DateOnly) != tt.want {
+			t.Errorf("ParseDate(%q) = %s, want %s", tt.in, got.Format(time.DateOnly), tt.want)
+		}
+	}
+}
+
+func TestFetchRoadmapMilestones(t *testing.T) {
+	server := testServer(t, map[string]string{
+		"/roadmaps/1/milestones": `{"results": [{"id": 7, "title": "Beta", "date": "2026-05-01"}, {"id": 8, "name": "GA", "date": "2026-06-01"}]}`,
+	})
+	defer server.Close()
+	client := testClient(t, server)
+
+	milestones, err := client.FetchRoadmapMilestones(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(milestones) != 2 {
+		t.Fatalf("expected 2 milestones, got %d", len(milestones))
+	}
+	if milestones[0].ID != "7" || milestones[0].Label() != "Beta" {
+		t.Errorf("unexpected first milestone: %+v", milestones[0])
+	}
+	if milestones[1].Label() != "GA" {
+		t.Errorf("expected Label to fall back to name, got %q", milestones[1].Label())
+	}
+}
+
+func TestFetchBarConnections(t *testing.T) {
+	server := testServer(t, map[string]string{
+		"/bars/5/connections": `[{"id": 1, "target_bar_id": 6}, {"id": 2, "from_bar_id": 4, "to_bar_id": 5}]`,
+	})
+	defer server.Close()
+	client := testClient(t, server)
+
+	conns, err := client.FetchBarConnections(context.Background(), "5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conns) != 2 {
+		t.Fatalf("expected 2 connections, got %d", len(conns))
+	}
+	if conns[0].SourceBarID != "5" || conns[0].TargetBarID != "6" {
+		t.Errorf("expected missing source filled with the bar, got %+v", conns[0])
+	}
+	if conns[1].SourceBarID != "4" || conns[1].TargetBarID != "5" {
+		t.Errorf("expected from/to aliases, got %+v", conns[1])
+	}
+}
+
+func TestFetchBarLinks(t *testing.T) {
+	server := testServer(t, map[string]string{
+		"/bars/5/links": `[{"id": 1, "url": "https://a.example", "name": "Spec"}, {"id": 2, "link": "https://b.example", "title": "Design"}]`,
+	})
+	defer server.Close()
+	client := testClient(t, server)
+
+	links, err := client.FetchBarLinks(context.Background(), "5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(links) != 2 || links[0].URL != "https://a.example" || links[1].URL != "https://b.example" || links[1].Name != "Design" {
+		t.Errorf("unexpected links %+v", links)
+	}
+}
+
+func TestFetchBarAndChildren(t *testing.T) {
+	server := testServer(t, map[string]string{
+		"/bars/5":            `{"id": 5, "name": "Epic", "container": true}`,
+		"/bars/5/child_bars": `[{"id": 6, "name": "Story", "parent_id": 5, "effort": 3}]`,
+	})
+	defer server.Close()
+	client := testClient(t, server)
+
+	bar, err := client.FetchBar(context.Background(), "5")
+	if err != nil || bar.Name != "Epic" || !bar.Container {
+		t.Errorf("unexpected bar %+v, %v", bar, err)
+	}
+	children, err := client.FetchBarChildren(context.Background(), "5")
+	if err != nil || len(children) != 1 || children[0].ParentID != "5" || *children[0].Effort != 3 {
+		t.Errorf("unexpected children %+v, %v", children, err)
+	}
+	if _, err = client.FetchBarChildren(context.Background(), "../5"); err == nil {
+		t.Error("expected error for an unsafe bar ID")
+	}
+}
+
+func TestFetchLaunchTasksRejectsUnsafeID(t *testing.T) {
+	server := testServer(t, map[string]string{})
+	defer server.Close()
+	client := testClient(t, server)
+
+	if _, err := client.FetchLaunchTasks(context.Background(), "../users"); err == nil {
+		t.Error("expected validation error for unsafe launch_id")
+	}
+}
+
+func TestFetchLaunchesParseError(t *testing.T) {
+	server := testServer(t, map[string]string{
+		"/launches": `"not a list"`,
+	})
+	defer server.Close()
+	client := testClient(t, server)
+
+	if _, err := client.FetchLaunches(context.Background()); err == nil {
+		t.Error("expected parse error")
+	}
+}
+
+func TestCreatedID(t *testing.T) {
+	tests := map[string]ID{
+		`{"id": 42, "name": "Bar"}`: "42",
+		`{"id": "abc"}`:             "abc",
+		`{"name": "no id"}`:         "",
+		`not json`:                  "",
+	}
+	for in, want := range tests {
+		if got := CreatedID(json.RawMessage(in)); got != want {
+			t.Errorf("CreatedID(%s) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFetchOpportunitiesAndUsers(t *testing.T) {
+	server := testServer(t, map[string]string{
+		"/discovery/opportunities": `[{"id": 1, "problem_statement": "Slow", "owner": {"first_name": "Ana", "last_name": "Lee"}}]`,
+		"/users":                   `[{"id": 7, "first_name": "Bo", "last_name": "Ek"}, {"id": 8, "email": "cy@example.com"}]`,
+	})
+	defer server.Close()
+	client := testClient(t, server)
+
+	opps, err := client.FetchOpportunities(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(opps) != 1 || opps[0].OwnerName != "Ana Lee" {
+		t.Errorf("unexpected opportunities %+v", opps)
+	}
+
+	users, err := client.FetchUsers(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(users) != 2 || users[0].DisplayName() != "Bo Ek" || users[1].DisplayName() != "cy@example.com" {
+		t.Errorf("unexpected users %+v", users)
+	}
+
+	var idea Idea
+	if err := json.Unmarshal([]byte(`{"id": 3, "owner": "Dee"}`), &idea); err != nil || idea.OwnerName != "Dee" {
+		t.Errorf("idea owner = %q, %v", idea.OwnerName, err)
+	}
+}
+
+func TestFetchIdeaAndOpportunity(t *testing.T) {
+	server := testServer(t, map[string]string{
+		"/discovery/ideas/3":         `{"id": 3, "name": "Bulk export", "tags": [{"name": "Data"}]}`,
+		"/discovery/opportunities/5": `{"id": 5, "problem_statement": "Reports are slow", "tags": ["Perf"]}`,
+	})
+	defer server.Close()
+	client := testClient(t, server)
+
+	idea, err := client.FetchIdea(context.Background(), "3")
+	if err != nil || idea.Name != "Bulk export" || len(idea.Tags) != 1 || idea.Tags[0] != "Data" {
+		t.Errorf("unexpected idea %+v, %v", idea, err)
+	}
+	opp, err := client.FetchOpportunity(context.Background(), "5")
+	if err != nil || opp.ProblemStatement != "Reports are slow" || len(opp.Tags) != 1 {
+		t.Errorf("unexpected opportunity %+v, %v", opp, err)
+	}
+	if got := client.WebURL("/discovery/ideas/3"); got != server.URL+"/discovery/ideas/3" {
+		t.Errorf("WebURL = %q", got)
+	}
+}
+
+func TestBarOwnersAndTeams(t *testing.T) {
+	var bars []Bar
+	if err := json.Unmarshal([]byte(`[
+		{"id": 1, "owner": {"id": 7, "first_name": "Ana", "last_name": "Lee"}},
+		{"id": 2, "owner": "Bo"},
+		{"id": 3, "owner_id": 9}
+	]`), &bars); err != nil {
+		t.Fatalf("decode bars: %v", err)
+	}
+	if bars[0].OwnerID != "7" || bars[0].OwnerName != "Ana Lee" || bars[1].OwnerName != "Bo" || bars[1].OwnerID != "" || bars[2].OwnerID != "9" {
+		t.Errorf("unexpected owners %+v", bars)
+	}
+
+	server := testServer(t, map[string]string{
+		"/teams": `[{"id": 1, "name": "Web", "members": [{"id": 7}, {"id": 8}]}, {"id": 2, "name": "Ops", "user_ids": [9]}]`,
+	})
+	defer server.Close()
+	teams, err := testClient(t, server).FetchTeams(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(teams) != 2 || len(teams[0].UserIDs) != 2 || teams[1].UserIDs[0] != "9" {
+		t.Errorf("unexpected teams %+v", teams)
+	}
+}
+
+func TestFetchIdeasFollowsPages(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page+"/"+r.URL.Query().Get("page_size"))
+		switch page {
+		case "1":
+			w.Write([]byte(`{"total_pages": 2, "results": [{"id": 1, "name": "A"}]}`))
+		case "2":
+			w.Write([]byte(`{"total_pages": 2, "results": [{"id": 2, "name": "B"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := testClient(t, server)
+
+	ideas, err := client.FetchIdeas(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ideas) != 2 || ideas[1].Name != "B" || strings.Join(pages, ",") != "1/100,2/100" {
+		t.Errorf("ideas %+v after pages %v", ideas, pages)
+	}
+}
+
+func TestGetAllPagesStops(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want int
+		raw  bool
+	}{
+		{"bare array", `[{"id": 1}, {"id": 2}]`, 1, true},
+		{"other shape", `{"data": [{"id": 1}]}`, 1, true},
+		{"short page", `{"results": [{"id": 1}]}`, 1, false},
+		{"repeated page", `{"results": [` + strings.TrimSuffix(strings.Repeat(`{"id": 1},`, 100), ",") + `]}`, 2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			data, err := testClient(t, server).getAllPages(context.Background(), "/discovery/ideas")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if requests != tt.want {
+				t.Errorf("made %d requests, want %d", requests, tt.want)
+			}
+			if tt.raw && string(data) != tt.body {
+				t.Errorf("expected the first page unchanged, got %s", data)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Package apitest serves canned ProductPlan API responses for tests.
+package apitest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+)
+
+// NewClient returns a client for a test server that answers each request
+// path in routes with its JSON body, and any other path with 404. The
+// server is closed when the test ends.
+func NewClient(t testing.TB, routes map[string]string) *api.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := api.New(api.Config{Token: "test-token", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
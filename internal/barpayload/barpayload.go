@@ -0,0 +1,81 @@
+// Package barpayload builds the request bodies for creating and updating
+// bars, so the manage_bar tool and the CSV importer send identical
+// requests.
+package barpayload
+
+// CustomField represents a name-value pair for custom fields.
+type CustomField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Fields holds the bar fields a create or update request can carry.
+type Fields struct {
+	RoadmapID            string        `json:"roadmap_id,omitempty"`
+	LaneID               string        `json:"lane_id,omitempty"`
+	Name                 string        `json:"name,omitempty"`
+	StartsOn             string        `json:"starts_on,omitempty"`
+	EndsOn               string        `json:"ends_on,omitempty"`
+	Description          string        `json:"description,omitempty"`
+	LegendID             string        `json:"legend_id,omitempty"`
+	PercentDone          *int          `json:"percent_done,omitempty"`
+	Container            *bool         `json:"container,omitempty"`
+	Parked               *bool         `json:"parked,omitempty"`
+	ParentID             string        `json:"parent_id,omitempty"`
+	StrategicValue       string        `json:"strategic_value,omitempty"`
+	Notes                string        `json:"notes,omitempty"`
+	Effort               *int          `json:"effort,omitempty"`
+	Tags                 []string      `json:"tags,omitempty"`
+	CustomTextFields     []CustomField `json:"custom_text_fields,omitempty"`
+	CustomDropdownFields []CustomField `json:"custom_dropdown_fields,omitempty"`
+}
+
+// Create builds the API payload for creating a bar.
+func Create(f Fields) map[string]any {
+	payload := map[string]any{
+		"roadmap_id": f.RoadmapID,
+		"lane_id":    f.LaneID,
+		"name":       f.Name,
+	}
+	AddOptional(payload, f)
+	return payload
+}
+
+// AddOptional adds the optional bar fields that are set to payload.
+func AddOptional(payload map[string]any, f Fields) {
+	setIfNotEmpty(payload, "starts_on", f.StartsOn)
+	setIfNotEmpty(payload, "ends_on", f.EndsOn)
+	setIfNotEmpty(payload, "description", f.Description)
+	setIfNotEmpty(payload, "legend_id", f.LegendID)
+	setIfNotEmpty(payload, "parent_id", f.ParentID)
+	setIfNotEmpty(payload, "strategic_value", f.StrategicValue)
+	setIfNotEmpty(payload, "notes", f.Notes)
+	setIfNotNil(payload, "percent_done", f.PercentDone)
+	setIfNotNil(payload, "container", f.Container)
+	setIfNotNil(payload, "parked", f.Parked)
+	setIfNotNil(payload, "effort", f.Effort)
+	setIfNotEmptySlice(payload, "tags", f.Tags)
+	setIfNotEmptySlice(payload, "custom_text_fields", f.CustomTextFields)
+	setIfNotEmptySlice(payload, "custom_dropdown_fields", f.CustomDropdownFields)
+}
+
+// setIfNotEmpty adds a key-value pair to the payload if the value is not empty.
+func setIfNotEmpty(payload map[string]any, key, value string) {
+	if value != "" {
+		payload[key] = value
+	}
+}
+
+// setIfNotNil adds a key-value pair to the payload if the pointer is not nil.
+func setIfNotNil[T any](payload map[string]any, key string, value *T) {
+	if value != nil {
+		payload[key] = *value
+	}
+}
+
+// setIfNotEmptySlice adds a key-value pair to the payload if the slice is not empty.
+func setIfNotEmptySlice[T any](payload map[string]any, key string, value []T) {
+	if len(value) > 0 {
+		payload[key] = value
+	}
+}
@@ -0,0 +1,19 @@
+package barpayload
+
+import "testing"
+
+func TestCreate(t *testing.T) {
+	effort := 5
+	payload := Create(Fields{RoadmapID: "1", LaneID: "2", Name: "Search", Effort: &effort, Tags: []string{"api"}})
+	if len(payload) != 5 || payload["lane_id"] != "2" || payload["effort"] != 5 {
+		t.Errorf("unexpected create payload %v", payload)
+	}
+}
+
+func TestAddOptional(t *testing.T) {
+	payload := make(map[string]any)
+	AddOptional(payload, Fields{Name: "ignored", Notes: "n", Tags: []string{}})
+	if len(payload) != 1 || payload["notes"] != "n" {
+		t.Errorf("expected only the set optional fields, got %v", payload)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 
 	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
 )
 
 // Config holds CLI configuration.
@@ -17,6 +18,8 @@ type Config struct {
 	Version string
 	Output  io.Writer
 	Error   io.Writer
+	// Calendar places the quarters reports and exports group by.
+	Calendar dates.Calendar
 }
 
 // CLI handles command-line operations.
@@ -129,6 +132,21 @@ func (c *CLI) Run(args []string) int {
 	case "status":
 		result, err = c.client.CheckStatus(ctx)
 
+	case "calendar":
+		return c.runCalendar(ctx, subArgs)
+
+	case "export":
+		return c.runExport(ctx, subArgs)
+
+	case "import":
+		return c.runImport(ctx, subArgs)
+
+	case "report":
+		return c.runReport(ctx, subArgs)
+
+	case "lint":
+		return c.runLint(ctx, subArgs)
+
 	default:
 		c.PrintUsage()
 		return 1
@@ -162,6 +180,11 @@ Commands:
   opportunities [id]                   List opportunities or get details
   launches [id]                        List launches or get details
   status                               Check API status
+  calendar [roadmap_id ...]            Export milestones and launches as iCalendar (.ics)
+  export --format csv <kind> [id]      Export bars, ideas or objectives as CSV
+  import bars --roadmap <id> <file>    Create bars from a CSV (dry run unless --apply)
+  report [--compact] <roadmap_id> ...  Markdown status report (--compact for Slack)
+  lint [--format json] <roadmap_id>    Check a roadmap for stale and malformed items
 
 Environment:
   PRODUCTPLAN_API_TOKEN                Your ProductPlan API token (required)
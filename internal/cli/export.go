@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/export"
+)
+
+// runCalendar writes an iCalendar document for the given roadmaps and launches.
+func (c *CLI) runCalendar(ctx context.Context, args []string) int {
+	fs := flag.NewFlagSet("calendar", flag.ContinueOnError)
+	fs.SetOutput(c.errOut)
+	noLaunches := fs.Bool("no-launches", false, "omit launch dates")
+	noTasks := fs.Bool("no-tasks", false, "omit launch task due dates")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(c.errOut, "Usage: productplan calendar [--no-launches] [--no-tasks] [roadmap_id ...]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if fs.NArg() == 0 && *noLaunches {
+		fs.Usage()
+		return 1
+	}
+
+	cal, err := export.BuildCalendar(ctx, c.client, export.CalendarOptions{
+		RoadmapIDs:      fs.Args(),
+		IncludeLaunches: !*noLaunches,
+		IncludeTasks:    !*noTasks,
+	})
+	if err != nil {
+		_, _ = fmt.Fprintf(c.errOut, "Error: %v\n", err)
+		return 1
+	}
+	_, _ = fmt.Fprint(c.output, cal.Encode())
+	return 0
+}
+
+// exportUsage documents the export command.
+const exportUsage = `Usage: productplan export [--format csv] [--columns a,b,c] [--bom] [--list-columns] <kind> [roadmap_id]
+
+Kinds:
+  bars <roadmap_id>     One row per bar, with lane name, legend label and custom fields
+  ideas                 One row per idea, with customers and tags
+  objectives            One row per key result, with its objective`
+
+// runExport writes bars, ideas or objectives as CSV.
+func (c *CLI) runExport(ctx context.Context, args []string) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(c.errOut)
+	format := fs.String("format", "csv", "output format (csv)")
+	columns := fs.String("columns", "", "comma-separated columns to include, in order (default: all)")
+	bom := fs.Bool("bom", false, "write a UTF-8 BOM and CRLF line endings for Excel")
+	listColumns := fs.Bool("list-columns", false, "print the available columns and exit")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(c.errOut, exportUsage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if *format != "csv" {
+		_, _ = fmt.Fprintf(c.errOut, "Error: unsupported format %q (supported: csv)\n", *format)
+		return 1
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 1
+	}
+
+	var selected []string
+	if *columns != "" {
+		selected = strings.Split(*columns, ",")
+	}
+	opts := export.CSVOptions{BOM: *bom}
+
+	var err error
+	switch kind := fs.Arg(0); kind {
+	case "bars":
+		if fs.NArg() < 2 {
+			fs.Usage()
+			return 1
+		}
+		var rows []export.BarRow
+		if rows, err = export.BarRows(ctx, c.client, fs.Arg(1)); err == nil {
+			err = writeTable(c, export.BarColumns(export.CustomFieldNames(rows), c.cfg.Calendar), rows, selected, *listColumns, opts)
+		}
+	case "ideas":
+		var ideas []api.Idea
+		if ideas, err = c.client.FetchIdeas(ctx); err == nil {
+			err = writeTable(c, export.IdeaColumns(), ideas, selected, *listColumns, opts)
+		}
+	case "objectives":
+		var rows []export.ObjectiveRow
+		if rows, err = export.ObjectiveRows(ctx, c.client); err == nil {
+			err = writeTable(c, export.ObjectiveColumns(), rows, selected, *listColumns, opts)
+		}
+	default:
+		_, _ = fmt.Fprintf(c.errOut, "Error: unknown export kind %q\n", kind)
+		fs.Usage()
+		return 1
+	}
+
+	if err != nil {
+		_, _ = fmt.Fprintf(c.errOut, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// writeTable selects columns and writes rows as CSV, or lists the available
+// columns when listOnly is set.
+func writeTable[T any](c *CLI, all []export.Column[T], rows []T, selected []string, listOnly bool, opts export.CSVOptions) error {
+	if listOnly {
+		_, err := fmt.Fprintln(c.output, strings.Join(export.ColumnNames(all), "\n"))
+		return err
+	}
+	cols, err := export.SelectColumns(all, selected)
+	if err != nil {
+		return err
+	}
+	return export.WriteCSV(c.output, cols, rows, opts)
+}
+
+// runReport writes a Markdown status report for each roadmap.
+func (c *CLI) runReport(ctx context.Context, args []string) int {
+	defaults := export.DefaultReportOptions()
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	fs.SetOutput(c.errOut)
+	groupBy := fs.String("group-by", defaults.GroupBy, "group bars by horizon (now/next/later) or quarter")
+	days := fs.Int("milestone-days", defaults.MilestoneDays, "include milestones within this many days (0 to omit)")
+	comments := fs.Int("comments", defaults.CommentLimit, "number of recent comments to include (0 to omit)")
+	compact := fs.Bool("compact", false, "short Slack-ready summary instead of the full report")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(c.errOut, "Usage: productplan report [--group-by horizon|quarter] [--milestone-days N] [--comments N] [--compact] <roadmap_id> ...")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 1
+	}
+
+	opts := export.ReportOptions{GroupBy: *groupBy, MilestoneDays: *days, CommentLimit: *comments, Calendar: c.cfg.Calendar}
+	if *compact {
+		opts.CommentLimit = 0
+	}
+	for i, id := range fs.Args() {
+		r, err := export.BuildReport(ctx, c.client, id, opts)
+		if err != nil {
+			_, _ = fmt.Fprintf(c.errOut, "Error: %v\n", err)
+			return 1
+		}
+		if i > 0 {
+			separator := "\n---\n\n"
+			if *compact {
+				separator = "\n"
+			}
+			_, _ = fmt.Fprint(c.output, separator)
+		}
+		if *compact {
+			_, _ = fmt.Fprint(c.output, r.Compact())
+		} else {
+			_, _ = fmt.Fprint(c.output, r.Markdown())
+		}
+	}
+	return 0
+}
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+)
+
+// setupRoutedCLI serves canned JSON bodies keyed by request path and returns
+// a CLI wired to capture stdout and stderr.
+func setupRoutedCLI(t *testing.T, routes map[string]string) (*CLI, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	client := apitest.NewClient(t, routes)
+	output := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+	return New(client, Config{Version: "test", Output: output, Error: errOut}), output, errOut
+}
+
+func TestCLI_Run_Calendar(t *testing.T) {
+	cli, output, _ := setupRoutedCLI(t, map[string]string{
+		"/roadmaps/1":            `{"id": 1, "name": "Core"}`,
+		"/roadmaps/1/milestones": `[{"id": 2, "title": "Beta", "date": "2026-05-01"}]`,
+	})
+
+	code := cli.Run([]string{"calendar", "--no-launches", "1"})
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if !strings.Contains(output.String(), "UID:milestone-1-2@productplan-mcp-server") {
+		t.Errorf("expected milestone event in output, got:\n%s", output.String())
+	}
+}
+
+func TestCLI_Run_CalendarNothingSelected(t *testing.T) {
+	cli, _, errOut := setupRoutedCLI(t, map[string]string{})
+
+	code := cli.Run([]string{"calendar", "--no-launches"})
+	if code != 1 {
+		t.Errorf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(errOut.String(), "Usage") {
+		t.Errorf("expected usage on stderr, got %q", errOut.String())
+	}
+}
+
+func TestCLI_Run_ExportCSV(t *testing.T) {
+	cli, output, _ := setupRoutedCLI(t, map[string]string{
+		"/discovery/ideas": `[{"id": 1, "name": "Dark mode", "tags": ["ui"]}]`,
+	})
+
+	code := cli.Run([]string{"export", "--format", "csv", "--columns", "id,name,tags", "ideas"})
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if output.String() != "id,name,tags\n1,Dark mode,ui\n" {
+		t.Errorf("unexpected output %q", output.String())
+	}
+}
+
+func TestCLI_Run_ExportErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		errPart string
+	}{
+		{"no kind", []string{"export"}, "Usage"},
+		{"bad format", []string{"export", "--format", "xml", "ideas"}, "unsupported format"},
+		{"unknown kind", []string{"export", "widgets"}, "unknown export kind"},
+		{"bars without roadmap", []string{"export", "bars"}, "Usage"},
+		{"unknown column", []string{"export", "--columns", "nope", "ideas"}, "unknown column"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli, _, errOut := setupRoutedCLI(t, map[string]string{
+				"/discovery/ideas": `[]`,
+			})
+			if code := cli.Run(tt.args); code != 1 {
+				t.Errorf("expected exit code 1, got %d", code)
+			}
+			if !strings.Contains(errOut.String(), tt.errPart) {
+				t.Errorf("expected %q in stderr, got %q", tt.errPart, errOut.String())
+			}
+		})
+	}
+}
+
+func TestCLI_Run_ExportListColumns(t *testing.T) {
+	cli, output, _ := setupRoutedCLI(t, map[string]string{
+		"/strategy/objectives": `[]`,
+	})
+
+	if code := cli.Run([]string{"export", "--list-columns", "objectives"}); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if !strings.Contains(output.String(), "key_result_id\n") {
+		t.Errorf("expected column list, got %q", output.String())
+	}
+}
+
+func TestCLI_Run_Report(t *testing.T) {
+	routes := map[string]string{
+		"/roadmaps/1":            `{"id": 1, "name": "Core"}`,
+		"/roadmaps/1/lanes":      `[{"id": 3, "name": "Backend"}]`,
+		"/roadmaps/1/bars":       `[{"id": 10, "name": "Search", "lane_id": 3, "starts_on": "2020-01-01", "ends_on": "2099-01-01"}]`,
+		"/roadmaps/1/milestones": `[]`,
+		"/roadmaps/1/comments":   `[]`,
+	}
+
+	cli, output, _ := setupRoutedCLI(t, routes)
+	if code := cli.Run([]string{"report", "1"}); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if !strings.HasPrefix(output.String(), "# Core: roadmap status") || !strings.Contains(output.String(), "## Recent comments") {
+		t.Errorf("unexpected report:\n%s", output.String())
+	}
+
+	cli, output, _ = setupRoutedCLI(t, routes)
+	if code := cli.Run([]string{"report", "--compact", "1", "1"}); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if strings.Count(output.String(), "*Now:* Search") != 2 {
+		t.Errorf("expected one compact summary per roadmap:\n%s", output.String())
+	}
+}
+
+func TestCLI_Run_ReportErrors(t *testing.T) {
+	cli, _, errOut := setupRoutedCLI(t, map[string]string{})
+	if code := cli.Run([]string{"report"}); code != 1 {
+		t.Errorf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(errOut.String(), "Usage: productplan report") {
+		t.Errorf("expected usage, got %q", errOut.String())
+	}
+
+	cli, _, errOut = setupRoutedCLI(t, map[string]string{})
+	if code := cli.Run([]string{"report", "--group-by", "month", "1"}); code != 1 {
+		t.Errorf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(errOut.String(), "unknown grouping") {
+		t.Errorf("expected grouping error, got %q", errOut.String())
+	}
+}
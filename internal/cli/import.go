@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/importer"
+)
+
+// importUsage documents the import command.
+const importUsage = `Usage: productplan import bars --roadmap <roadmap_id> [--apply] <file.csv>
+
+Reads one bar per row. Without --apply, prints what would be created and exits.
+
+Columns:
+  name (required), lane or lane_id (required), legend or legend_id,
+  starts_on, ends_on (YYYY-MM-DD), percent_done, effort, tags (separated by ; or ,),
+  parent_id, container, parked, strategic_value, notes, description,
+  custom:<field> (custom text field), dropdown:<field> (custom dropdown field)
+
+Lanes that do not exist are created. Legends are matched by label.`
+
+// runImport creates bars from a CSV file, showing a dry run first.
+func (c *CLI) runImport(ctx context.Context, args []string) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(c.errOut)
+	roadmapID := fs.String("roadmap", "", "roadmap to import into (required)")
+	apply := fs.Bool("apply", false, "create the bars after printing the dry run")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(c.errOut, importUsage)
+		fs.PrintDefaults()
+	}
+	if len(args) == 0 || args[0] != "bars" {
+		fs.Usage()
+		return 1
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return 1
+	}
+	if *roadmapID == "" || fs.NArg() != 1 {
+		fs.Usage()
+		return 1
+	}
+
+	rows, err := readBarsFile(fs.Arg(0))
+	if err != nil {
+		_, _ = fmt.Fprintf(c.errOut, "Error: %v\n", err)
+		return 1
+	}
+	plan, err := importer.PlanBars(ctx, c.client, *roadmapID, rows)
+	if err != nil {
+		_, _ = fmt.Fprintf(c.errOut, "Error: %v\n", err)
+		return 1
+	}
+
+	c.printBarPlan(plan)
+	if invalid := plan.ErrorCount(); invalid > 0 {
+		_, _ = fmt.Fprintf(c.errOut, "Error: %d of %d rows are invalid; fix them and re-run\n", invalid, len(plan.Rows))
+		return 1
+	}
+	if !*apply {
+		_, _ = fmt.Fprintln(c.output, "\nDry run: re-run with --apply to create these bars.")
+		return 0
+	}
+
+	results := plan.Apply(ctx, c.client)
+	failed := c.printBarResults(results)
+	if failed > 0 {
+		_, _ = fmt.Fprintf(c.errOut, "Error: %d of %d bars failed to import\n", failed, len(results))
+		return 1
+	}
+	return 0
+}
+
+// readBarsFile parses the CSV at path.
+func readBarsFile(path string) ([]importer.BarRow, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return importer.ReadBars(f)
+}
+
+// printBarPlan prints the dry-run table.
+func (c *CLI) printBarPlan(plan *importer.BarPlan) {
+	newLanes := make(map[string]bool, len(plan.NewLanes))
+	for _, name := range plan.NewLanes {
+		newLanes[strings.ToLower(name)] = true
+	}
+
+	tw := tabwriter.NewWriter(c.output, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "LINE\tNAME\tLANE\tLEGEND\tSTARTS\tENDS\tSTATUS")
+	for _, r := range plan.Rows {
+		lane := r.Lane
+		if lane == "" && r.Args.LaneID != "" {
+			lane = "#" + r.Args.LaneID
+		}
+		legend := r.Legend
+		if legend == "" && r.Args.LegendID != "" {
+			legend = "#" + r.Args.LegendID
+		}
+		status := "ok"
+		switch {
+		case r.Err != nil:
+			status = "error: " + r.Err.Error()
+		case r.Args.LaneID == "" && newLanes[strings.ToLower(r.Lane)]:
+			status = "ok (new lane)"
+		}
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.Line, cell(r.Args.Name), cell(lane), cell(legend), cell(r.Args.StartsOn), cell(r.Args.EndsOn), status)
+	}
+	_ = tw.Flush()
+
+	if len(plan.NewLanes) > 0 {
+		_, _ = fmt.Fprintf(c.output, "\nLanes to create: %s\n", strings.Join(plan.NewLanes, ", "))
+	}
+}
+
+// printBarResults prints the per-row import report and returns the number
+// of failed rows.
+func (c *CLI) printBarResults(results []importer.BarResult) int {
+	_, _ = fmt.Fprintln(c.output)
+	tw := tabwriter.NewWriter(c.output, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "LINE\tNAME\tRESULT")
+	failed := 0
+	for _, r := range results {
+		outcome := "created " + r.BarID.String()
+		if r.Err != nil {
+			outcome = "error: " + r.Err.Error()
+			failed++
+		}
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\n", r.Line, cell(r.Name), strings.TrimSpace(outcome))
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintf(c.output, "\nImported %d of %d bars\n", len(results)-failed, len(results))
+	return failed
+}
+
+// cell renders a table value, showing "-" for empty values.
+func cell(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
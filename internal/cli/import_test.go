@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeCSV(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "bars.csv")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write CSV: %v", err)
+	}
+	return path
+}
+
+var importRoutes = map[string]string{
+	"/roadmaps/1":       `{"id": 1, "name": "Core", "legends": [{"id": 9, "label": "Growth"}]}`,
+	"/roadmaps/1/lanes": `[{"id": 3, "name": "Backend"}]`,
+	"/bars":             `{"id": 77}`,
+}
+
+func TestCLI_Run_ImportDryRun(t *testing.T) {
+	cli, output, _ := setupRoutedCLI(t, importRoutes)
+	path := writeCSV(t, "name,lane,legend,starts_on\nSearch,Backend,Growth,2026-01-01\nBilling,Payments,,\n")
+
+	code := cli.Run([]string{"import", "bars", "--roadmap", "1", path})
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	out := output.String()
+	for _, want := range []string{"LINE", "Search", "Growth", "2026-01-01", "ok (new lane)", "Lanes to create: Payments", "--apply"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "created") {
+		t.Error("dry run should not create bars")
+	}
+}
+
+func TestCLI_Run_ImportApply(t *testing.T) {
+	cli, output, _ := setupRoutedCLI(t, importRoutes)
+	path := writeCSV(t, "name,lane\nSearch,Backend\n")
+
+	code := cli.Run([]string{"import", "bars", "--roadmap", "1", "--apply", path})
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if !strings.Contains(output.String(), "created 77") || !strings.Contains(output.String(), "Imported 1 of 1 bars") {
+		t.Errorf("unexpected output:\n%s", output.String())
+	}
+}
+
+func TestCLI_Run_ImportInvalidRows(t *testing.T) {
+	cli, output, errOut := setupRoutedCLI(t, importRoutes)
+	path := writeCSV(t, "name,lane,ends_on\nSearch,Backend,2026-02-30\n")
+
+	if code := cli.Run([]string{"import", "bars", "--roadmap", "1", "--apply", path}); code != 1 {
+		t.Errorf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(output.String(), "'ends_on': is not a valid calendar date") {
+		t.Errorf("expected row error in table:\n%s", output.String())
+	}
+	if !strings.Contains(errOut.String(), "1 of 1 rows are invalid") {
+		t.Errorf("unexpected stderr %q", errOut.String())
+	}
+}
+
+func TestCLI_Run_ImportUsage(t *testing.T) {
+	tests := [][]string{
+		{"import"},
+		{"import", "ideas"},
+		{"import", "bars", "file.csv"},
+		{"import", "bars", "--roadmap", "1"},
+	}
+	for _, args := range tests {
+		cli, _, errOut := setupRoutedCLI(t, importRoutes)
+		if code := cli.Run(args); code != 1 {
+			t.Errorf("%v: expected exit code 1, got %d", args, code)
+		}
+		if !strings.Contains(errOut.String(), "Usage: productplan import bars") {
+			t.Errorf("%v: expected usage, got %q", args, errOut.String())
+		}
+	}
+}
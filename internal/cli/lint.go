@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/lint"
+)
+
+// lintUsage documents the lint command.
+const lintUsage = `Usage: productplan lint [--rules a,b] [--skip a,b] [--format text|json] [--fail-on error|warning|info|none] <roadmap_id>
+       productplan lint --list-rules`
+
+// runLint checks a roadmap for hygiene problems. It exits 1 when a finding
+// is at least as severe as --fail-on, so it can gate scripts.
+func (c *CLI) runLint(ctx context.Context, args []string) int {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	fs.SetOutput(c.errOut)
+	rules := fs.String("rules", "", "comma-separated rules to run (default: all)")
+	skip := fs.String("skip", "", "comma-separated rules to leave out")
+	format := fs.String("format", "text", "output format (text or json)")
+	failOn := fs.String("fail-on", "error", "exit 1 when a finding is at least this severe (error, warning, info or none)")
+	listRules := fs.Bool("list-rules", false, "print the available rules and exit")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(c.errOut, lintUsage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	linter := lint.Default()
+	if *listRules {
+		for _, r := range linter.Rules() {
+			_, _ = fmt.Fprintf(c.output, "%-20s %s\n", r.Name(), r.Description())
+		}
+		return 0
+	}
+	if fs.NArg() != 1 || (*format != "text" && *format != "json") {
+		fs.Usage()
+		return 1
+	}
+	linter, err := linter.Select(commaList(*rules), commaList(*skip))
+	if err != nil {
+		_, _ = fmt.Fprintf(c.errOut, "Error: %v\n", err)
+		return 1
+	}
+	threshold := lint.Severity("")
+	if *failOn != "none" {
+		if threshold, err = lint.ParseSeverity(*failOn); err != nil {
+			_, _ = fmt.Fprintf(c.errOut, "Error: --fail-on: %v\n", err)
+			return 1
+		}
+	}
+
+	snap, err := lint.Load(ctx, c.client, fs.Arg(0), time.Time{})
+	if err != nil {
+		_, _ = fmt.Fprintf(c.errOut, "Error: %v\n", err)
+		return 1
+	}
+	report := linter.Lint(snap)
+
+	if *format == "json" {
+		var data []byte
+		if data, err = json.Marshal(report); err != nil {
+			_, _ = fmt.Fprintf(c.errOut, "Error: %v\n", err)
+			return 1
+		}
+		c.printJSON(data)
+	} else {
+		c.printLintReport(report)
+	}
+
+	if threshold != "" && report.Count(threshold) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// printLintReport writes findings as an aligned table with a fix under each.
+func (c *CLI) printLintReport(r *lint.Report) {
+	if len(r.Findings) == 0 {
+		_, _ = fmt.Fprintf(c.output, "%s: no findings\n", r.Roadmap)
+		return
+	}
+	tw := tabwriter.NewWriter(c.output, 0, 0, 2, ' ', 0)
+	for _, f := range r.Findings {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s %s\t%s\n", f.Severity, f.Rule, f.Target.Type, f.Target.ID, f.Message)
+		_, _ = fmt.Fprintf(tw, "\t\t\t  fix: %s\n", f.Fix)
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintf(c.output, "\n%s: errors %d, warnings %d, info %d\n", r.Roadmap, r.Errors, r.Warnings, r.Infos)
+}
+
+// commaList splits a comma-separated flag value, dropping blank entries.
+func commaList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
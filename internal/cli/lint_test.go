@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func lintRoutes() map[string]string {
+	return map[string]string{
+		"/roadmaps/1":            `{"id": 1, "name": "Core"}`,
+		"/roadmaps/1/bars":       `[{"id": 2, "name": "Search", "lane_id": 3, "legend_id": 4, "description": "x"}]`,
+		"/roadmaps/1/lanes":      `[{"id": 3, "name": "Web"}, {"id": 5, "name": "Old"}]`,
+		"/roadmaps/1/milestones": `[]`,
+		"/bars/2/links":          `[{"id": 6, "url": "wiki/search"}]`,
+	}
+}
+
+func TestCLI_Run_Lint(t *testing.T) {
+	cli, output, _ := setupRoutedCLI(t, lintRoutes())
+
+	code := cli.Run([]string{"lint", "1"})
+	if code != 1 {
+		t.Errorf("expected exit code 1 for an error finding, got %d", code)
+	}
+	out := output.String()
+	for _, want := range []string{"error", "invalid_link", "link 6", "fix: Delete the link", "empty_lane", "Core: errors 1, warnings 0, info 1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestCLI_Run_LintJSON(t *testing.T) {
+	cli, output, _ := setupRoutedCLI(t, lintRoutes())
+
+	code := cli.Run([]string{"lint", "--format", "json", "--skip", "invalid_link", "--fail-on", "info", "1"})
+	if code != 1 {
+		t.Errorf("expected exit code 1 with --fail-on info, got %d", code)
+	}
+	var report struct {
+		Rules    []string `json:"rules"`
+		Findings []struct {
+			Rule string `json:"rule"`
+		} `json:"findings"`
+	}
+	if err := json.Unmarshal(output.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, output.String())
+	}
+	if len(report.Findings) != 1 || report.Findings[0].Rule != "empty_lane" {
+		t.Errorf("unexpected findings %+v", report.Findings)
+	}
+
+	cli, _, _ = setupRoutedCLI(t, lintRoutes())
+	if code := cli.Run([]string{"lint", "--rules", "empty_lane", "--fail-on", "none", "1"}); code != 0 {
+		t.Errorf("expected exit code 0 with --fail-on none, got %d", code)
+	}
+}
+
+func TestCLI_Run_LintErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no roadmap", []string{"lint"}, "Usage: productplan lint"},
+		{"bad format", []string{"lint", "--format", "xml", "1"}, "Usage: productplan lint"},
+		{"unknown rule", []string{"lint", "--rules", "typo", "1"}, "unknown lint rule"},
+		{"bad severity", []string{"lint", "--fail-on", "fatal", "1"}, "--fail-on"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli, _, errOut := setupRoutedCLI(t, lintRoutes())
+			if code := cli.Run(tt.args); code != 1 {
+				t.Errorf("expected exit code 1, got %d", code)
+			}
+			if !strings.Contains(errOut.String(), tt.want) {
+				t.Errorf("stderr missing %q: %s", tt.want, errOut.String())
+			}
+		})
+	}
+}
+
+func TestCLI_Run_LintListRules(t *testing.T) {
+	cli, output, _ := setupRoutedCLI(t, nil)
+	if code := cli.Run([]string{"lint", "--list-rules"}); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if !strings.Contains(output.String(), "overdue_bar") || !strings.Contains(output.String(), "invalid_link") {
+		t.Errorf("expected rule list, got:\n%s", output.String())
+	}
+}
@@ -0,0 +1,387 @@
+// Package config loads the optional config file of named profiles, so one
+// installation can switch between ProductPlan accounts such as a sandbox
+// and production.
+//
+// A profile overrides api.DefaultConfig field by field; anything it leaves
+// out keeps the default, and without a config file the server runs on
+// environment variables alone.
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+	"github.com/olgasafonova/productplan-mcp-server/internal/logging"
+)
+
+const (
+	// EnvFile overrides the config file location.
+	EnvFile = "PRODUCTPLAN_CONFIG"
+	// EnvProfile selects a profile when --profile is not given.
+	EnvProfile = "PRODUCTPLAN_PROFILE"
+	// EnvToken is the variable the token is read from unless a profile
+	// names another.
+	EnvToken = "PRODUCTPLAN_API_TOKEN"
+	// FileSuffix turns a token variable into one naming a file that holds
+	// the token, e.g. PRODUCTPLAN_API_TOKEN_FILE.
+	FileSuffix = "_FILE"
+	// DefaultProfile is used when neither the command line, the
+	// environment nor the file's default_profile selects one.
+	DefaultProfile = "default"
+)
+
+// File is the parsed config file.
+type File struct {
+	// DefaultProfile names the profile used when none is selected.
+	DefaultProfile string             `yaml:"default_profile"`
+	Profiles       map[string]Profile `yaml:"profiles"`
+	// Redact adds log redaction rules to the defaults.
+	Redact Redact `yaml:"redact"`
+
+	path string
+}
+
+// Profile is one account's settings.
+type Profile struct {
+	// Name is the profile's key in the file; empty when running without
+	// a profile.
+	Name string `yaml:"-"`
+	// BaseURL is the API base URL, e.g. a sandbox host.
+	BaseURL string `yaml:"base_url"`
+	// TokenEnv names the environment variable holding the API token, or
+	// with FileSuffix appended, a file holding it. Empty means
+	// PRODUCTPLAN_API_TOKEN.
+	TokenEnv string `yaml:"token_env"`
+	// TokenFile is a file holding the API token.
+	TokenFile string `yaml:"token_file"`
+	// TokenCommand is a shell command that prints the API token, e.g.
+	// "pass show productplan" or "op read op://vault/productplan/token".
+	TokenCommand string `yaml:"token_command"`
+	// Timeout bounds each HTTP request, e.g. "45s".
+	Timeout time.Duration `yaml:"timeout"`
+	// RateLimit tunes the adaptive rate limiter.
+	RateLimit RateLimit `yaml:"rate_limit"`
+	// DefaultRoadmap fills in roadmap_id for tools that require one when
+	// the caller leaves it out.
+	DefaultRoadmap string `yaml:"default_roadmap"`
+	// Calendar sets the fiscal year and week start.
+	Calendar Calendar `yaml:"calendar"`
+	// OKR sets how objective_coverage links bars to objectives.
+	OKR OKRLinks `yaml:"okr"`
+	// Tools limits which MCP tools the server exposes.
+	Tools ToolFilter `yaml:"tools"`
+	// Workspaces names other profiles the MCP server also connects to.
+	// Tool calls reach them through the workspace argument, keyed by
+	// profile name; their own tools and workspaces settings are ignored.
+	Workspaces []string `yaml:"workspaces"`
+}
+
+// RateLimit overrides the rate limiter defaults. Zero fields keep them.
+type RateLimit struct {
+	// Requests is the assumed limit per window when the API sends no
+	// rate limit headers.
+	Requests int `yaml:"requests"`
+	// SlowdownThreshold is the fraction of remaining requests below which
+	// calls are spaced out, e.g. 0.2.
+	SlowdownThreshold float64       `yaml:"slowdown_threshold"`
+	MinDelay          time.Duration `yaml:"min_delay"`
+	MaxDelay          time.Duration `yaml:"max_delay"`
+}
+
+// Calendar overrides the calendar defaults. Empty fields keep them;
+// $PRODUCTPLAN_FISCAL_YEAR_START and $PRODUCTPLAN_WEEK_START override both.
+type Calendar struct {
+	// FiscalYearStart is the month the fiscal year starts in, as a number
+	// or a name.
+	FiscalYearStart string `yaml:"fiscal_year_start"`
+	// WeekStart is the first day of the week, e.g. "Sunday".
+	WeekStart string `yaml:"week_start"`
+}
+
+// OKRLinks overrides the bar-to-objective link conventions. A nil field
+// keeps the default and an empty string turns that convention off;
+// $PRODUCTPLAN_OKR_TAG_PREFIX and $PRODUCTPLAN_OKR_FIELD override both.
+type OKRLinks struct {
+	// TagPrefix marks bar tags naming an objective, e.g. "okr:".
+	TagPrefix *string `yaml:"tag_prefix"`
+	// Field is the custom field naming a bar's objective.
+	Field *string `yaml:"field"`
+}
+
+// Redact lists extra field names and regular expressions whose values
+// are masked in log output, on top of logging.DefaultRedactFields and
+// logging.DefaultRedactPatterns.
+type Redact struct {
+	Fields   []string `yaml:"fields"`
+	Patterns []string `yaml:"patterns"`
+}
+
+// ToolFilter selects the exposed tools by name or path.Match pattern
+// ("get_*"). Exclude wins over Include; empty Include means all tools.
+type ToolFilter struct {
+	Include []string `yaml:"include"`
+	Exclude []string `yaml:"exclude"`
+	// ReadOnly exposes only tools annotated read-only.
+	ReadOnly bool `yaml:"read_only"`
+}
+
+// DefaultPath returns the config file path: $PRODUCTPLAN_CONFIG when set,
+// otherwise config.yaml in the user config directory.
+func DefaultPath() (string, error) {
+	if p := os.Getenv(EnvFile); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "productplan-mcp", "config.yaml"), nil
+}
+
+// Load reads and validates the config file at path. A missing file is an
+// empty config, not an error.
+func Load(path string) (*File, error) {
+	f := &File{path: path}
+	data, err := os.ReadFile(path) // #nosec G304 -- the path is the user's own config file
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err = dec.Decode(f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for name, p := range f.Profiles {
+		if err = p.validate(); err != nil {
+			return nil, fmt.Errorf("%s: profile %q: %w", path, name, err)
+		}
+		for _, ws := range p.Workspaces {
+			if _, ok := f.Profiles[ws]; !ok || ws == name {
+				return nil, fmt.Errorf("%s: profile %q: workspace %q must name another profile", path, name, ws)
+			}
+		}
+	}
+	if _, err = f.Redactor(); err != nil {
+		return nil, fmt.Errorf("%s: redact: %w", path, err)
+	}
+	if f.DefaultProfile != "" {
+		if _, ok := f.Profiles[f.DefaultProfile]; !ok {
+			return nil, fmt.Errorf("%s: default_profile %q is not defined", path, f.DefaultProfile)
+		}
+	}
+	return f, nil
+}
+
+// Path returns the file the config was loaded from.
+func (f *File) Path() string {
+	return f.path
+}
+
+// Redactor returns the default log redaction rules extended with the
+// file's.
+func (f *File) Redactor() (*logging.Redactor, error) {
+	return logging.NewRedactor(
+		append(slices.Clone(logging.DefaultRedactFields), f.Redact.Fields...),
+		append(slices.Clone(logging.DefaultRedactPatterns), f.Redact.Patterns...),
+	)
+}
+
+// Names returns the profile names, sorted.
+func (f *File) Names() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profile returns the named profile. An empty name falls back to
+// $PRODUCTPLAN_PROFILE, the file's default_profile, then a profile called
+// "default"; when none of those exist it returns an empty profile, which
+// keeps every default.
+func (f *File) Profile(name string) (Profile, error) {
+	explicit := name != ""
+	if !explicit {
+		name = os.Getenv(EnvProfile)
+		explicit = name != ""
+	}
+	if name == "" {
+		name = f.DefaultProfile
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+	p, ok := f.Profiles[name]
+	switch {
+	case ok:
+		p.Name = name
+		return p, nil
+	case !explicit && f.DefaultProfile == "":
+		return Profile{}, nil
+	case len(f.Profiles) == 0:
+		return Profile{}, fmt.Errorf("profile %q not found: no profiles in %s", name, f.path)
+	}
+	return Profile{}, fmt.Errorf("profile %q not found in %s (profiles: %s)", name, f.path, strings.Join(f.Names(), ", "))
+}
+
+// Workspaces returns the profiles p names as workspaces, in order.
+func (f *File) Workspaces(p Profile) ([]Profile, error) {
+	workspaces := make([]Profile, 0, len(p.Workspaces))
+	for _, name := range p.Workspaces {
+		ws, err := f.Profile(name)
+		if err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, ws)
+	}
+	return workspaces, nil
+}
+
+func (p Profile) validate() error {
+	if p.BaseURL != "" {
+		u, err := url.Parse(p.BaseURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("base_url %q must be an http(s) URL", p.BaseURL)
+		}
+	}
+	sources := 0
+	for _, s := range []string{p.TokenEnv, p.TokenFile, p.TokenCommand} {
+		if s != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return fmt.Errorf("set only one of token_env, token_file and token_command")
+	}
+	if p.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative")
+	}
+	r := p.RateLimit
+	if r.Requests < 0 || r.MinDelay < 0 || r.MaxDelay < 0 {
+		return fmt.Errorf("rate_limit values must not be negative")
+	}
+	if r.SlowdownThreshold < 0 || r.SlowdownThreshold > 1 {
+		return fmt.Errorf("rate_limit.slowdown_threshold must be between 0 and 1")
+	}
+	if _, err := p.Calendar.parse(); err != nil {
+		return err
+	}
+	for _, pattern := range append(append([]string{}, p.Tools.Include...), p.Tools.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("tools pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// TokenVar returns the environment variable the profile reads its token
+// from.
+func (p Profile) TokenVar() string {
+	if p.TokenEnv != "" {
+		return p.TokenEnv
+	}
+	return EnvToken
+}
+
+// Token returns the API token from the profile's token_command or
+// token_file, else from its token variable or the file that variable with
+// FileSuffix names. It is called again to pick up a rotated token; ctx
+// bounds a token_command.
+func (p Profile) Token(ctx context.Context) (string, error) {
+	switch {
+	case p.TokenCommand != "":
+		return commandToken(ctx, p.TokenCommand)
+	case p.TokenFile != "":
+		return fileToken(p.TokenFile)
+	}
+	if token := os.Getenv(p.TokenVar()); token != "" {
+		return token, nil
+	}
+	if file := os.Getenv(p.TokenVar() + FileSuffix); file != "" {
+		return fileToken(file)
+	}
+	return "", fmt.Errorf("%s or %s environment variable is required", p.TokenVar(), p.TokenVar()+FileSuffix)
+}
+
+// Apply overrides cfg with the profile's settings.
+func (p Profile) Apply(cfg api.Config) api.Config {
+	if p.BaseURL != "" {
+		cfg.BaseURL = p.BaseURL
+	}
+	if p.Timeout > 0 {
+		cfg.Timeout = p.Timeout
+	}
+	r := p.RateLimit
+	if r.Requests > 0 {
+		cfg.RateLimit.DefaultLimit = r.Requests
+	}
+	if r.SlowdownThreshold > 0 {
+		cfg.RateLimit.SlowdownThreshold = r.SlowdownThreshold
+	}
+	if r.MinDelay > 0 {
+		cfg.RateLimit.MinDelay = r.MinDelay
+	}
+	if r.MaxDelay > 0 {
+		cfg.RateLimit.MaxDelay = r.MaxDelay
+	}
+	return cfg
+}
+
+// FiscalCalendar returns the profile's calendar, with
+// $PRODUCTPLAN_FISCAL_YEAR_START and $PRODUCTPLAN_WEEK_START overriding
+// it.
+func (p Profile) FiscalCalendar() (dates.Calendar, error) {
+	c, err := p.Calendar.parse()
+	if err != nil {
+		return dates.Calendar{}, err
+	}
+	env, err := dates.CalendarFromEnv()
+	if err != nil {
+		return dates.Calendar{}, err
+	}
+	if env.FiscalYearStart != 0 {
+		c.FiscalYearStart = env.FiscalYearStart
+	}
+	if env.WeekStart != nil {
+		c.WeekStart = env.WeekStart
+	}
+	return c, nil
+}
+
+func (c Calendar) parse() (dates.Calendar, error) {
+	var out dates.Calendar
+	if c.FiscalYearStart != "" {
+		m, err := dates.ParseMonth(c.FiscalYearStart)
+		if err != nil {
+			return dates.Calendar{}, fmt.Errorf("calendar.fiscal_year_start: %w", err)
+		}
+		out.FiscalYearStart = m
+	}
+	if c.WeekStart != "" {
+		d, err := dates.ParseWeekday(c.WeekStart)
+		if err != nil {
+			return dates.Calendar{}, fmt.Errorf("calendar.week_start: %w", err)
+		}
+		out = out.WithWeekStart(d)
+	}
+	return out, nil
+}
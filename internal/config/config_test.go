@@ -0,0 +1,217 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+	"github.com/olgasafonova/productplan-mcp-server/internal/logging"
+)
+
+const sample = `default_profile: prod
+redact:
+  fields: [customer_note]
+  patterns: ['acct-\d+']
+profiles:
+  prod:
+    default_roadmap: "42"
+    workspaces: [sandbox]
+    okr:
+      tag_prefix: "goal:"
+      field: ""
+  sandbox:
+    base_url: https://sandbox.example.com/api/v2
+    token_env: PP_SANDBOX_TOKEN
+    timeout: 45s
+    calendar:
+      fiscal_year_start: February
+      week_start: sunday
+    rate_limit:
+      requests: 50
+      max_delay: 10s
+    tools:
+      read_only: true
+      exclude: [health_check]
+`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadAndSelectProfile(t *testing.T) {
+	t.Setenv(EnvProfile, "")
+	f, err := Load(writeConfig(t, sample))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(f.Names(), ","); got != "prod,sandbox" {
+		t.Errorf("Names = %s", got)
+	}
+	r, err := f.Redactor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.RedactField("customer_note", "x") != logging.Redacted || r.RedactString("acct-42 ana@example.com") != logging.Redacted+" "+logging.Redacted {
+		t.Error("expected the file's rules on top of the defaults")
+	}
+
+	p, err := f.Profile("")
+	if err != nil || p.Name != "prod" || p.DefaultRoadmap != "42" {
+		t.Errorf("default profile = %+v, %v", p, err)
+	}
+	if o := p.OKR; o.TagPrefix == nil || *o.TagPrefix != "goal:" || o.Field == nil || *o.Field != "" {
+		t.Errorf("unexpected okr links %+v", o)
+	}
+
+	t.Setenv(EnvProfile, "sandbox")
+	if p, err = f.Profile(""); err != nil || p.Name != "sandbox" {
+		t.Fatalf("env profile = %+v, %v", p, err)
+	}
+	if p.TokenVar() != "PP_SANDBOX_TOKEN" || !p.Tools.ReadOnly || p.Tools.Exclude[0] != "health_check" || p.Calendar.FiscalYearStart != "February" {
+		t.Errorf("unexpected sandbox profile %+v", p)
+	}
+
+	cfg := p.Apply(api.DefaultConfig("t"))
+	if cfg.BaseURL != "https://sandbox.example.com/api/v2" || cfg.Timeout != 45*time.Second ||
+		cfg.RateLimit.DefaultLimit != 50 || cfg.RateLimit.MaxDelay != 10*time.Second || cfg.RateLimit.MinDelay != 100*time.Millisecond {
+		t.Errorf("unexpected api config %+v", cfg)
+	}
+
+	ws, err := f.Workspaces(p)
+	if err != nil || len(ws) != 0 {
+		t.Errorf("sandbox workspaces = %+v, %v", ws, err)
+	}
+	t.Setenv(EnvProfile, "")
+	if p, err = f.Profile(""); err != nil {
+		t.Fatal(err)
+	}
+	if ws, err = f.Workspaces(p); err != nil || len(ws) != 1 || ws[0].Name != "sandbox" || ws[0].TokenVar() != "PP_SANDBOX_TOKEN" {
+		t.Errorf("prod workspaces = %+v, %v", ws, err)
+	}
+
+	if _, err = f.Profile("staging"); err == nil || !strings.Contains(err.Error(), "profiles: prod, sandbox") {
+		t.Errorf("expected unknown profile error, got %v", err)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	t.Setenv(EnvProfile, "")
+	f, err := Load(filepath.Join(t.TempDir(), "none.yaml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p, err := f.Profile("")
+	if err != nil || p.Name != "" || p.TokenVar() != EnvToken {
+		t.Errorf("expected an empty profile, got %+v, %v", p, err)
+	}
+	if cfg, def := p.Apply(api.DefaultConfig("t")), api.DefaultConfig("t"); cfg.BaseURL != def.BaseURL || cfg.Timeout != def.Timeout || cfg.RateLimit != def.RateLimit {
+		t.Error("an empty profile should keep the defaults")
+	}
+	if _, err = f.Profile("sandbox"); err == nil {
+		t.Error("expected an error for a named profile without a config file")
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	for content, msg := range map[string]string{
+		"profiles:\n  a:\n    base_url: ftp://x\n":                        "base_url",
+		"profiles:\n  a:\n    timeout: soon\n":                            "parse",
+		"profiles:\n  a:\n    tokn_env: X\n":                              "tokn_env",
+		"profiles:\n  a:\n    rate_limit:\n      requests: -1\n":          "rate_limit",
+		"profiles:\n  a:\n    tools:\n      include: ['[']\n":             "tools pattern",
+		"default_profile: b\nprofiles:\n  a:\n    timeout: 1s\n":          "default_profile",
+		"profiles:\n  a:\n    rate_limit:\n      slowdown_threshold: 2\n": "slowdown_threshold",
+		"profiles:\n  a:\n    workspaces: [b]\n":                          "workspace \"b\"",
+		"profiles:\n  a:\n    calendar:\n      fiscal_year_start: 13\n":   "calendar.fiscal_year_start",
+		"profiles:\n  a:\n    calendar:\n      week_start: someday\n":     "calendar.week_start",
+		"profiles:\n  a:\n    workspaces: [a]\n":                          "workspace \"a\"",
+		"redact:\n  patterns: ['(']\n":                                    "redact pattern",
+		"profiles:\n  a:\n    token_env: X\n    token_command: pass x\n":  "only one of",
+	} {
+		if _, err := Load(writeConfig(t, content)); err == nil || !strings.Contains(err.Error(), msg) {
+			t.Errorf("Load(%q) error = %v, want it to mention %q", content, err, msg)
+		}
+	}
+}
+
+func TestProfileToken(t *testing.T) {
+	p := Profile{TokenEnv: "PP_TEST_TOKEN"}
+	t.Setenv("PP_TEST_TOKEN", "")
+	t.Setenv("PP_TEST_TOKEN_FILE", "")
+	if _, err := p.Token(context.Background()); err == nil || !strings.Contains(err.Error(), "PP_TEST_TOKEN_FILE") {
+		t.Errorf("expected a missing token error naming the variables, got %v", err)
+	}
+
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PP_TEST_TOKEN_FILE", file)
+	if token, err := p.Token(context.Background()); err != nil || token != "from-file" {
+		t.Errorf("Token from _FILE = %q, %v", token, err)
+	}
+	t.Setenv("PP_TEST_TOKEN", "secret")
+	if token, err := p.Token(context.Background()); err != nil || token != "secret" {
+		t.Errorf("Token = %q, %v", token, err)
+	}
+	if token, err := (Profile{TokenFile: file}).Token(context.Background()); err != nil || token != "from-file" {
+		t.Errorf("Token from token_file = %q, %v", token, err)
+	}
+}
+
+func TestProfileTokenCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+	for command, want := range map[string]string{
+		"echo '  cmd-token  '":     "cmd-token",
+		"echo denied >&2; exit 3":  "token_command failed: exit status 3: denied",
+		"true":                     "token_command printed nothing",
+		`printf 'a\nlogin: ana\n'`: "a",
+	} {
+		token, err := (Profile{TokenCommand: command}).Token(context.Background())
+		got := token
+		if err != nil {
+			got = err.Error()
+		}
+		if got != want {
+			t.Errorf("token_command %q = %q, want %q", command, got, want)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := (Profile{TokenCommand: "sleep 5"}).Token(ctx); err == nil {
+		t.Error("expected a cancelled token_command to fail")
+	}
+}
+
+func TestProfileFiscalCalendar(t *testing.T) {
+	t.Setenv(dates.EnvFiscalYearStart, "")
+	t.Setenv(dates.EnvWeekStart, "")
+	p := Profile{Calendar: Calendar{FiscalYearStart: "February", WeekStart: "Sunday"}}
+	c, err := p.FiscalCalendar()
+	if err != nil || c.FiscalYearStart != time.February || c.FirstWeekday() != time.Sunday {
+		t.Errorf("profile calendar = %v, %v", c, err)
+	}
+
+	t.Setenv(dates.EnvFiscalYearStart, "7")
+	if c, err = p.FiscalCalendar(); err != nil || c.FiscalYearStart != time.July || c.FirstWeekday() != time.Sunday {
+		t.Errorf("expected the variable to override the fiscal year only, got %v, %v", c, err)
+	}
+	t.Setenv(dates.EnvWeekStart, "never")
+	if _, err = p.FiscalCalendar(); err == nil || !strings.Contains(err.Error(), dates.EnvWeekStart) {
+		t.Errorf("expected an error naming %s, got %v", dates.EnvWeekStart, err)
+	}
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// tokenCommandTimeout bounds a token_command, which may wait on a secret
+// store or an unlock prompt.
+const tokenCommandTimeout = 30 * time.Second
+
+// fileToken reads a token from path, ignoring surrounding whitespace.
+func fileToken(path string) (string, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- the path is configured by the user
+	if err != nil {
+		return "", fmt.Errorf("read token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", path)
+	}
+	return token, nil
+}
+
+// commandToken runs command through the shell and returns the first line
+// of its output, trimmed; secret stores such as pass print metadata on the
+// lines after the secret. The output is never included in errors.
+func commandToken(ctx context.Context, command string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, tokenCommandTimeout)
+	defer cancel()
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	cmd := exec.CommandContext(ctx, shell, flag, command) // #nosec G204 -- the command comes from the user's own config file
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("token_command failed: %w: %s", err, firstLine(msg))
+		}
+		return "", fmt.Errorf("token_command failed: %w", err)
+	}
+	token := strings.TrimSpace(firstLine(strings.TrimSpace(stdout.String())))
+	if token == "" {
+		return "", fmt.Errorf("token_command printed nothing")
+	}
+	return token, nil
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
@@ -0,0 +1,222 @@
+// Package dates places days on the configured fiscal calendar and resolves
+// the date expressions tools accept, such as "+2w", "next monday", "end of
+// Q3 2026" or "milestone:Beta+7d", against it.
+package dates
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables configuring the calendar.
+const (
+	// EnvFiscalYearStart holds the month the fiscal year starts in, as a
+	// number (2) or a name ("February").
+	EnvFiscalYearStart = "PRODUCTPLAN_FISCAL_YEAR_START"
+	// EnvWeekStart holds the first day of the week, as a name ("Sunday").
+	EnvWeekStart = "PRODUCTPLAN_WEEK_START"
+)
+
+// Period is a closed date range [Start, End], both at midnight UTC.
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Elapsed returns the fraction of the period that has passed on day, clamped
+// to [0, 1].
+func (p Period) Elapsed(day time.Time) float64 {
+	total := p.End.Sub(p.Start).Hours()/24 + 1
+	done := day.Sub(p.Start).Hours()/24 + 1
+	return min(max(done/total, 0), 1)
+}
+
+// Calendar places weeks, quarters, halves and years. A fiscal year is
+// named after the calendar year it ends in, so with a February start
+// FY2027 runs from February 2026 to January 2027. The zero Calendar is
+// the calendar year with Monday weeks.
+type Calendar struct {
+	// FiscalYearStart is the first month of the fiscal year. Zero means
+	// January, the calendar year.
+	FiscalYearStart time.Month
+	// WeekStart is the first day of the week. Nil means Monday, as in
+	// ISO 8601.
+	WeekStart *time.Weekday
+}
+
+// CalendarFromEnv reads the fiscal year start from
+// $PRODUCTPLAN_FISCAL_YEAR_START and the week start from
+// $PRODUCTPLAN_WEEK_START. Unset variables keep the defaults.
+func CalendarFromEnv() (Calendar, error) {
+	var c Calendar
+	if v := os.Getenv(EnvFiscalYearStart); v != "" {
+		m, err := ParseMonth(v)
+		if err != nil {
+			return Calendar{}, fmt.Errorf("%s: %w", EnvFiscalYearStart, err)
+		}
+		c.FiscalYearStart = m
+	}
+	if v := os.Getenv(EnvWeekStart); v != "" {
+		d, err := ParseWeekday(v)
+		if err != nil {
+			return Calendar{}, fmt.Errorf("%s: %w", EnvWeekStart, err)
+		}
+		c = c.WithWeekStart(d)
+	}
+	return c, nil
+}
+
+// WithWeekStart returns a copy of c whose weeks start on d.
+func (c Calendar) WithWeekStart(d time.Weekday) Calendar {
+	c.WeekStart = &d
+	return c
+}
+
+// FirstWeekday returns the day weeks start on.
+func (c Calendar) FirstWeekday() time.Weekday {
+	if c.WeekStart == nil {
+		return time.Monday
+	}
+	return *c.WeekStart
+}
+
+// Fiscal reports whether the fiscal year differs from the calendar year.
+func (c Calendar) Fiscal() bool {
+	return c.FirstMonth() != time.January
+}
+
+// String describes the calendar, e.g. "fiscal year starting February,
+// weeks starting Sunday".
+func (c Calendar) String() string {
+	year := "calendar year"
+	if c.Fiscal() {
+		year = "fiscal year starting " + c.FirstMonth().String()
+	}
+	return year + ", weeks starting " + c.FirstWeekday().String()
+}
+
+// ParseWeekday parses a day name or its abbreviation.
+func ParseWeekday(s string) (time.Weekday, error) {
+	if d, ok := weekdays[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return d, nil
+	}
+	return 0, fmt.Errorf("unknown weekday %q", s)
+}
+
+// ParseMonth parses a month number (1-12), name or three-letter
+// abbreviation.
+func ParseMonth(s string) (time.Month, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 1 || n > 12 {
+			return 0, fmt.Errorf("month %d must be between 1 and 12", n)
+		}
+		return time.Month(n), nil
+	}
+	if m, ok := monthNames[s]; ok {
+		return m, nil
+	}
+	return 0, fmt.Errorf("unknown month %q", s)
+}
+
+var monthNames = func() map[string]time.Month {
+	names := make(map[string]time.Month, 24)
+	for m := time.January; m <= time.December; m++ {
+		full := strings.ToLower(m.String())
+		names[full] = m
+		names[full[:3]] = m
+	}
+	names["sept"] = time.September
+	return names
+}()
+
+// FirstMonth returns the month fiscal years start in.
+func (c Calendar) FirstMonth() time.Month {
+	if c.FiscalYearStart < time.January || c.FiscalYearStart > time.December {
+		return time.January
+	}
+	return c.FiscalYearStart
+}
+
+// FiscalYear returns the fiscal year day falls in.
+func (c Calendar) FiscalYear(day time.Time) int {
+	if start := c.FirstMonth(); start != time.January && day.Month() >= start {
+		return day.Year() + 1
+	}
+	return day.Year()
+}
+
+// Year returns fiscal year fy.
+func (c Calendar) Year(fy int) Period {
+	return c.months(fy, 0, 12)
+}
+
+// Half returns half h (1 or 2) of fiscal year fy.
+func (c Calendar) Half(fy, h int) Period {
+	return c.months(fy, (h-1)*6, 6)
+}
+
+// Quarter returns quarter q (1-4) of fiscal year fy.
+func (c Calendar) Quarter(fy, q int) Period {
+	return c.months(fy, (q-1)*3, 3)
+}
+
+// QuarterOf returns the fiscal year and quarter day falls in.
+func (c Calendar) QuarterOf(day time.Time) (fy, q int) {
+	fy = c.FiscalYear(day)
+	offset := (int(day.Month()) - int(c.FirstMonth()) + 12) % 12
+	return fy, offset/3 + 1
+}
+
+// months returns n months of fiscal year fy starting offset months in.
+func (c Calendar) months(fy, offset, n int) Period {
+	year := fy
+	if c.FirstMonth() != time.January {
+		year--
+	}
+	start := time.Date(year, c.FirstMonth()+time.Month(offset), 1, 0, 0, 0, 0, time.UTC)
+	return Period{Start: start, End: start.AddDate(0, n, -1)}
+}
+
+// YearLabel names fiscal year fy: "FY2027", or "2026" for the calendar
+// year.
+func (c Calendar) YearLabel(fy int) string {
+	if c.Fiscal() {
+		return fmt.Sprintf("FY%d", fy)
+	}
+	return strconv.Itoa(fy)
+}
+
+// HalfLabel names half h of fiscal year fy, e.g. "FY2027 H1" or
+// "2026 H2".
+func (c Calendar) HalfLabel(fy, h int) string {
+	return fmt.Sprintf("%s H%d", c.YearLabel(fy), h)
+}
+
+// QuarterLabel names quarter q of fiscal year fy, e.g. "FY2027 Q1" or
+// "2026 Q3". Labels of one calendar sort chronologically as text.
+func (c Calendar) QuarterLabel(fy, q int) string {
+	return fmt.Sprintf("%s Q%d", c.YearLabel(fy), q)
+}
+
+// Month returns the calendar month.
+func Month(year int, m time.Month) Period {
+	start := time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+	return Period{Start: start, End: start.AddDate(0, 1, -1)}
+}
+
+// Week returns the seven-day week day falls in.
+func (c Calendar) Week(day time.Time) Period {
+	start := day.AddDate(0, 0, -((int(day.Weekday()) - int(c.FirstWeekday()) + 7) % 7))
+	return Period{Start: start, End: start.AddDate(0, 0, 6)}
+}
+
+// WeekLabel names the week day falls in after the ISO week holding most
+// of its days, e.g. "2026-W24". For Monday weeks this is the ISO week.
+func (c Calendar) WeekLabel(day time.Time) string {
+	year, week := c.Week(day).Start.AddDate(0, 0, 3).ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
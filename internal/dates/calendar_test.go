@@ -0,0 +1,109 @@
+package dates
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendar(t *testing.T) {
+	c := Calendar{FiscalYearStart: time.October}
+	day := time.Date(2026, 11, 5, 0, 0, 0, 0, time.UTC)
+	if fy, q := c.QuarterOf(day); fy != 2027 || q != 1 {
+		t.Errorf("QuarterOf = FY%d Q%d, want FY2027 Q1", fy, q)
+	}
+	if p := c.Year(2027); p.Start.Format(time.DateOnly) != "2026-10-01" || p.End.Format(time.DateOnly) != "2027-09-30" {
+		t.Errorf("Year(2027) = %v", p)
+	}
+	if fy, q := (Calendar{}).QuarterOf(day); fy != 2026 || q != 4 {
+		t.Errorf("calendar QuarterOf = FY%d Q%d, want 2026 Q4", fy, q)
+	}
+}
+
+func TestCalendarWeeksAndLabels(t *testing.T) {
+	day := time.Date(2026, 6, 10, 0, 0, 0, 0, time.UTC) // Wednesday
+	if w := (Calendar{}).Week(day); w.Start.Format(time.DateOnly) != "2026-06-08" || w.End.Format(time.DateOnly) != "2026-06-14" {
+		t.Errorf("Monday week = %v", w)
+	}
+	sunday := Calendar{FiscalYearStart: time.February}.WithWeekStart(time.Sunday)
+	if w := sunday.Week(day); w.Start.Format(time.DateOnly) != "2026-06-07" {
+		t.Errorf("Sunday week = %v", w)
+	}
+	if got := sunday.WeekLabel(time.Date(2026, 6, 7, 0, 0, 0, 0, time.UTC)); got != "2026-W24" {
+		t.Errorf("WeekLabel = %s, want 2026-W24", got)
+	}
+	if got := sunday.QuarterLabel(sunday.QuarterOf(day)); got != "FY2027 Q2" {
+		t.Errorf("QuarterLabel = %s, want FY2027 Q2", got)
+	}
+	if got := (Calendar{}).QuarterLabel((Calendar{}).QuarterOf(day)); got != "2026 Q2" {
+		t.Errorf("calendar QuarterLabel = %s, want 2026 Q2", got)
+	}
+	if got := sunday.String(); got != "fiscal year starting February, weeks starting Sunday" {
+		t.Errorf("String = %q", got)
+	}
+}
+
+func TestParseMonth(t *testing.T) {
+	for in, want := range map[string]time.Month{"2": time.February, "Feb": time.February, " october ": time.October, "sept": time.September} {
+		if got, err := ParseMonth(in); err != nil || got != want {
+			t.Errorf("ParseMonth(%q) = %v, %v", in, got, err)
+		}
+	}
+	for _, in := range []string{"0", "13", "Smarch"} {
+		if _, err := ParseMonth(in); err == nil {
+			t.Errorf("ParseMonth(%q): expected error", in)
+		}
+	}
+}
+
+func TestCalendarFromEnv(t *testing.T) {
+	t.Setenv(EnvFiscalYearStart, "february")
+	if c, err := CalendarFromEnv(); err != nil || c.FiscalYearStart != time.February {
+		t.Errorf("CalendarFromEnv = %+v, %v", c, err)
+	}
+	t.Setenv(EnvWeekStart, "Sun")
+	if c, err := CalendarFromEnv(); err != nil || c.FirstWeekday() != time.Sunday {
+		t.Errorf("CalendarFromEnv week start = %+v, %v", c, err)
+	}
+	t.Setenv(EnvWeekStart, "someday")
+	if _, err := CalendarFromEnv(); err == nil {
+		t.Error("expected error for an unknown weekday")
+	}
+	t.Setenv(EnvWeekStart, "")
+	t.Setenv(EnvFiscalYearStart, "nope")
+	if _, err := CalendarFromEnv(); err == nil {
+		t.Error("expected error for an unknown month")
+	}
+}
+
+func TestTimeframe(t *testing.T) {
+	c := Calendar{FiscalYearStart: time.February}.WithWeekStart(time.Sunday)
+	tf := c.Timeframe(time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC))
+	if tf.FiscalYear != 2027 || tf.QuarterNumber != 3 || tf.HalfNumber != 2 || tf.Weekday != "Sunday" {
+		t.Errorf("unexpected timeframe %+v", tf)
+	}
+	if tf.Quarter != (Span{Label: "FY2027 Q3", Start: "2026-08-01", End: "2026-10-31"}) || tf.DayOfQuarter != 79 || tf.DaysInQuarter != 92 {
+		t.Errorf("unexpected quarter %+v day %d/%d", tf.Quarter, tf.DayOfQuarter, tf.DaysInQuarter)
+	}
+	if tf.Year.Label != "FY2027" || tf.Half.Start != "2026-08-01" || tf.Month.Label != "2026-10" {
+		t.Errorf("unexpected year/half/month %+v %+v %+v", tf.Year, tf.Half, tf.Month)
+	}
+	if tf.Week != (Span{Label: "2026-W43", Start: "2026-10-18", End: "2026-10-24"}) {
+		t.Errorf("unexpected week %+v", tf.Week)
+	}
+}
+
+func TestPeriodElapsed(t *testing.T) {
+	p := Calendar{}.Quarter(2026, 1) // 90 days
+	tests := map[string]float64{
+		"2025-12-01": 0,
+		"2026-01-01": 1.0 / 90,
+		"2026-03-31": 1,
+		"2026-06-01": 1,
+	}
+	for day, want := range tests {
+		d, _ := time.Parse(time.DateOnly, day)
+		if got := p.Elapsed(d); got != want {
+			t.Errorf("Elapsed(%s) = %v, want %v", day, got, want)
+		}
+	}
+}
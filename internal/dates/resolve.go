@@ -0,0 +1,309 @@
+package dates
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Syntax summarises the accepted expressions, for error messages and tool
+// descriptions.
+const Syntax = `YYYY-MM-DD, today, tomorrow, +2w, -3d, in 2 weeks, next monday, start of next month, end of Q3 2026, end of FY2027, milestone:Beta+7d, 2 weeks after milestone:Beta`
+
+// MilestoneFunc returns the date of the named milestone.
+type MilestoneFunc func(name string) (time.Time, error)
+
+// Resolver turns date expressions into dates.
+type Resolver struct {
+	Calendar Calendar
+	// Today anchors relative expressions. Zero means the current date.
+	Today time.Time
+	// Milestone resolves milestone:<name> references. Nil rejects them.
+	Milestone MilestoneFunc
+}
+
+var isoDate = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// IsISODate reports whether s is already a YYYY-MM-DD date, which needs
+// no resolving.
+func IsISODate(s string) bool {
+	return isoDate.MatchString(strings.TrimSpace(s))
+}
+
+// Resolve parses expr into a date at midnight UTC. Expressions are a base
+// date optionally followed by offsets ("end of Q3 2026 - 2w"), an offset
+// alone ("+10d", from today), or "<duration> after|before <base>".
+// Quarters, halves and years follow the fiscal calendar; a period without
+// a year means the current one.
+func (r Resolver) Resolve(expr string) (time.Time, error) {
+	s := strings.Join(strings.Fields(strings.ToLower(expr)), " ")
+	if s == "" {
+		return time.Time{}, fmt.Errorf("empty date")
+	}
+	d, err := r.resolve(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cannot resolve date %q: %w (try %s)", strings.TrimSpace(expr), err, Syntax)
+	}
+	return d, nil
+}
+
+func (r Resolver) today() time.Time {
+	t := r.Today
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+var (
+	unitPattern     = `(d|days?|w|wks?|weeks?|m|mos?|months?|q|quarters?|y|yrs?|years?)`
+	numberPattern   = `(\d{1,4}|a|an|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve)`
+	durationPattern = regexp.MustCompile(`^` + numberPattern + ` ?` + unitPattern + `$`)
+	relativePattern = regexp.MustCompile(`^(.+?) (after|before) (.+)$`)
+	inPattern       = regexp.MustCompile(`^in (.+)$`)
+	agoPattern      = regexp.MustCompile(`^(.+) (ago|from now|from today)$`)
+	offsetPattern   = regexp.MustCompile(`^(.*?) ?([+-]) ?(\d{1,4}) ?` + unitPattern + `$`)
+	periodPattern   = regexp.MustCompile(`^(start|beginning|end) of (?:the )?(.+)$`)
+	weekdayPattern  = regexp.MustCompile(`^(?:(next|this|last) )?(monday|tuesday|wednesday|thursday|friday|saturday|sunday|mon|tue|tues|wed|thu|thur|thurs|fri|sat|sun)$`)
+	quarterPattern  = regexp.MustCompile(`^(?:q([1-4])(?: ?/? ?(?:fy ?)?(\d{4}))?|(?:fy ?)?(\d{4}) ?[/-]? ?q([1-4]))$`)
+	halfPattern     = regexp.MustCompile(`^(?:h([12])(?: ?/? ?(?:fy ?)?(\d{4}))?|(?:fy ?)?(\d{4}) ?[/-]? ?h([12]))$`)
+	yearPattern     = regexp.MustCompile(`^(?:fy ?|year )?(\d{4})$`)
+	monthPattern    = regexp.MustCompile(`^([a-z]+)(?: (\d{4}))?$`)
+	relPeriod       = regexp.MustCompile(`^(?:(this|next|last|current|previous) )?(week|month|quarter|half|year|fiscal year)$`)
+)
+
+var numberWords = map[string]int{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+}
+
+func (r Resolver) resolve(s string) (time.Time, error) {
+	if m := relativePattern.FindStringSubmatch(s); m != nil {
+		if n, unit, ok := parseDuration(m[1]); ok {
+			base, err := r.resolve(m[3])
+			if err != nil {
+				return time.Time{}, err
+			}
+			if m[2] == "before" {
+				n = -n
+			}
+			return shift(base, n, unit), nil
+		}
+	}
+	if m := inPattern.FindStringSubmatch(s); m != nil {
+		if n, unit, ok := parseDuration(m[1]); ok {
+			return shift(r.today(), n, unit), nil
+		}
+	}
+	if m := agoPattern.FindStringSubmatch(s); m != nil {
+		if n, unit, ok := parseDuration(m[1]); ok {
+			if m[2] == "ago" {
+				n = -n
+			}
+			return shift(r.today(), n, unit), nil
+		}
+	}
+	if m := offsetPattern.FindStringSubmatch(s); m != nil {
+		base := r.today()
+		if rest := strings.TrimSpace(m[1]); rest != "" {
+			var err error
+			if base, err = r.resolve(rest); err != nil {
+				return time.Time{}, err
+			}
+		}
+		n, _ := strconv.Atoi(m[3])
+		if m[2] == "-" {
+			n = -n
+		}
+		return shift(base, n, m[4][:1]), nil
+	}
+	return r.base(s)
+}
+
+// parseDuration parses "2 weeks", "2w" or "two weeks" into a count and a
+// one-letter unit.
+func parseDuration(s string) (int, string, bool) {
+	m := durationPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, "", false
+	}
+	n, ok := numberWords[m[1]]
+	if !ok {
+		n, _ = strconv.Atoi(m[1])
+	}
+	return n, m[2][:1], true
+}
+
+// shift moves day by n units: d(ays), w(eeks), m(onths), q(uarters) or
+// y(ears). Month arithmetic clamps to the end of shorter months, so
+// January 31 plus a month is the last day of February.
+func shift(day time.Time, n int, unit string) time.Time {
+	switch unit {
+	case "d":
+		return day.AddDate(0, 0, n)
+	case "w":
+		return day.AddDate(0, 0, 7*n)
+	case "q":
+		return addMonths(day, 3*n)
+	case "y":
+		return addMonths(day, 12*n)
+	default:
+		return addMonths(day, n)
+	}
+}
+
+func addMonths(day time.Time, n int) time.Time {
+	first := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, n, 0)
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day.Day(), last)-1)
+}
+
+// base resolves an expression without offsets.
+func (r Resolver) base(s string) (time.Time, error) {
+	today := r.today()
+	switch s {
+	case "today", "now":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+	if isoDate.MatchString(s) {
+		d, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%s is not a real date", s)
+		}
+		return d, nil
+	}
+	if name, ok := strings.CutPrefix(s, "milestone:"); ok {
+		return r.milestone(name)
+	}
+	if m := weekdayPattern.FindStringSubmatch(s); m != nil {
+		return r.Calendar.weekday(today, m[1], m[2]), nil
+	}
+	if m := periodPattern.FindStringSubmatch(s); m != nil {
+		p, ok := r.period(m[2])
+		if !ok {
+			return time.Time{}, fmt.Errorf("unknown period %q", m[2])
+		}
+		if m[1] == "end" {
+			return p.End, nil
+		}
+		return p.Start, nil
+	}
+	if _, ok := r.period(s); ok {
+		return time.Time{}, fmt.Errorf("%q is a period; say \"start of %s\" or \"end of %s\"", s, s, s)
+	}
+	return time.Time{}, fmt.Errorf("unrecognised expression")
+}
+
+func (r Resolver) milestone(name string) (time.Time, error) {
+	name = strings.Trim(strings.TrimSpace(name), `"'`)
+	if name == "" {
+		return time.Time{}, fmt.Errorf("milestone: needs a milestone name")
+	}
+	if r.Milestone == nil {
+		return time.Time{}, fmt.Errorf("milestone references are not available here")
+	}
+	return r.Milestone(name)
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// weekday resolves "monday" and "next monday" to the first Monday after
+// today, "last monday" to the last one before today, and "this monday" to
+// the Monday of the current week.
+func (c Calendar) weekday(today time.Time, which, name string) time.Time {
+	wd := weekdays[name]
+	switch which {
+	case "last":
+		back := (int(today.Weekday()) - int(wd) + 7) % 7
+		if back == 0 {
+			back = 7
+		}
+		return today.AddDate(0, 0, -back)
+	case "this":
+		return c.Week(today).Start.AddDate(0, 0, (int(wd)-int(c.FirstWeekday())+7)%7)
+	default:
+		ahead := (int(wd) - int(today.Weekday()) + 7) % 7
+		if ahead == 0 {
+			ahead = 7
+		}
+		return today.AddDate(0, 0, ahead)
+	}
+}
+
+// period resolves a named period: a quarter, half, fiscal year, month or
+// week, absolute ("Q3 2026", "FY2027", "june 2026") or relative ("next
+// quarter", "this month", or just "quarter" for the current one).
+func (r Resolver) period(s string) (Period, bool) {
+	today := r.today()
+	c := r.Calendar
+	if m := quarterPattern.FindStringSubmatch(s); m != nil {
+		q, year := atoi(m[1], m[4]), atoi(m[2], m[3])
+		if year == 0 {
+			year = c.FiscalYear(today)
+		}
+		return c.Quarter(year, q), true
+	}
+	if m := halfPattern.FindStringSubmatch(s); m != nil {
+		h, year := atoi(m[1], m[4]), atoi(m[2], m[3])
+		if year == 0 {
+			year = c.FiscalYear(today)
+		}
+		return c.Half(year, h), true
+	}
+	if m := yearPattern.FindStringSubmatch(s); m != nil {
+		return c.Year(atoi(m[1], "")), true
+	}
+	if m := relPeriod.FindStringSubmatch(s); m != nil {
+		step := map[string]int{"this": 0, "current": 0, "next": 1, "last": -1, "previous": -1}[m[1]]
+		switch m[2] {
+		case "week":
+			return c.Week(today.AddDate(0, 0, 7*step)), true
+		case "month":
+			d := addMonths(today, step)
+			return Month(d.Year(), d.Month()), true
+		case "quarter":
+			fy, q := c.QuarterOf(addMonths(today, 3*step))
+			return c.Quarter(fy, q), true
+		case "half":
+			fy, q := c.QuarterOf(addMonths(today, 6*step))
+			return c.Half(fy, (q+1)/2), true
+		default:
+			return c.Year(c.FiscalYear(today) + step), true
+		}
+	}
+	if m := monthPattern.FindStringSubmatch(s); m != nil {
+		month, ok := monthNames[m[1]]
+		if !ok {
+			return Period{}, false
+		}
+		year := atoi(m[2], "")
+		if year == 0 {
+			year = today.Year()
+		}
+		return Month(year, month), true
+	}
+	return Period{}, false
+}
+
+// atoi returns the first non-empty capture as an int, or zero.
+func atoi(a, b string) int {
+	if a == "" {
+		a = b
+	}
+	n, _ := strconv.Atoi(a)
+	return n
+}
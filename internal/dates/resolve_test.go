@@ -0,0 +1,115 @@
+package dates
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResolve(t *testing.T) {
+	r := Resolver{
+		Today: time.Date(2026, 6, 10, 15, 0, 0, 0, time.UTC), // Wednesday
+		Milestone: func(name string) (time.Time, error) {
+			if name != "public beta" {
+				return time.Time{}, fmt.Errorf("no milestone named %q", name)
+			}
+			return time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), nil
+		},
+	}
+	tests := map[string]string{
+		"2026-02-28":                            "2026-02-28",
+		"today":                                 "2026-06-10",
+		"Tomorrow":                              "2026-06-11",
+		"+2w":                                   "2026-06-24",
+		"-3d":                                   "2026-06-07",
+		"+1 month":                              "2026-07-10",
+		"in two weeks":                          "2026-06-24",
+		"3 days ago":                            "2026-06-07",
+		"next monday":                           "2026-06-15",
+		"wednesday":                             "2026-06-17",
+		"last wednesday":                        "2026-06-03",
+		"this friday":                           "2026-06-12",
+		"end of Q3 2026":                        "2026-09-30",
+		"start of 2026-Q4":                      "2026-10-01",
+		"end of q1":                             "2026-03-31",
+		"end of H1 2026":                        "2026-06-30",
+		"end of FY2026":                         "2026-12-31",
+		"start of next month":                   "2026-07-01",
+		"end of this quarter":                   "2026-06-30",
+		"end of the quarter":                    "2026-06-30",
+		"start of next week":                    "2026-06-15",
+		"end of february 2028":                  "2028-02-29",
+		"end of Q3 2026 - 2w":                   "2026-09-16",
+		"2026-01-31+1m":                         "2026-02-28",
+		"milestone:Public Beta+7d":              "2026-07-08",
+		`milestone:"Public Beta"`:               "2026-07-01",
+		"two weeks after milestone:public beta": "2026-07-15",
+		"1 day before end of next quarter":      "2026-09-29",
+	}
+	for expr, want := range tests {
+		got, err := r.Resolve(expr)
+		if err != nil {
+			t.Errorf("Resolve(%q): %v", expr, err)
+			continue
+		}
+		if got.Format(time.DateOnly) != want {
+			t.Errorf("Resolve(%q) = %s, want %s", expr, got.Format(time.DateOnly), want)
+		}
+	}
+
+	for expr, msg := range map[string]string{
+		"":               "empty date",
+		"2026-02-30":     "not a real date",
+		"soonish":        "unrecognised expression",
+		"Q3 2026":        `say "start of q3 2026"`,
+		"end of someday": "unknown period",
+		"milestone:GA":   `no milestone named "ga"`,
+		"milestone:":     "needs a milestone name",
+	} {
+		if _, err := r.Resolve(expr); err == nil || !strings.Contains(err.Error(), msg) {
+			t.Errorf("Resolve(%q) error = %v, want it to mention %q", expr, err, msg)
+		}
+	}
+
+	if _, err := (Resolver{}).Resolve("milestone:Beta"); err == nil {
+		t.Error("expected error for a milestone without a lookup")
+	}
+}
+
+func TestResolveFiscal(t *testing.T) {
+	r := Resolver{Calendar: Calendar{FiscalYearStart: time.February}, Today: time.Date(2026, 6, 10, 0, 0, 0, 0, time.UTC)}
+	tests := map[string]string{
+		"start of Q1 2027":      "2026-02-01",
+		"end of Q3 2027":        "2026-10-31",
+		"end of q4":             "2027-01-31",
+		"end of this quarter":   "2026-07-31",
+		"start of next quarter": "2026-08-01",
+		"end of H1":             "2026-07-31",
+		"end of FY2027":         "2027-01-31",
+		"start of fiscal year":  "2026-02-01",
+		"end of last year":      "2026-01-31",
+	}
+	for expr, want := range tests {
+		got, err := r.Resolve(expr)
+		if err != nil || got.Format(time.DateOnly) != want {
+			t.Errorf("Resolve(%q) = %s, %v; want %s", expr, got.Format(time.DateOnly), err, want)
+		}
+	}
+}
+
+func TestResolveWeekStart(t *testing.T) {
+	r := Resolver{Calendar: Calendar{}.WithWeekStart(time.Sunday), Today: time.Date(2026, 6, 10, 0, 0, 0, 0, time.UTC)}
+	tests := map[string]string{
+		"start of this week": "2026-06-07",
+		"end of next week":   "2026-06-20",
+		"this monday":        "2026-06-08",
+		"this sunday":        "2026-06-07",
+	}
+	for expr, want := range tests {
+		got, err := r.Resolve(expr)
+		if err != nil || got.Format(time.DateOnly) != want {
+			t.Errorf("Resolve(%q) = %s, %v; want %s", expr, got.Format(time.DateOnly), err, want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package dates
+
+import "time"
+
+// Span is a labelled period with YYYY-MM-DD bounds.
+type Span struct {
+	Label string `json:"label"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+func span(label string, p Period) Span {
+	return Span{Label: label, Start: p.Start.Format(time.DateOnly), End: p.End.Format(time.DateOnly)}
+}
+
+// Timeframe places one date on the calendar.
+type Timeframe struct {
+	Date          string `json:"date"`
+	Weekday       string `json:"weekday"`
+	FiscalYear    int    `json:"fiscal_year"`
+	QuarterNumber int    `json:"quarter_number"`
+	HalfNumber    int    `json:"half_number"`
+	// DayOfQuarter counts from 1 on the quarter's first day.
+	DayOfQuarter  int  `json:"day_of_quarter"`
+	DaysInQuarter int  `json:"days_in_quarter"`
+	Year          Span `json:"year"`
+	Half          Span `json:"half"`
+	Quarter       Span `json:"quarter"`
+	Month         Span `json:"month"`
+	Week          Span `json:"week"`
+}
+
+// Timeframe returns the fiscal year, half, quarter, month and week day
+// falls in.
+func (c Calendar) Timeframe(day time.Time) Timeframe {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	fy, q := c.QuarterOf(day)
+	h := (q + 1) / 2
+	quarter := c.Quarter(fy, q)
+	return Timeframe{
+		Date:          day.Format(time.DateOnly),
+		Weekday:       day.Weekday().String(),
+		FiscalYear:    fy,
+		QuarterNumber: q,
+		HalfNumber:    h,
+		DayOfQuarter:  int(day.Sub(quarter.Start).Hours()/24) + 1,
+		DaysInQuarter: int(quarter.End.Sub(quarter.Start).Hours()/24) + 1,
+		Year:          span(c.YearLabel(fy), c.Year(fy)),
+		Half:          span(c.HalfLabel(fy, h), c.Half(fy, h)),
+		Quarter:       span(c.QuarterLabel(fy, q), quarter),
+		Month:         span(day.Format("2006-01"), Month(day.Year(), day.Month())),
+		Week:          span(c.WeekLabel(day), c.Week(day)),
+	}
+}
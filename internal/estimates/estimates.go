@@ -0,0 +1,207 @@
+// Package estimates keeps prioritisation estimates — reach, impact,
+// confidence, effort and custom criteria — that ProductPlan has no field
+// for. They live in a local JSON file keyed by opportunity ID so scores can
+// be recomputed across sessions.
+package estimates
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EnvFile names the environment variable that overrides the store path.
+const EnvFile = "PRODUCTPLAN_ESTIMATES_FILE"
+
+// fileVersion is the on-disk format version.
+const fileVersion = 1
+
+// Estimate holds the inputs for one opportunity. Nil fields are not
+// estimated yet.
+type Estimate struct {
+	Reach      *float64           `json:"reach,omitempty"`
+	Impact     *float64           `json:"impact,omitempty"`
+	Confidence *float64           `json:"confidence,omitempty"`
+	Effort     *float64           `json:"effort,omitempty"`
+	Ease       *float64           `json:"ease,omitempty"`
+	Criteria   map[string]float64 `json:"criteria,omitempty"`
+	UpdatedAt  string             `json:"updated_at,omitempty"`
+}
+
+// Merge returns e with every field set in update copied over it. Criteria
+// merge by name.
+func (e Estimate) Merge(update Estimate) Estimate {
+	for _, f := range []struct{ dst, src **float64 }{
+		{&e.Reach, &update.Reach},
+		{&e.Impact, &update.Impact},
+		{&e.Confidence, &update.Confidence},
+		{&e.Effort, &update.Effort},
+		{&e.Ease, &update.Ease},
+	} {
+		if *f.src != nil {
+			*f.dst = *f.src
+		}
+	}
+	if len(update.Criteria) > 0 {
+		merged := make(map[string]float64, len(e.Criteria)+len(update.Criteria))
+		for k, v := range e.Criteria {
+			merged[k] = v
+		}
+		for k, v := range update.Criteria {
+			merged[k] = v
+		}
+		e.Criteria = merged
+	}
+	if update.UpdatedAt != "" {
+		e.UpdatedAt = update.UpdatedAt
+	}
+	return e
+}
+
+// file is the on-disk layout.
+type file struct {
+	Version       int                 `json:"version"`
+	Opportunities map[string]Estimate `json:"opportunities"`
+}
+
+// Store reads and writes estimates. A Store with no path keeps estimates
+// in memory only. It is safe for concurrent use.
+type Store struct {
+	path   string
+	mu     sync.Mutex
+	memory map[string]Estimate
+}
+
+// Open returns a store backed by path; the file is created on first save.
+// An empty path gives an in-memory store.
+func Open(path string) *Store {
+	return &Store{path: path, memory: map[string]Estimate{}}
+}
+
+// DefaultPath returns the store path: $PRODUCTPLAN_ESTIMATES_FILE when set,
+// otherwise estimates.json in the user config directory.
+func DefaultPath() (string, error) {
+	if p := os.Getenv(EnvFile); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "productplan-mcp", "estimates.json"), nil
+}
+
+// WorkspacePath returns the store path for a named workspace: path with
+// the workspace name before its extension, e.g. estimates-sandbox.json,
+// so accounts whose opportunity IDs collide keep separate estimates. An
+// empty workspace returns path unchanged.
+func WorkspacePath(path, workspace string) string {
+	if path == "" || workspace == "" {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + workspace + ext
+}
+
+// Path returns the backing file, or "" for an in-memory store.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load returns every stored estimate keyed by opportunity ID. A missing
+// file is an empty store.
+func (s *Store) Load() (map[string]Estimate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+// Update merges changes into the stored estimates, stamping each changed
+// entry with now, saves, and returns the full set.
+func (s *Store) Update(changes map[string]Estimate, now time.Time) (map[string]Estimate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return all, nil
+	}
+	stamp := now.UTC().Format(time.RFC3339)
+	for id, change := range changes {
+		change.UpdatedAt = stamp
+		all[id] = all[id].Merge(change)
+	}
+	if err = s.save(all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+func (s *Store) load() (map[string]Estimate, error) {
+	if s.path == "" {
+		out := make(map[string]Estimate, len(s.memory))
+		for k, v := range s.memory {
+			out[k] = v
+		}
+		return out, nil
+	}
+	data, err := os.ReadFile(s.path) // #nosec G304 -- path is the operator-configured estimates file
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]Estimate{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read estimates: %w", err)
+	}
+	var f file
+	if err = json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse estimates %s: %w", s.path, err)
+	}
+	if f.Version > fileVersion {
+		return nil, fmt.Errorf("estimates %s: unsupported version %d", s.path, f.Version)
+	}
+	if f.Opportunities == nil {
+		f.Opportunities = map[string]Estimate{}
+	}
+	return f.Opportunities, nil
+}
+
+// save writes the file atomically: a temp file in the same directory is
+// renamed over the old one, so a crash never leaves half a file.
+func (s *Store) save(all map[string]Estimate) error {
+	if s.path == "" {
+		s.memory = all
+		return nil
+	}
+	data, err := json.MarshalIndent(file{Version: fileVersion, Opportunities: all}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode estimates: %w", err)
+	}
+	dir := filepath.Dir(s.path)
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("create estimates directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".estimates-*.json")
+	if err != nil {
+		return fmt.Errorf("write estimates: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err = tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write estimates: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("write estimates: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("write estimates: %w", err)
+	}
+	return nil
+}
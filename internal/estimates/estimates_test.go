@@ -0,0 +1,99 @@
+package estimates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func f(v float64) *float64 { return &v }
+
+var now = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "estimates.json")
+	s := Open(path)
+
+	all, err := s.Load()
+	if err != nil || len(all) != 0 {
+		t.Fatalf("empty store: %v, %v", all, err)
+	}
+	if _, err = s.Update(map[string]Estimate{"5": {Reach: f(100), Impact: f(2), Criteria: map[string]float64{"fit": 3}}}, now); err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+	if _, err = s.Update(map[string]Estimate{"5": {Impact: f(3), Criteria: map[string]float64{"risk": 1}}}, now.Add(time.Hour)); err != nil {
+		t.Fatalf("second update: %v", err)
+	}
+
+	all, err = Open(path).Load()
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	e := all["5"]
+	if *e.Reach != 100 || *e.Impact != 3 || e.Effort != nil || e.Criteria["fit"] != 3 || e.Criteria["risk"] != 1 {
+		t.Errorf("unexpected merged estimate %+v", e)
+	}
+	if e.UpdatedAt != "2026-06-01T13:00:00Z" {
+		t.Errorf("updated_at = %q", e.UpdatedAt)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		t.Errorf("estimates file should not be readable by others, mode %v", info.Mode())
+	}
+}
+
+func TestStoreInMemory(t *testing.T) {
+	s := Open("")
+	if _, err := s.Update(map[string]Estimate{"1": {Effort: f(2)}}, now); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	all, err := s.Load()
+	if err != nil || *all["1"].Effort != 2 {
+		t.Errorf("in-memory store lost the estimate: %v, %v", all, err)
+	}
+	if s.Path() != "" {
+		t.Errorf("path = %q", s.Path())
+	}
+}
+
+func TestStoreRejectsBadFiles(t *testing.T) {
+	dir := t.TempDir()
+	for name, body := range map[string]string{
+		"garbage.json": "not json",
+		"future.json":  `{"version": 99, "opportunities": {}}`,
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Open(path).Load(); err == nil || !strings.Contains(err.Error(), name) {
+			t.Errorf("%s: expected an error naming the file, got %v", name, err)
+		}
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv(EnvFile, "/tmp/custom.json")
+	if p, err := DefaultPath(); err != nil || p != "/tmp/custom.json" {
+		t.Errorf("DefaultPath() = %q, %v", p, err)
+	}
+}
+
+func TestWorkspacePath(t *testing.T) {
+	tests := []struct{ path, workspace, want string }{
+		{"/cfg/estimates.json", "", "/cfg/estimates.json"},
+		{"/cfg/estimates.json", "sandbox", "/cfg/estimates-sandbox.json"},
+		{"/cfg/estimates", "sandbox", "/cfg/estimates-sandbox"},
+		{"", "sandbox", ""},
+	}
+	for _, tt := range tests {
+		if got := WorkspacePath(tt.path, tt.workspace); got != tt.want {
+			t.Errorf("WorkspacePath(%q, %q) = %q, want %q", tt.path, tt.workspace, got, tt.want)
+		}
+	}
+}
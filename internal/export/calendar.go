@@ -0,0 +1,151 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/pkg/productplan"
+)
+
+// uidDomain scopes event UIDs so they cannot collide with other producers.
+const uidDomain = "productplan-mcp-server"
+
+// CalendarOptions selects what BuildCalendar exports.
+type CalendarOptions struct {
+	// RoadmapIDs lists roadmaps whose milestones become events.
+	RoadmapIDs []string
+	// IncludeLaunches adds one event per dated launch.
+	IncludeLaunches bool
+	// IncludeTasks adds one event per launch task with a due date.
+	// Ignored unless IncludeLaunches is set.
+	IncludeTasks bool
+}
+
+// launchWithTasks pairs a launch with its fetched tasks.
+type launchWithTasks struct {
+	launch api.Launch
+	tasks  []api.LaunchTask
+}
+
+// BuildCalendar fetches milestones, launches and launch tasks and returns
+// them as an iCalendar document. Items without a parseable date are skipped.
+func BuildCalendar(ctx context.Context, client *api.Client, opts CalendarOptions) (*Calendar, error) {
+	cal := &Calendar{Name: "ProductPlan"}
+
+	var names []string
+	for _, id := range opts.RoadmapIDs {
+		roadmap, err := client.FetchRoadmap(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("roadmap %s: %w", id, err)
+		}
+		milestones, err := client.FetchRoadmapMilestones(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("roadmap %s milestones: %w", id, err)
+		}
+		names = append(names, roadmap.Name)
+		cal.Events = append(cal.Events, MilestoneEvents(roadmap, milestones)...)
+	}
+	if len(names) > 0 {
+		cal.Name = "ProductPlan: " + strings.Join(names, ", ")
+	}
+
+	if opts.IncludeLaunches {
+		launches, err := fetchLaunches(ctx, client, opts.IncludeTasks)
+		if err != nil {
+			return nil, err
+		}
+		for _, lt := range launches {
+			cal.Events = append(cal.Events, LaunchEvents(lt.launch, lt.tasks)...)
+		}
+	}
+
+	sort.SliceStable(cal.Events, func(i, j int) bool {
+		return cal.Events[i].Date.Before(cal.Events[j].Date)
+	})
+	return cal, nil
+}
+
+// fetchLaunches returns every launch, with tasks fetched in parallel when requested.
+func fetchLaunches(ctx context.Context, client *api.Client, withTasks bool) ([]launchWithTasks, error) {
+	launches, err := client.FetchLaunches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("launches: %w", err)
+	}
+	if !withTasks {
+		out := make([]launchWithTasks, len(launches))
+		for i, l := range launches {
+			out[i] = launchWithTasks{launch: l}
+		}
+		return out, nil
+	}
+
+	fns := make([]func(ctx context.Context) (launchWithTasks, error), len(launches))
+	for i, l := range launches {
+		fns[i] = func(ctx context.Context) (launchWithTasks, error) {
+			tasks, err := client.FetchLaunchTasks(ctx, l.ID.String())
+			if err != nil {
+				return launchWithTasks{}, fmt.Errorf("launch %s tasks: %w", l.ID, err)
+			}
+			return launchWithTasks{launch: l, tasks: tasks}, nil
+		}
+	}
+	result := productplan.Execute(ctx, productplan.DefaultBatchConfig(), fns)
+	if result.HasErrors() {
+		return nil, result.Errors[0].Err
+	}
+	return result.Results, nil
+}
+
+// MilestoneEvents converts a roadmap's milestones into calendar events.
+func MilestoneEvents(roadmap api.Roadmap, milestones []api.Milestone) []Event {
+	events := make([]Event, 0, len(milestones))
+	for _, m := range milestones {
+		date, ok := api.ParseDate(m.Date)
+		if !ok {
+			continue
+		}
+		events = append(events, Event{
+			UID:         fmt.Sprintf("milestone-%s-%s@%s", roadmap.ID, m.ID, uidDomain),
+			Summary:     m.Label(),
+			Description: "Milestone on roadmap " + roadmap.Name,
+			Date:        date,
+			Categories:  []string{"Milestone", roadmap.Name},
+		})
+	}
+	return events
+}
+
+// LaunchEvents converts a launch and its task due dates into calendar events.
+func LaunchEvents(launch api.Launch, tasks []api.LaunchTask) []Event {
+	var events []Event
+	if date, ok := api.ParseDate(launch.Date); ok {
+		events = append(events, Event{
+			UID:         fmt.Sprintf("launch-%s@%s", launch.ID, uidDomain),
+			Summary:     "Launch: " + launch.Name,
+			Description: launch.Description,
+			Date:        date,
+			Categories:  []string{"Launch"},
+		})
+	}
+	for _, t := range tasks {
+		date, ok := api.ParseDate(t.DueDate)
+		if !ok {
+			continue
+		}
+		desc := "Launch task for " + launch.Name
+		if t.Status != "" {
+			desc += " (status: " + t.Status + ")"
+		}
+		events = append(events, Event{
+			UID:         fmt.Sprintf("launch-task-%s-%s@%s", launch.ID, t.ID, uidDomain),
+			Summary:     t.Name + " due (" + launch.Name + ")",
+			Description: desc,
+			Date:        date,
+			Categories:  []string{"Launch Task"},
+		})
+	}
+	return events
+}
@@ -0,0 +1,72 @@
+package export
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+)
+
+func TestBuildCalendar(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/roadmaps/10":            `{"id": 10, "name": "Platform"}`,
+		"/roadmaps/10/milestones": `[{"id": 1, "title": "GA", "date": "2026-06-01"}, {"id": 2, "title": "Undated", "date": null}]`,
+		"/launches":               `[{"id": 5, "name": "Spring release", "date": "2026-04-15"}]`,
+		"/launches/5/tasks":       `[{"id": 50, "name": "Docs", "due_date": "2026-04-01", "status": "to_do"}]`,
+	})
+
+	cal, err := BuildCalendar(context.Background(), client, CalendarOptions{
+		RoadmapIDs:      []string{"10"},
+		IncludeLaunches: true,
+		IncludeTasks:    true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(cal.Events) != 3 {
+		t.Fatalf("expected 3 events (undated milestone skipped), got %d", len(cal.Events))
+	}
+	wantUIDs := []string{
+		"launch-task-5-50@productplan-mcp-server",
+		"launch-5@productplan-mcp-server",
+		"milestone-10-1@productplan-mcp-server",
+	}
+	for i, want := range wantUIDs {
+		if cal.Events[i].UID != want {
+			t.Errorf("event %d UID = %q, want %q (events sorted by date)", i, cal.Events[i].UID, want)
+		}
+	}
+	if !strings.Contains(cal.Name, "Platform") {
+		t.Errorf("expected calendar name to mention the roadmap, got %q", cal.Name)
+	}
+}
+
+func TestBuildCalendarStableUIDs(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/launches": `[{"id": 5, "name": "Spring release", "date": "2026-04-15"}]`,
+	})
+
+	opts := CalendarOptions{IncludeLaunches: true}
+	first, err := BuildCalendar(context.Background(), client, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := BuildCalendar(context.Background(), client, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.Events[0].UID != second.Events[0].UID {
+		t.Errorf("UIDs differ between exports: %q vs %q", first.Events[0].UID, second.Events[0].UID)
+	}
+}
+
+func TestBuildCalendarRoadmapError(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{})
+
+	_, err := BuildCalendar(context.Background(), client, CalendarOptions{RoadmapIDs: []string{"404"}})
+	if err == nil {
+		t.Fatal("expected error for missing roadmap")
+	}
+}
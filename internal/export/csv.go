@@ -0,0 +1,115 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// listSeparator joins multi-valued cells (tags, customers).
+const listSeparator = "; "
+
+// utf8BOM makes spreadsheet applications detect UTF-8 when opening a CSV.
+const utf8BOM = "\ufeff"
+
+// Column is one CSV column: a stable header and a cell extractor.
+type Column[T any] struct {
+	Name  string
+	Value func(T) string
+}
+
+// ColumnNames returns the headers of cols in order.
+func ColumnNames[T any](cols []Column[T]) []string {
+	names := make([]string, len(cols))
+	for i, c := range cols {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// SelectColumns returns the named columns in the requested order. An empty
+// selection returns all columns unchanged. Unknown names are an error so a
+// typo cannot silently drop a column a downstream sheet depends on.
+func SelectColumns[T any](all []Column[T], names []string) ([]Column[T], error) {
+	if len(names) == 0 {
+		return all, nil
+	}
+	byName := make(map[string]Column[T], len(all))
+	for _, c := range all {
+		byName[c.Name] = c
+	}
+	selected := make([]Column[T], 0, len(names))
+	for _, name := range names {
+		c, ok := byName[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q (available: %s)", name, strings.Join(ColumnNames(all), ", "))
+		}
+		selected = append(selected, c)
+	}
+	return selected, nil
+}
+
+// CSVOptions controls CSV encoding.
+type CSVOptions struct {
+	// BOM prefixes the output with a UTF-8 byte order mark and uses CRLF
+	// line endings, which is what Excel expects.
+	BOM bool
+}
+
+// WriteCSV writes a header row followed by one row per item.
+func WriteCSV[T any](w io.Writer, cols []Column[T], rows []T, opts CSVOptions) error {
+	if opts.BOM {
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return err
+		}
+	}
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = opts.BOM
+	if err := cw.Write(ColumnNames(cols)); err != nil {
+		return err
+	}
+	record := make([]string, len(cols))
+	for _, row := range rows {
+		for i, c := range cols {
+			record[i] = sanitizeCell(c.Value(row))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// sanitizeCell neutralises spreadsheet formula injection. A cell that starts
+// with =, +, -, @ or a control character is executed as a formula by Excel
+// and Sheets, so text from ProductPlan (bar names, customer names) is
+// prefixed with a single quote. Numbers such as "-5" pass through unchanged.
+func sanitizeCell(s string) string {
+	if s == "" {
+		return s
+	}
+	switch s[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		if _, err := strconv.ParseFloat(s, 64); err == nil {
+			return s
+		}
+		return "'" + s
+	}
+	return s
+}
+
+// formatNumber renders an optional number without trailing zeros.
+func formatNumber(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
+
+// joinList joins multi-valued cells with listSeparator.
+func joinList(items []string) string {
+	return strings.Join(items, listSeparator)
+}
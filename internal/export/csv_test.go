@@ -0,0 +1,77 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type row struct{ a, b string }
+
+var testColumns = []Column[row]{
+	{"a", func(r row) string { return r.a }},
+	{"b", func(r row) string { return r.b }},
+}
+
+func TestSelectColumns(t *testing.T) {
+	all, err := SelectColumns(testColumns, nil)
+	if err != nil || len(all) != 2 {
+		t.Fatalf("expected all columns, got %v (%v)", ColumnNames(all), err)
+	}
+
+	reordered, err := SelectColumns(testColumns, []string{"b", " a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(ColumnNames(reordered), ","); got != "b,a" {
+		t.Errorf("expected b,a got %s", got)
+	}
+
+	if _, err := SelectColumns(testColumns, []string{"c"}); err == nil || !strings.Contains(err.Error(), "available: a, b") {
+		t.Errorf("expected unknown column error listing available columns, got %v", err)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	rows := []row{{"x, y", "1"}, {"=HYPERLINK()", "-5"}}
+	if err := WriteCSV(&buf, testColumns, rows, CSVOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "a,b\n\"x, y\",1\n'=HYPERLINK(),-5\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteCSVBOM(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, testColumns, []row{{"1", "2"}}, CSVOptions{BOM: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), utf8BOM) {
+		t.Error("expected BOM prefix")
+	}
+	if !strings.Contains(buf.String(), "a,b\r\n") {
+		t.Error("expected CRLF line endings")
+	}
+}
+
+func TestSanitizeCell(t *testing.T) {
+	tests := map[string]string{
+		"":         "",
+		"plain":    "plain",
+		"=1+1":     "'=1+1",
+		"+cmd":     "'+cmd",
+		"@SUM(A1)": "'@SUM(A1)",
+		"-3.5":     "-3.5",
+		"-not num": "'-not num",
+		"\tindent": "'\tindent",
+	}
+	for in, want := range tests {
+		if got := sanitizeCell(in); got != want {
+			t.Errorf("sanitizeCell(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
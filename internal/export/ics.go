@@ -0,0 +1,111 @@
+// Package export renders ProductPlan data into formats consumed outside the
+// MCP conversation: calendar feeds, spreadsheets and Markdown status reports.
+package export
+
+import (
+	"strings"
+	"time"
+)
+
+// icsLineLimit is the maximum octets per content line before folding (RFC 5545 §3.1).
+const icsLineLimit = 75
+
+// icsProdID identifies this server as the calendar producer.
+const icsProdID = "-//productplan-mcp-server//ProductPlan Export//EN"
+
+// Event is a single all-day calendar entry.
+type Event struct {
+	// UID must be stable across exports so that calendar clients update the
+	// existing entry on re-import instead of adding a duplicate.
+	UID         string
+	Summary     string
+	Description string
+	Date        time.Time
+	Categories  []string
+}
+
+// Calendar is an iCalendar document made of all-day events.
+type Calendar struct {
+	Name   string
+	Events []Event
+	// Stamp is written as DTSTAMP on every event. Zero means time.Now.
+	Stamp time.Time
+}
+
+// Encode renders the calendar as an RFC 5545 document with CRLF line endings.
+func (c *Calendar) Encode() string {
+	stamp := c.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+	dtstamp := stamp.UTC().Format("20060102T150405Z")
+
+	var b strings.Builder
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:"+icsProdID)
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+	if c.Name != "" {
+		writeLine(&b, "X-WR-CALNAME:"+escapeText(c.Name))
+	}
+	for _, e := range c.Events {
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+e.UID)
+		writeLine(&b, "DTSTAMP:"+dtstamp)
+		writeLine(&b, "DTSTART;VALUE=DATE:"+e.Date.Format("20060102"))
+		writeLine(&b, "DTEND;VALUE=DATE:"+e.Date.AddDate(0, 0, 1).Format("20060102"))
+		writeLine(&b, "SUMMARY:"+escapeText(e.Summary))
+		if e.Description != "" {
+			writeLine(&b, "DESCRIPTION:"+escapeText(e.Description))
+		}
+		if len(e.Categories) > 0 {
+			escaped := make([]string, len(e.Categories))
+			for i, cat := range e.Categories {
+				escaped[i] = escapeText(cat)
+			}
+			writeLine(&b, "CATEGORIES:"+strings.Join(escaped, ","))
+		}
+		writeLine(&b, "TRANSP:TRANSPARENT")
+		writeLine(&b, "END:VEVENT")
+	}
+	writeLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+// escapeText escapes a TEXT value per RFC 5545 §3.3.11.
+func escapeText(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return r.Replace(s)
+}
+
+// writeLine writes a content line, folding it at icsLineLimit octets without
+// splitting a UTF-8 sequence. Continuation lines start with a single space.
+func writeLine(b *strings.Builder, line string) {
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines lose one octet to the leading space.
+		limit = icsLineLimit - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// isRuneStart reports whether b begins a UTF-8 sequence.
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
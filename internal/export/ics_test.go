@@ -0,0 +1,67 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCalendarEncode(t *testing.T) {
+	cal := &Calendar{
+		Name:  "Roadmap",
+		Stamp: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Events: []Event{{
+			UID:         "milestone-1-2@productplan-mcp-server",
+			Summary:     "Beta; phase 1, final",
+			Description: "line one\nline two",
+			Date:        time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+			Categories:  []string{"Milestone", "Core, Platform"},
+		}},
+	}
+
+	out := cal.Encode()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Roadmap\r\n",
+		"UID:milestone-1-2@productplan-mcp-server\r\n",
+		"DTSTAMP:20260102T030405Z\r\n",
+		"DTSTART;VALUE=DATE:20260331\r\n",
+		"DTEND;VALUE=DATE:20260401\r\n",
+		`SUMMARY:Beta\; phase 1\, final` + "\r\n",
+		`DESCRIPTION:line one\nline two` + "\r\n",
+		`CATEGORIES:Milestone,Core\, Platform` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteLineFolds(t *testing.T) {
+	var b strings.Builder
+	long := "SUMMARY:" + strings.Repeat("é", 100)
+	writeLine(&b, long)
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected folded output, got %d line(s)", len(lines))
+	}
+	var rebuilt strings.Builder
+	for i, line := range lines {
+		if len(line) > icsLineLimit {
+			t.Errorf("line %d is %d octets, limit %d", i, len(line), icsLineLimit)
+		}
+		if i > 0 {
+			if !strings.HasPrefix(line, " ") {
+				t.Errorf("continuation line %d does not start with a space", i)
+			}
+			line = line[1:]
+		}
+		rebuilt.WriteString(line)
+	}
+	if rebuilt.String() != long {
+		t.Error("unfolded output does not match the input")
+	}
+}
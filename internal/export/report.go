@@ -0,0 +1,446 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+)
+
+// Report grouping modes.
+const (
+	GroupByHorizon = "horizon"
+	GroupByQuarter = "quarter"
+)
+
+// Horizon group names, in display order.
+const (
+	groupNow         = "Now"
+	groupNext        = "Next"
+	groupLater       = "Later"
+	groupDone        = "Recently done"
+	groupUnscheduled = "Unscheduled"
+)
+
+const (
+	// nextHorizonDays is how far ahead a bar may start and still be "Next".
+	nextHorizonDays = 90
+	// recentlyDoneDays is how long a finished bar stays in the report.
+	recentlyDoneDays = 14
+	// progressWidth is the number of cells in a percent-done bar.
+	progressWidth = 10
+	// commentMaxLen caps a comment excerpt, in runes.
+	commentMaxLen = 200
+	// compactMaxItems caps the bars listed per line in the compact variant.
+	compactMaxItems = 8
+)
+
+// ReportOptions controls what a roadmap report contains.
+type ReportOptions struct {
+	// GroupBy is GroupByHorizon (now/next/later) or GroupByQuarter.
+	GroupBy string
+	// MilestoneDays is the look-ahead window for upcoming milestones.
+	// Zero omits the milestones section.
+	MilestoneDays int
+	// CommentLimit is the number of most recent comments to include.
+	// Zero omits the comments section.
+	CommentLimit int
+	// Now is the report date. Zero means today.
+	Now time.Time
+	// Calendar places the quarters GroupByQuarter groups by.
+	Calendar dates.Calendar
+}
+
+// DefaultReportOptions returns the options used when a caller sets none.
+func DefaultReportOptions() ReportOptions {
+	return ReportOptions{GroupBy: GroupByHorizon, MilestoneDays: 30, CommentLimit: 5}
+}
+
+// ReportGroup is a horizon or quarter within a lane.
+type ReportGroup struct {
+	Name string
+	Bars []api.Bar
+}
+
+// ReportLane is a lane and its bars, grouped.
+type ReportLane struct {
+	Name   string
+	Groups []ReportGroup
+}
+
+// Report is the data behind a roadmap status report.
+type Report struct {
+	Roadmap    api.Roadmap
+	Date       time.Time
+	Options    ReportOptions
+	Lanes      []ReportLane
+	Milestones []api.Milestone
+	Comments   []api.Comment
+}
+
+// BuildReport fetches a roadmap and arranges it for a status report.
+// Parked bars are left out, as are bars that finished more than
+// recentlyDoneDays ago when grouping by horizon.
+func BuildReport(ctx context.Context, client *api.Client, roadmapID string, opts ReportOptions) (*Report, error) {
+	if opts.GroupBy == "" {
+		opts.GroupBy = GroupByHorizon
+	}
+	if opts.GroupBy != GroupByHorizon && opts.GroupBy != GroupByQuarter {
+		return nil, fmt.Errorf("unknown grouping %q (use %s or %s)", opts.GroupBy, GroupByHorizon, GroupByQuarter)
+	}
+	today := opts.Now
+	if today.IsZero() {
+		today = time.Now()
+	}
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+
+	roadmap, err := client.FetchRoadmap(ctx, roadmapID)
+	if err != nil {
+		return nil, fmt.Errorf("roadmap %s: %w", roadmapID, err)
+	}
+	bars, err := client.FetchRoadmapBars(ctx, roadmapID)
+	if err != nil {
+		return nil, fmt.Errorf("roadmap %s bars: %w", roadmapID, err)
+	}
+	lanes, err := client.FetchRoadmapLanes(ctx, roadmapID)
+	if err != nil {
+		return nil, fmt.Errorf("roadmap %s lanes: %w", roadmapID, err)
+	}
+
+	r := &Report{Roadmap: roadmap, Date: today, Options: opts}
+	r.Lanes = groupLanes(lanes, bars, func(b api.Bar) string { return barGroup(b, today, opts) })
+
+	if opts.MilestoneDays > 0 {
+		var milestones []api.Milestone
+		if milestones, err = client.FetchRoadmapMilestones(ctx, roadmapID); err != nil {
+			return nil, fmt.Errorf("roadmap %s milestones: %w", roadmapID, err)
+		}
+		r.Milestones = upcomingMilestones(milestones, today, opts.MilestoneDays)
+	}
+	if opts.CommentLimit > 0 {
+		var comments []api.Comment
+		if comments, err = client.FetchRoadmapComments(ctx, roadmapID); err != nil {
+			return nil, fmt.Errorf("roadmap %s comments: %w", roadmapID, err)
+		}
+		r.Comments = recentComments(comments, opts.CommentLimit)
+	}
+	return r, nil
+}
+
+// groupLanes buckets bars by lane and then by group, keeping the API's lane
+// order. Bars whose lane is unknown go under "No lane" at the end. Bars for
+// which group returns "" are dropped.
+func groupLanes(lanes []api.Lane, bars []api.Bar, group func(api.Bar) string) []ReportLane {
+	byLane := make(map[api.ID][]api.Bar)
+	known := make(map[api.ID]bool, len(lanes))
+	for _, l := range lanes {
+		known[l.ID] = true
+	}
+	var orphans []api.Bar
+	for _, b := range bars {
+		if b.Parked {
+			continue
+		}
+		if known[b.LaneID] {
+			byLane[b.LaneID] = append(byLane[b.LaneID], b)
+		} else {
+			orphans = append(orphans, b)
+		}
+	}
+
+	var out []ReportLane
+	add := func(name string, laneBars []api.Bar) {
+		groups := groupBars(laneBars, group)
+		if len(groups) > 0 {
+			out = append(out, ReportLane{Name: name, Groups: groups})
+		}
+	}
+	for _, l := range lanes {
+		add(l.Name, byLane[l.ID])
+	}
+	add("No lane", orphans)
+	return out
+}
+
+// groupBars buckets bars and orders the groups for display.
+func groupBars(bars []api.Bar, group func(api.Bar) string) []ReportGroup {
+	byGroup := make(map[string][]api.Bar)
+	for _, b := range bars {
+		if g := group(b); g != "" {
+			byGroup[g] = append(byGroup[g], b)
+		}
+	}
+	names := make([]string, 0, len(byGroup))
+	for name := range byGroup {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return groupRank(names[i]) < groupRank(names[j]) })
+
+	groups := make([]ReportGroup, len(names))
+	for i, name := range names {
+		gb := byGroup[name]
+		sort.SliceStable(gb, func(x, y int) bool {
+			if gb[x].StartsOn != gb[y].StartsOn {
+				return gb[x].StartsOn < gb[y].StartsOn
+			}
+			return gb[x].Name < gb[y].Name
+		})
+		groups[i] = ReportGroup{Name: name, Bars: gb}
+	}
+	return groups
+}
+
+// groupRank orders horizon groups first-to-last and quarter groups
+// chronologically ("2026 Q1" and "FY2027 Q1" sort as text), with
+// Unscheduled always last.
+func groupRank(name string) string {
+	switch name {
+	case groupNow:
+		return "0"
+	case groupNext:
+		return "1"
+	case groupLater:
+		return "2"
+	case groupDone:
+		return "3"
+	case groupUnscheduled:
+		return "9"
+	}
+	return "5" + name
+}
+
+// barGroup returns the group a bar belongs to on the report date, or ""
+// to leave it out.
+func barGroup(b api.Bar, today time.Time, opts ReportOptions) string {
+	start, hasStart := api.ParseDate(b.StartsOn)
+	end, hasEnd := api.ParseDate(b.EndsOn)
+
+	if opts.GroupBy == GroupByQuarter {
+		switch {
+		case hasStart:
+			return quarterLabel(start, opts.Calendar)
+		case hasEnd:
+			return quarterLabel(end, opts.Calendar)
+		}
+		return groupUnscheduled
+	}
+
+	switch {
+	case hasEnd && end.Before(today):
+		if today.Sub(end) <= recentlyDoneDays*24*time.Hour {
+			return groupDone
+		}
+		return ""
+	case !hasStart && !hasEnd:
+		return groupUnscheduled
+	case !hasStart || !start.After(today):
+		return groupNow
+	case !start.After(today.AddDate(0, 0, nextHorizonDays)):
+		return groupNext
+	}
+	return groupLater
+}
+
+// quarterLabel returns the quarter of t on cal, e.g. "2026 Q3" or
+// "FY2027 Q1".
+func quarterLabel(t time.Time, cal dates.Calendar) string {
+	return cal.QuarterLabel(cal.QuarterOf(t))
+}
+
+// upcomingMilestones returns milestones dated within days of today, soonest first.
+func upcomingMilestones(milestones []api.Milestone, today time.Time, days int) []api.Milestone {
+	horizon := today.AddDate(0, 0, days)
+	var out []api.Milestone
+	for _, m := range milestones {
+		d, ok := api.ParseDate(m.Date)
+		if ok && !d.Before(today) && !d.After(horizon) {
+			out = append(out, m)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Date < out[j].Date })
+	return out
+}
+
+// recentComments returns the newest limit comments, newest first.
+func recentComments(comments []api.Comment, limit int) []api.Comment {
+	sorted := append([]api.Comment(nil), comments...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].CreatedAt > sorted[j].CreatedAt })
+	if len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+	return sorted
+}
+
+// Markdown renders the full report.
+func (r *Report) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s: roadmap status\n\n", mdEscape(r.Roadmap.Name))
+	grouping := "now / next / later"
+	if r.Options.GroupBy == GroupByQuarter {
+		grouping = "quarter"
+		if r.Options.Calendar.Fiscal() {
+			grouping = "fiscal quarter"
+		}
+	}
+	fmt.Fprintf(&b, "_%s · grouped by %s_\n", r.Date.Format(time.DateOnly), grouping)
+
+	if len(r.Lanes) == 0 {
+		b.WriteString("\nNo scheduled bars.\n")
+	}
+	for _, lane := range r.Lanes {
+		fmt.Fprintf(&b, "\n## %s\n", mdEscape(lane.Name))
+		for _, g := range lane.Groups {
+			fmt.Fprintf(&b, "\n**%s**\n\n", g.Name)
+			for _, bar := range g.Bars {
+				b.WriteString("- " + barLine(bar) + "\n")
+			}
+		}
+	}
+
+	if r.Options.MilestoneDays > 0 {
+		fmt.Fprintf(&b, "\n## Upcoming milestones (next %d days)\n\n", r.Options.MilestoneDays)
+		if len(r.Milestones) == 0 {
+			b.WriteString("None.\n")
+		}
+		for _, m := range r.Milestones {
+			d, _ := api.ParseDate(m.Date)
+			fmt.Fprintf(&b, "- **%s** %s (%s)\n", d.Format(time.DateOnly), mdEscape(m.Label()), inDays(int(d.Sub(r.Date).Hours()/24)))
+		}
+	}
+
+	if r.Options.CommentLimit > 0 {
+		b.WriteString("\n## Recent comments\n\n")
+		if len(r.Comments) == 0 {
+			b.WriteString("None.\n")
+		}
+		for _, c := range r.Comments {
+			b.WriteString("- " + commentLine(c) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// Compact renders a short summary in Slack mrkdwn: one line per group
+// across all lanes, then upcoming milestones. Comments are left out.
+func (r *Report) Compact() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%s* roadmap status (%s)\n", slackEscape(r.Roadmap.Name), r.Date.Format(time.DateOnly))
+
+	merged := make(map[string][]api.Bar)
+	var order []string
+	for _, lane := range r.Lanes {
+		for _, g := range lane.Groups {
+			if _, ok := merged[g.Name]; !ok {
+				order = append(order, g.Name)
+			}
+			merged[g.Name] = append(merged[g.Name], g.Bars...)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool { return groupRank(order[i]) < groupRank(order[j]) })
+
+	for _, name := range order {
+		bars := merged[name]
+		items := make([]string, 0, compactMaxItems+1)
+		for i, bar := range bars {
+			if i == compactMaxItems {
+				items = append(items, fmt.Sprintf("+%d more", len(bars)-compactMaxItems))
+				break
+			}
+			item := slackEscape(bar.Name)
+			if bar.PercentDone != nil {
+				item += fmt.Sprintf(" %.0f%%", *bar.PercentDone)
+			}
+			items = append(items, item)
+		}
+		fmt.Fprintf(&b, "*%s:* %s\n", name, strings.Join(items, ", "))
+	}
+
+	if len(r.Milestones) > 0 {
+		items := make([]string, len(r.Milestones))
+		for i, m := range r.Milestones {
+			d, _ := api.ParseDate(m.Date)
+			items[i] = fmt.Sprintf("%s %s", slackEscape(m.Label()), d.Format("Jan 2"))
+		}
+		fmt.Fprintf(&b, "*Milestones:* %s\n", strings.Join(items, ", "))
+	}
+	return b.String()
+}
+
+// barLine renders one bar: name, dates and a percent-done bar.
+func barLine(bar api.Bar) string {
+	parts := []string{"**" + mdEscape(bar.Name) + "**"}
+	switch {
+	case bar.StartsOn != "" && bar.EndsOn != "":
+		parts = append(parts, bar.StartsOn+" → "+bar.EndsOn)
+	case bar.StartsOn != "":
+		parts = append(parts, "from "+bar.StartsOn)
+	case bar.EndsOn != "":
+		parts = append(parts, "until "+bar.EndsOn)
+	}
+	if bar.PercentDone != nil {
+		parts = append(parts, progressBar(*bar.PercentDone))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// progressBar renders a percentage as a fixed-width block bar.
+func progressBar(pct float64) string {
+	pct = min(max(pct, 0), 100)
+	filled := int(pct/100*progressWidth + 0.5)
+	return fmt.Sprintf("`%s%s` %.0f%%", strings.Repeat("█", filled), strings.Repeat("░", progressWidth-filled), pct)
+}
+
+// commentLine renders a comment excerpt on one line.
+func commentLine(c api.Comment) string {
+	body := strings.Join(strings.Fields(c.Body), " ")
+	if runes := []rune(body); len(runes) > commentMaxLen {
+		body = string(runes[:commentMaxLen]) + "…"
+	}
+	author := c.AuthorName
+	if author == "" {
+		author = "Someone"
+	}
+	line := "**" + mdEscape(author) + "**"
+	if d, ok := api.ParseDate(c.CreatedAt); ok {
+		line += ", " + d.Format(time.DateOnly)
+	}
+	return line + ": " + mdEscape(body)
+}
+
+// inDays describes a day offset relative to today.
+func inDays(n int) string {
+	switch n {
+	case 0:
+		return "today"
+	case 1:
+		return "tomorrow"
+	}
+	return fmt.Sprintf("in %d days", n)
+}
+
+// mdEscape escapes characters that would change inline Markdown formatting.
+func mdEscape(s string) string {
+	return mdEscaper.Replace(s)
+}
+
+var mdEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	">", `\>`,
+)
+
+// slackEscape escapes the three characters Slack mrkdwn reserves.
+func slackEscape(s string) string {
+	return slackEscaper.Replace(s)
+}
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
@@ -0,0 +1,189 @@
+package export
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+)
+
+var reportNow = time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC)
+
+func reportClient(t *testing.T) *api.Client {
+	return apitest.NewClient(t, map[string]string{
+		"/roadmaps/1":       `{"id": 1, "name": "Core"}`,
+		"/roadmaps/1/lanes": `[{"id": 3, "name": "Backend"}, {"id": 4, "name": "Empty"}, {"id": 5, "name": "Mobile"}]`,
+		"/roadmaps/1/bars": `[
+			{"id": 10, "name": "Search", "lane_id": 3, "starts_on": "2026-09-01", "ends_on": "2026-12-31", "percent_done": 40},
+			{"id": 11, "name": "Billing", "lane_id": 3, "starts_on": "2026-12-01", "ends_on": "2027-01-31"},
+			{"id": 12, "name": "Refunds", "lane_id": 3, "starts_on": "2027-06-01"},
+			{"id": 13, "name": "Old", "lane_id": 3, "starts_on": "2026-01-01", "ends_on": "2026-03-01"},
+			{"id": 14, "name": "Shipped", "lane_id": 3, "starts_on": "2026-08-01", "ends_on": "2026-10-10", "percent_done": 100},
+			{"id": 15, "name": "Parked", "lane_id": 3, "parked": true},
+			{"id": 16, "name": "Idea_*x*", "lane_id": 5},
+			{"id": 17, "name": "Stray", "lane_id": 99, "starts_on": "2026-10-01"}
+		]`,
+		"/roadmaps/1/milestones": `[
+			{"id": 1, "title": "GA", "date": "2026-11-10"},
+			{"id": 2, "title": "Beta", "date": "2026-10-19"},
+			{"id": 3, "title": "Past", "date": "2026-10-01"},
+			{"id": 4, "title": "Far", "date": "2027-03-01"}
+		]`,
+		"/roadmaps/1/comments": `[
+			{"id": 1, "body": "first", "author": {"first_name": "Ana", "last_name": "Li"}, "created_at": "2026-10-01T10:00:00Z"},
+			{"id": 2, "text": "latest\nnews", "author_name": "Bo", "created_at": "2026-10-17T10:00:00Z"}
+		]`,
+	})
+}
+
+func TestBuildReportHorizon(t *testing.T) {
+	opts := DefaultReportOptions()
+	opts.Now = reportNow
+	r, err := BuildReport(context.Background(), reportClient(t), "1", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(r.Lanes) != 3 {
+		t.Fatalf("expected Backend, Mobile and No lane, got %+v", r.Lanes)
+	}
+	got := map[string][]string{}
+	for _, g := range r.Lanes[0].Groups {
+		for _, b := range g.Bars {
+			got[g.Name] = append(got[g.Name], b.Name)
+		}
+	}
+	want := map[string]string{
+		"Now":           "Search",
+		"Next":          "Billing",
+		"Later":         "Refunds",
+		"Recently done": "Shipped",
+	}
+	for group, names := range want {
+		if strings.Join(got[group], ",") != names {
+			t.Errorf("%s: expected %s, got %v", group, names, got[group])
+		}
+	}
+	if r.Lanes[0].Groups[0].Name != "Now" || r.Lanes[1].Groups[0].Name != "Unscheduled" || r.Lanes[2].Name != "No lane" {
+		t.Errorf("unexpected group order %+v", r.Lanes)
+	}
+
+	if len(r.Milestones) != 2 || r.Milestones[0].Label() != "Beta" {
+		t.Errorf("expected Beta then GA, got %+v", r.Milestones)
+	}
+	if len(r.Comments) != 2 || r.Comments[0].AuthorName != "Bo" || r.Comments[1].AuthorName != "Ana Li" {
+		t.Errorf("expected newest comment first, got %+v", r.Comments)
+	}
+}
+
+func TestBuildReportQuarter(t *testing.T) {
+	r, err := BuildReport(context.Background(), reportClient(t), "1", ReportOptions{GroupBy: GroupByQuarter, Now: reportNow})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, g := range r.Lanes[0].Groups {
+		names = append(names, g.Name)
+	}
+	if strings.Join(names, ",") != "2026 Q1,2026 Q3,2026 Q4,2027 Q2" {
+		t.Errorf("unexpected quarters %v", names)
+	}
+	if r.Milestones != nil || r.Comments != nil {
+		t.Error("zero MilestoneDays and CommentLimit should omit those sections")
+	}
+}
+
+func TestBuildReportFiscalQuarter(t *testing.T) {
+	opts := ReportOptions{GroupBy: GroupByQuarter, Now: reportNow, Calendar: dates.Calendar{FiscalYearStart: time.February}}
+	r, err := BuildReport(context.Background(), reportClient(t), "1", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, g := range r.Lanes[0].Groups {
+		names = append(names, g.Name)
+	}
+	if strings.Join(names, ",") != "FY2026 Q4,FY2027 Q3,FY2027 Q4,FY2028 Q2" {
+		t.Errorf("unexpected fiscal quarters %v", names)
+	}
+	if md := r.Markdown(); !strings.Contains(md, "grouped by fiscal quarter") {
+		t.Errorf("expected the fiscal grouping in the header:\n%s", md)
+	}
+}
+
+func TestBuildReportUnknownGrouping(t *testing.T) {
+	if _, err := BuildReport(context.Background(), nil, "1", ReportOptions{GroupBy: "month"}); err == nil {
+		t.Error("expected unknown grouping error")
+	}
+}
+
+func TestReportMarkdown(t *testing.T) {
+	opts := DefaultReportOptions()
+	opts.Now = reportNow
+	r, err := BuildReport(context.Background(), reportClient(t), "1", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	md := r.Markdown()
+
+	for _, want := range []string{
+		"# Core: roadmap status\n",
+		"_2026-10-18 · grouped by now / next / later_",
+		"## Backend\n\n**Now**\n\n- **Search** · 2026-09-01 → 2026-12-31 · `████░░░░░░` 40%\n",
+		"- **Refunds** · from 2027-06-01",
+		`**Idea\_\*x\***`,
+		"## Upcoming milestones (next 30 days)\n\n- **2026-10-19** Beta (tomorrow)\n- **2026-11-10** GA (in 23 days)\n",
+		"- **Bo**, 2026-10-17: latest news\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected %q in:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "Parked") || strings.Contains(md, "Old") {
+		t.Errorf("parked and long-finished bars should be left out:\n%s", md)
+	}
+}
+
+func TestReportCompact(t *testing.T) {
+	opts := DefaultReportOptions()
+	opts.Now = reportNow
+	r, err := BuildReport(context.Background(), reportClient(t), "1", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "*Core* roadmap status (2026-10-18)\n" +
+		"*Now:* Search 40%, Stray\n" +
+		"*Next:* Billing\n" +
+		"*Later:* Refunds\n" +
+		"*Recently done:* Shipped 100%\n" +
+		"*Unscheduled:* Idea_*x*\n" +
+		"*Milestones:* Beta Oct 19, GA Nov 10\n"
+	if got := r.Compact(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	tests := map[float64]string{
+		0:   "`░░░░░░░░░░` 0%",
+		55:  "`██████░░░░` 55%",
+		100: "`██████████` 100%",
+		140: "`██████████` 100%",
+	}
+	for in, want := range tests {
+		if got := progressBar(in); got != want {
+			t.Errorf("progressBar(%v) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSlackEscape(t *testing.T) {
+	if got := slackEscape("R&D <beta>"); got != "R&amp;D &lt;beta&gt;" {
+		t.Errorf("unexpected escape %q", got)
+	}
+}
@@ -0,0 +1,190 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+	"github.com/olgasafonova/productplan-mcp-server/pkg/productplan"
+)
+
+// customFieldPrefix namespaces custom field columns so they cannot collide
+// with the built-in bar columns.
+const customFieldPrefix = "custom:"
+
+// BarRow is a bar joined with its lane name and legend label.
+type BarRow struct {
+	Bar         api.Bar
+	LaneName    string
+	LegendLabel string
+}
+
+// BarRows fetches a roadmap's bars and joins them with lanes and legends.
+func BarRows(ctx context.Context, client *api.Client, roadmapID string) ([]BarRow, error) {
+	roadmap, err := client.FetchRoadmap(ctx, roadmapID)
+	if err != nil {
+		return nil, fmt.Errorf("roadmap %s: %w", roadmapID, err)
+	}
+	bars, err := client.FetchRoadmapBars(ctx, roadmapID)
+	if err != nil {
+		return nil, fmt.Errorf("roadmap %s bars: %w", roadmapID, err)
+	}
+	lanes, err := client.FetchRoadmapLanes(ctx, roadmapID)
+	if err != nil {
+		return nil, fmt.Errorf("roadmap %s lanes: %w", roadmapID, err)
+	}
+
+	laneNames := make(map[api.ID]string, len(lanes))
+	for _, l := range lanes {
+		laneNames[l.ID] = l.Name
+	}
+	legendLabels := make(map[api.ID]string, len(roadmap.Legends))
+	for _, l := range roadmap.Legends {
+		legendLabels[l.ID] = l.Label
+	}
+
+	rows := make([]BarRow, len(bars))
+	for i, b := range bars {
+		rows[i] = BarRow{Bar: b, LaneName: laneNames[b.LaneID], LegendLabel: legendLabels[b.LegendID]}
+	}
+	return rows, nil
+}
+
+// CustomFieldNames returns the sorted set of custom field names across rows.
+func CustomFieldNames(rows []BarRow) []string {
+	seen := make(map[string]bool)
+	for _, r := range rows {
+		for name := range r.Bar.CustomFields() {
+			seen[name] = true
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BarColumns returns the bar columns. The built-in columns come first in a
+// fixed order; one "custom:<name>" column per custom field follows,
+// sorted by name. The quarter column, which places bars on cal, comes
+// last among the built-in columns so that adding it left the others where
+// they were.
+func BarColumns(customFields []string, cal dates.Calendar) []Column[BarRow] {
+	cols := []Column[BarRow]{
+		{"id", func(r BarRow) string { return r.Bar.ID.String() }},
+		{"name", func(r BarRow) string { return r.Bar.Name }},
+		{"lane", func(r BarRow) string { return r.LaneName }},
+		{"legend", func(r BarRow) string { return r.LegendLabel }},
+		{"starts_on", func(r BarRow) string { return r.Bar.StartsOn }},
+		{"ends_on", func(r BarRow) string { return r.Bar.EndsOn }},
+		{"effort", func(r BarRow) string { return formatNumber(r.Bar.Effort) }},
+		{"percent_done", func(r BarRow) string { return formatNumber(r.Bar.PercentDone) }},
+		{"tags", func(r BarRow) string { return joinList(r.Bar.Tags) }},
+		{"parent_id", func(r BarRow) string { return r.Bar.ParentID.String() }},
+		{"container", func(r BarRow) string { return strconv.FormatBool(r.Bar.Container) }},
+		{"parked", func(r BarRow) string { return strconv.FormatBool(r.Bar.Parked) }},
+		{"strategic_value", func(r BarRow) string { return r.Bar.StrategicValue }},
+		{"description", func(r BarRow) string { return r.Bar.Description }},
+		{"quarter", func(r BarRow) string { return barQuarter(r.Bar, cal) }},
+	}
+	for _, name := range customFields {
+		cols = append(cols, Column[BarRow]{
+			Name:  customFieldPrefix + name,
+			Value: func(r BarRow) string { return r.Bar.CustomFields()[name] },
+		})
+	}
+	return cols
+}
+
+// barQuarter returns the quarter a bar starts in, or ends in when it has
+// no start date.
+func barQuarter(b api.Bar, cal dates.Calendar) string {
+	d, ok := api.ParseDate(b.StartsOn)
+	if !ok {
+		if d, ok = api.ParseDate(b.EndsOn); !ok {
+			return ""
+		}
+	}
+	return quarterLabel(d, cal)
+}
+
+// IdeaColumns returns the idea columns in their fixed order.
+func IdeaColumns() []Column[api.Idea] {
+	return []Column[api.Idea]{
+		{"id", func(i api.Idea) string { return i.ID.String() }},
+		{"name", func(i api.Idea) string { return i.Name }},
+		{"status", func(i api.Idea) string { return i.Status }},
+		{"channel", func(i api.Idea) string { return i.Channel }},
+		{"customers", func(i api.Idea) string { return joinList(i.Customers) }},
+		{"customer_count", func(i api.Idea) string { return strconv.Itoa(len(i.Customers)) }},
+		{"tags", func(i api.Idea) string { return joinList(i.Tags) }},
+		{"opportunities_count", func(i api.Idea) string { return strconv.Itoa(i.OpportunitiesCount) }},
+		{"created_at", func(i api.Idea) string { return i.CreatedAt }},
+		{"updated_at", func(i api.Idea) string { return i.UpdatedAt }},
+		{"description", func(i api.Idea) string { return i.Description }},
+	}
+}
+
+// ObjectiveRow is one key result with its parent objective. Objectives
+// without key results produce a single row with an empty KeyResult.
+type ObjectiveRow struct {
+	Objective api.Objective
+	KeyResult api.KeyResult
+}
+
+// ObjectiveRows fetches every objective and its key results, flattened to
+// one row per key result. Key results are fetched in parallel.
+func ObjectiveRows(ctx context.Context, client *api.Client) ([]ObjectiveRow, error) {
+	objectives, err := client.FetchObjectives(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("objectives: %w", err)
+	}
+
+	fns := make([]func(ctx context.Context) ([]ObjectiveRow, error), len(objectives))
+	for i, o := range objectives {
+		fns[i] = func(ctx context.Context) ([]ObjectiveRow, error) {
+			krs, err := client.FetchKeyResults(ctx, o.ID.String())
+			if err != nil {
+				return nil, fmt.Errorf("objective %s key results: %w", o.ID, err)
+			}
+			if len(krs) == 0 {
+				return []ObjectiveRow{{Objective: o}}, nil
+			}
+			rows := make([]ObjectiveRow, len(krs))
+			for j, kr := range krs {
+				rows[j] = ObjectiveRow{Objective: o, KeyResult: kr}
+			}
+			return rows, nil
+		}
+	}
+	result := productplan.Execute(ctx, productplan.DefaultBatchConfig(), fns)
+	if result.HasErrors() {
+		return nil, result.Errors[0].Err
+	}
+
+	var rows []ObjectiveRow
+	for _, r := range result.Results {
+		rows = append(rows, r...)
+	}
+	return rows, nil
+}
+
+// ObjectiveColumns returns the objective/key result columns in their fixed order.
+func ObjectiveColumns() []Column[ObjectiveRow] {
+	return []Column[ObjectiveRow]{
+		{"objective_id", func(r ObjectiveRow) string { return r.Objective.ID.String() }},
+		{"objective", func(r ObjectiveRow) string { return r.Objective.Name }},
+		{"status", func(r ObjectiveRow) string { return r.Objective.Status }},
+		{"time_frame", func(r ObjectiveRow) string { return r.Objective.TimeFrame }},
+		{"key_result_id", func(r ObjectiveRow) string { return r.KeyResult.ID.String() }},
+		{"key_result", func(r ObjectiveRow) string { return r.KeyResult.Name }},
+		{"starting_value", func(r ObjectiveRow) string { return string(r.KeyResult.StartValue) }},
+		{"current_value", func(r ObjectiveRow) string { return string(r.KeyResult.CurrentValue) }},
+		{"target_value", func(r ObjectiveRow) string { return string(r.KeyResult.TargetValue) }},
+	}
+}
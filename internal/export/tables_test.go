@@ -0,0 +1,104 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+)
+
+func TestBarRowsCSV(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/roadmaps/1":       `{"id": 1, "name": "Core", "legends": [{"id": 9, "label": "Growth", "color": "#00FF00"}]}`,
+		"/roadmaps/1/lanes": `[{"id": 3, "name": "Backend"}]`,
+		"/roadmaps/1/bars": `[{
+			"id": 10, "name": "Search", "lane_id": 3, "legend_id": 9,
+			"start_date": "2026-01-01", "ends_on": "2026-03-31",
+			"effort": 5, "percent_done": 40,
+			"tags": ["api", {"name": "q1"}],
+			"custom_text_fields": [{"name": "Owner", "value": "Ana"}],
+			"custom_dropdown_fields": [{"name": "Tier", "value": 2}]
+		}]`,
+	})
+
+	rows, err := BarRows(context.Background(), client, "1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fields := CustomFieldNames(rows)
+	if strings.Join(fields, ",") != "Owner,Tier" {
+		t.Errorf("unexpected custom fields %v", fields)
+	}
+
+	cols, err := SelectColumns(BarColumns(fields, dates.Calendar{}), []string{"name", "lane", "legend", "starts_on", "ends_on", "effort", "percent_done", "tags", "custom:Owner", "custom:Tier"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, cols, rows, CSVOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "name,lane,legend,starts_on,ends_on,effort,percent_done,tags,custom:Owner,custom:Tier\n" +
+		"Search,Backend,Growth,2026-01-01,2026-03-31,5,40,api; q1,Ana,2\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	all := BarColumns(fields, dates.Calendar{FiscalYearStart: time.February})
+	var names []string
+	for _, c := range all {
+		names = append(names, c.Name)
+	}
+	if got := strings.Join(names, ","); got != "id,name,lane,legend,starts_on,ends_on,effort,percent_done,tags,parent_id,container,parked,strategic_value,description,quarter,custom:Owner,custom:Tier" {
+		t.Errorf("unexpected column order %s", got)
+	}
+	if q := all[14].Value(rows[0]); q != "FY2026 Q4" {
+		t.Errorf("quarter = %q", q)
+	}
+}
+
+func TestObjectiveRows(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/strategy/objectives":               `[{"id": 1, "name": "Grow"}, {"id": 2, "name": "Retain"}]`,
+		"/strategy/objectives/1/key_results": `[{"id": 11, "name": "MRR", "current_value": 50, "target_value": "100"}, {"id": 12, "name": "Logos"}]`,
+		"/strategy/objectives/2/key_results": `[]`,
+	})
+
+	rows, err := ObjectiveRows(context.Background(), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows (2 key results + 1 objective without), got %d", len(rows))
+	}
+	if rows[0].KeyResult.CurrentValue != "50" || rows[0].KeyResult.TargetValue != "100" {
+		t.Errorf("unexpected key result values %+v", rows[0].KeyResult)
+	}
+	if rows[2].Objective.Name != "Retain" || rows[2].KeyResult.ID != "" {
+		t.Errorf("expected empty key result row for Retain, got %+v", rows[2])
+	}
+}
+
+func TestIdeaColumns(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/discovery/ideas": `{"results": [{"id": 1, "name": "Dark mode", "customers": [{"name": "Acme"}, {"name": "Globex"}], "tags": ["ui"], "opportunities_count": 2}]}`,
+	})
+	ideas, err := client.FetchIdeas(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cols, _ := SelectColumns(IdeaColumns(), []string{"name", "customers", "customer_count", "tags", "opportunities_count"})
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, cols, ideas, CSVOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Dark mode,Acme; Globex,2,ui,2") {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
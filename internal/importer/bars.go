@@ -0,0 +1,216 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/barpayload"
+	"github.com/olgasafonova/productplan-mcp-server/pkg/productplan"
+)
+
+// BarPlan is a validated import: every row resolved against the roadmap's
+// lanes and legends, plus the lanes that must be created first.
+type BarPlan struct {
+	RoadmapID string
+	Rows      []BarRow
+	// NewLanes lists lane names referenced by rows that do not exist yet,
+	// in order of first appearance.
+	NewLanes []string
+}
+
+// ErrorCount returns the number of rows that cannot be imported.
+func (p *BarPlan) ErrorCount() int {
+	n := 0
+	for _, r := range p.Rows {
+		if r.Err != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// PlanBars resolves lane names and legend labels against the roadmap and
+// validates every row without writing anything. Rows that fail validation
+// keep their error in BarRow.Err.
+func PlanBars(ctx context.Context, client *api.Client, roadmapID string, rows []BarRow) (*BarPlan, error) {
+	if err := productplan.RequireRoadmapID(roadmapID); err != nil {
+		return nil, err
+	}
+	roadmap, err := client.FetchRoadmap(ctx, roadmapID)
+	if err != nil {
+		return nil, fmt.Errorf("roadmap %s: %w", roadmapID, err)
+	}
+	lanes, err := client.FetchRoadmapLanes(ctx, roadmapID)
+	if err != nil {
+		return nil, fmt.Errorf("roadmap %s lanes: %w", roadmapID, err)
+	}
+
+	laneIDs := make(map[string]string, len(lanes))
+	for _, l := range lanes {
+		laneIDs[strings.ToLower(l.Name)] = l.ID.String()
+	}
+	legendIDs := make(map[string]string, len(roadmap.Legends))
+	legendLabels := make([]string, 0, len(roadmap.Legends))
+	for _, l := range roadmap.Legends {
+		legendIDs[strings.ToLower(l.Label)] = l.ID.String()
+		legendLabels = append(legendLabels, l.Label)
+	}
+	sort.Strings(legendLabels)
+
+	plan := &BarPlan{RoadmapID: roadmapID, Rows: make([]BarRow, len(rows))}
+	newLanes := make(map[string]bool)
+	for i, row := range rows {
+		row.Args.RoadmapID = roadmapID
+		if row.Err == nil {
+			row.Err = validateRow(&row)
+		}
+		if row.Err == nil && row.Legend != "" && row.Args.LegendID == "" {
+			id, ok := legendIDs[strings.ToLower(row.Legend)]
+			if !ok {
+				row.Err = fmt.Errorf("legend %q not found on roadmap (available: %s)", row.Legend, strings.Join(legendLabels, ", "))
+			}
+			row.Args.LegendID = id
+		}
+		// Lanes are only created for rows that will be imported.
+		if row.Err == nil && row.Args.LaneID == "" {
+			if id, ok := laneIDs[strings.ToLower(row.Lane)]; ok {
+				row.Args.LaneID = id
+			} else if key := strings.ToLower(row.Lane); !newLanes[key] {
+				newLanes[key] = true
+				plan.NewLanes = append(plan.NewLanes, row.Lane)
+			}
+		}
+		plan.Rows[i] = row
+	}
+	return plan, nil
+}
+
+// validateRow checks the fields PlanBars cannot resolve from the roadmap.
+func validateRow(row *BarRow) error {
+	a := row.Args
+	if err := productplan.RequireNonEmpty("name", a.Name); err != nil {
+		return err
+	}
+	if a.LaneID == "" && row.Lane == "" {
+		return errors.New("lane or lane_id is required")
+	}
+	if err := validateDate("starts_on", a.StartsOn); err != nil {
+		return err
+	}
+	if err := validateDate("ends_on", a.EndsOn); err != nil {
+		return err
+	}
+	// YYYY-MM-DD strings order the same way the dates do.
+	if a.StartsOn != "" && a.EndsOn != "" && a.EndsOn < a.StartsOn {
+		return fmt.Errorf("ends_on %s is before starts_on %s", a.EndsOn, a.StartsOn)
+	}
+	if a.PercentDone != nil && (*a.PercentDone < 0 || *a.PercentDone > 100) {
+		return fmt.Errorf("percent_done %d must be between 0 and 100", *a.PercentDone)
+	}
+	return nil
+}
+
+// validateDate checks the YYYY-MM-DD format and that the date exists, so a
+// typo like 2026-02-30 is caught before any bar is created.
+func validateDate(field, value string) error {
+	if err := productplan.ValidateDate(field, value); err != nil {
+		return err
+	}
+	if value == "" {
+		return nil
+	}
+	if _, err := time.Parse(time.DateOnly, value); err != nil {
+		return productplan.NewValidationError(field, "is not a valid calendar date")
+	}
+	return nil
+}
+
+// BarResult reports the outcome of importing one row.
+type BarResult struct {
+	Line  int
+	Name  string
+	BarID api.ID
+	Err   error
+}
+
+// Apply creates any missing lanes, then creates every valid bar in a
+// batch. It returns one result per row in input order; rows that failed
+// planning are reported with their planning error and are not sent.
+func (p *BarPlan) Apply(ctx context.Context, client *api.Client) []BarResult {
+	laneIDs, laneErrs := p.createLanes(ctx, client)
+
+	results := make([]BarResult, len(p.Rows))
+	var fns []func(ctx context.Context) (BarResult, error)
+	var pending []int
+	for i, row := range p.Rows {
+		results[i] = BarResult{Line: row.Line, Name: row.Args.Name, Err: row.Err}
+		if row.Err != nil {
+			continue
+		}
+		args := row.Args
+		if args.LaneID == "" {
+			key := strings.ToLower(row.Lane)
+			if err := laneErrs[key]; err != nil {
+				results[i].Err = fmt.Errorf("lane %q: %w", row.Lane, err)
+				continue
+			}
+			args.LaneID = laneIDs[key]
+		}
+		result := results[i]
+		pending = append(pending, i)
+		// Failures are carried in the result rather than returned so that
+		// batch results stay aligned with rows.
+		fns = append(fns, func(ctx context.Context) (BarResult, error) {
+			data, err := client.CreateBar(ctx, barpayload.Create(args))
+			result.Err = err
+			result.BarID = api.CreatedID(data)
+			return result, nil
+		})
+	}
+
+	batch := productplan.Execute(ctx, productplan.DefaultBatchConfig(), fns)
+	// Only cancellation surfaces as a batch error; Results holds the rest in
+	// order, skipping failed indices.
+	failed := make(map[int]error, len(batch.Errors))
+	for _, e := range batch.Errors {
+		failed[e.Index] = e.Err
+	}
+	next := 0
+	for j, i := range pending {
+		switch err, ok := failed[j]; {
+		case ok:
+			results[i].Err = err
+		case next < len(batch.Results):
+			results[i] = batch.Results[next]
+			next++
+		default:
+			results[i].Err = errors.New("not attempted: batch stopped early")
+		}
+	}
+	return results
+}
+
+// createLanes creates the plan's new lanes one at a time, keyed by
+// lower-cased name.
+func (p *BarPlan) createLanes(ctx context.Context, client *api.Client) (map[string]string, map[string]error) {
+	ids := make(map[string]string, len(p.NewLanes))
+	errs := make(map[string]error)
+	for _, name := range p.NewLanes {
+		key := strings.ToLower(name)
+		data, err := client.CreateLane(ctx, p.RoadmapID, map[string]any{"name": name})
+		if err == nil && api.CreatedID(data) == "" {
+			err = errors.New("create response did not include an id")
+		}
+		if err != nil {
+			errs[key] = err
+			continue
+		}
+		ids[key] = api.CreatedID(data).String()
+	}
+	return ids, errs
+}
@@ -0,0 +1,162 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+)
+
+// fakeRoadmap serves roadmap 1 with one lane and one legend, and records
+// create requests.
+type fakeRoadmap struct {
+	mu      sync.Mutex
+	lanes   []map[string]any
+	bars    []map[string]any
+	failBar string
+}
+
+func (f *fakeRoadmap) client(t *testing.T) *api.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		key := r.Method + " " + r.URL.Path
+		switch key {
+		case "GET /roadmaps/1":
+			_, _ = io.WriteString(w, `{"id": 1, "name": "Core", "legends": [{"id": 9, "label": "Growth"}]}`)
+		case "GET /roadmaps/1/lanes":
+			_, _ = io.WriteString(w, `[{"id": 3, "name": "Backend"}]`)
+		case "POST /roadmaps/1/lanes", "POST /bars":
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if key == "POST /bars" {
+				if body["name"] == f.failBar {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = io.WriteString(w, `{"error": "invalid bar"}`)
+					return
+				}
+				f.bars = append(f.bars, body)
+				_, _ = fmt.Fprintf(w, `{"id": %d}`, 100+len(f.bars))
+				return
+			}
+			f.lanes = append(f.lanes, body)
+			_, _ = fmt.Fprintf(w, `{"id": %d}`, 10+len(f.lanes))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := api.New(api.Config{Token: "test-token", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func readRows(t *testing.T, input string) []BarRow {
+	t.Helper()
+	rows, err := ReadBars(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return rows
+}
+
+func TestPlanBars(t *testing.T) {
+	client := (&fakeRoadmap{}).client(t)
+	rows := readRows(t, "name,lane,legend,starts_on,ends_on,percent_done\n"+
+		"Search,backend,growth,2026-01-01,2026-02-01,\n"+
+		"Billing,Payments,,,,\n"+
+		"Refunds,payments,,,,\n"+
+		"Bad date,Backend,,2026-13-01,,\n"+
+		"Backwards,Backend,,2026-03-01,2026-02-01,\n"+
+		"Bad legend,Backend,Unknown,,,\n"+
+		",Backend,,,,\n"+
+		"No lane,,,,,\n"+
+		"Too done,Backend,,,,150\n")
+
+	plan, err := PlanBars(context.Background(), client, "1", rows)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first := plan.Rows[0]
+	if first.Err != nil || first.Args.LaneID != "3" || first.Args.LegendID != "9" || first.Args.RoadmapID != "1" {
+		t.Errorf("expected lane and legend resolved case-insensitively, got %+v", first)
+	}
+	if strings.Join(plan.NewLanes, ",") != "Payments" {
+		t.Errorf("expected one new lane, got %v", plan.NewLanes)
+	}
+
+	wantErrs := map[int]string{
+		5:  "starts_on",
+		6:  "before starts_on",
+		7:  `legend "Unknown" not found`,
+		8:  "name",
+		9:  "lane or lane_id",
+		10: "percent_done",
+	}
+	for _, r := range plan.Rows {
+		want, bad := wantErrs[r.Line]
+		switch {
+		case bad && (r.Err == nil || !strings.Contains(r.Err.Error(), want)):
+			t.Errorf("line %d: expected error containing %q, got %v", r.Line, want, r.Err)
+		case !bad && r.Err != nil:
+			t.Errorf("line %d: unexpected error %v", r.Line, r.Err)
+		}
+	}
+	if plan.ErrorCount() != len(wantErrs) {
+		t.Errorf("expected %d invalid rows, got %d", len(wantErrs), plan.ErrorCount())
+	}
+}
+
+func TestPlanBarsRejectsUnsafeRoadmapID(t *testing.T) {
+	if _, err := PlanBars(context.Background(), nil, "../1", nil); err == nil {
+		t.Error("expected invalid roadmap_id error")
+	}
+}
+
+func TestBarPlanApply(t *testing.T) {
+	fake := &fakeRoadmap{failBar: "Broken"}
+	client := fake.client(t)
+	rows := readRows(t, "name,lane,tags\nSearch,Backend,api\nBilling,Payments,\nRefunds,payments,\nBroken,Backend,\n")
+
+	plan, err := PlanBars(context.Background(), client, "1", rows)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	results := plan.Apply(context.Background(), client)
+
+	if len(fake.lanes) != 1 || fake.lanes[0]["name"] != "Payments" {
+		t.Errorf("expected Payments lane created once, got %v", fake.lanes)
+	}
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(results))
+	}
+	for i, name := range []string{"Search", "Billing", "Refunds"} {
+		if results[i].Name != name || results[i].Err != nil || results[i].BarID == "" {
+			t.Errorf("result %d: expected %s created, got %+v", i, name, results[i])
+		}
+	}
+	if results[3].Err == nil {
+		t.Error("expected Broken to fail")
+	}
+
+	lanesByBar := make(map[string]any)
+	for _, b := range fake.bars {
+		lanesByBar[b["name"].(string)] = b["lane_id"]
+	}
+	if lanesByBar["Search"] != "3" || lanesByBar["Billing"] != "11" || lanesByBar["Refunds"] != "11" {
+		t.Errorf("unexpected lane assignment %v", lanesByBar)
+	}
+}
@@ -0,0 +1,255 @@
+// Package importer loads roadmap data from spreadsheets into ProductPlan.
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/barpayload"
+)
+
+// Column prefixes for custom fields. Text fields use the same prefix as the
+// CSV export so an exported sheet can be edited and imported back.
+const (
+	customTextPrefix     = "custom:"
+	customDropdownPrefix = "dropdown:"
+)
+
+// BarRow is one CSV row mapped onto manage_bar arguments. Lane and Legend
+// hold the human-readable names from the sheet until PlanBars resolves them
+// to IDs.
+type BarRow struct {
+	// Line is the 1-based line number in the CSV, counting the header.
+	Line   int
+	Args   barpayload.Fields
+	Lane   string
+	Legend string
+	// Err is set when the row cannot be imported as written.
+	Err error
+}
+
+// barField assigns one cell to a row.
+type barField func(r *BarRow, value string) error
+
+// barFields maps CSV headers to barpayload.Fields. Headers match the
+// manage_bar argument names, plus "lane" and "legend" for names and labels.
+var barFields = map[string]barField{
+	"name":            func(r *BarRow, v string) error { r.Args.Name = v; return nil },
+	"lane":            func(r *BarRow, v string) error { r.Lane = v; return nil },
+	"lane_id":         func(r *BarRow, v string) error { r.Args.LaneID = v; return nil },
+	"legend":          func(r *BarRow, v string) error { r.Legend = v; return nil },
+	"legend_id":       func(r *BarRow, v string) error { r.Args.LegendID = v; return nil },
+	"starts_on":       func(r *BarRow, v string) error { r.Args.StartsOn = v; return nil },
+	"ends_on":         func(r *BarRow, v string) error { r.Args.EndsOn = v; return nil },
+	"description":     func(r *BarRow, v string) error { r.Args.Description = v; return nil },
+	"notes":           func(r *BarRow, v string) error { r.Args.Notes = v; return nil },
+	"strategic_value": func(r *BarRow, v string) error { r.Args.StrategicValue = v; return nil },
+	"parent_id":       func(r *BarRow, v string) error { r.Args.ParentID = v; return nil },
+	"percent_done":    intField("percent_done", func(r *BarRow, n *int) { r.Args.PercentDone = n }),
+	"effort":          intField("effort", func(r *BarRow, n *int) { r.Args.Effort = n }),
+	"container":       boolField("container", func(r *BarRow, b *bool) { r.Args.Container = b }),
+	"parked":          boolField("parked", func(r *BarRow, b *bool) { r.Args.Parked = b }),
+	"tags": func(r *BarRow, v string) error {
+		r.Args.Tags = splitList(v)
+		return nil
+	},
+}
+
+// headerAliases maps alternative spellings onto barFields keys.
+var headerAliases = map[string]string{
+	"start_date": "starts_on",
+	"end_date":   "ends_on",
+}
+
+// ignoredHeaders are export columns that have no meaning on create.
+// "quarter" is derived from the dates.
+var ignoredHeaders = map[string]bool{
+	"id":      true,
+	"quarter": true,
+}
+
+// ReadBars parses a CSV of bars. The header row is required; unknown
+// headers are an error so a misspelt column cannot be silently dropped.
+// Cell-level problems are recorded on the row instead of failing the read.
+func ReadBars(r io.Reader) ([]BarRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("CSV is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	setters, err := mapHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []BarRow
+	for line := 2; ; line++ {
+		record, readErr := cr.Read()
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("line %d: %w", line, readErr)
+		}
+		if isBlank(record) {
+			continue
+		}
+		row := BarRow{Line: line}
+		for i, cell := range record {
+			if i >= len(setters) || setters[i] == nil {
+				continue
+			}
+			if setErr := setters[i](&row, strings.TrimSpace(unguard(cell))); setErr != nil && row.Err == nil {
+				row.Err = setErr
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// mapHeader returns one setter per column; ignored columns get nil.
+func mapHeader(header []string) ([]barField, error) {
+	setters := make([]barField, len(header))
+	seen := make(map[string]bool, len(header))
+	hasName := false
+	for i, raw := range header {
+		h := strings.TrimSpace(raw)
+		key := strings.ToLower(h)
+		if alias, ok := headerAliases[key]; ok {
+			key = alias
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate column %q", h)
+		}
+		seen[key] = true
+
+		switch {
+		case ignoredHeaders[key]:
+			continue
+		case strings.HasPrefix(key, customTextPrefix):
+			setters[i] = customField(h[len(customTextPrefix):], false)
+		case strings.HasPrefix(key, customDropdownPrefix):
+			setters[i] = customField(h[len(customDropdownPrefix):], true)
+		default:
+			set, ok := barFields[key]
+			if !ok {
+				return nil, fmt.Errorf("unknown column %q (available: %s)", h, strings.Join(ColumnNames(), ", "))
+			}
+			setters[i] = set
+			hasName = hasName || key == "name"
+		}
+	}
+	if !hasName {
+		return nil, errors.New(`missing required column "name"`)
+	}
+	return setters, nil
+}
+
+// ColumnNames returns the accepted CSV headers in a stable order, with the
+// custom field prefixes shown as patterns.
+func ColumnNames() []string {
+	return []string{
+		"name", "lane", "lane_id", "legend", "legend_id", "starts_on", "ends_on",
+		"percent_done", "effort", "tags", "parent_id", "container", "parked",
+		"strategic_value", "notes", "description",
+		customTextPrefix + "<field>", customDropdownPrefix + "<field>",
+	}
+}
+
+// customField returns a setter that records a custom field value. Empty
+// cells are skipped so they do not blank the field.
+func customField(name string, dropdown bool) barField {
+	name = strings.TrimSpace(name)
+	return func(r *BarRow, v string) error {
+		if v == "" {
+			return nil
+		}
+		field := barpayload.CustomField{Name: name, Value: v}
+		if dropdown {
+			r.Args.CustomDropdownFields = append(r.Args.CustomDropdownFields, field)
+		} else {
+			r.Args.CustomTextFields = append(r.Args.CustomTextFields, field)
+		}
+		return nil
+	}
+}
+
+// intField returns a setter for an optional integer column. The export
+// writes numbers without trailing zeros, so a fraction such as 2.5 is a
+// value manage_bar cannot hold and is reported rather than rounded.
+func intField(name string, set func(*BarRow, *int)) barField {
+	return func(r *BarRow, v string) error {
+		if v == "" {
+			return nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%s: %q is not a whole number", name, v)
+		}
+		set(r, &n)
+		return nil
+	}
+}
+
+// boolField returns a setter for an optional boolean column. It accepts the
+// spellings spreadsheets commonly produce.
+func boolField(name string, set func(*BarRow, *bool)) barField {
+	return func(r *BarRow, v string) error {
+		var b bool
+		switch strings.ToLower(v) {
+		case "":
+			return nil
+		case "true", "yes", "y", "1":
+			b = true
+		case "false", "no", "n", "0":
+			b = false
+		default:
+			return fmt.Errorf("%s: %q is not true or false", name, v)
+		}
+		set(r, &b)
+		return nil
+	}
+}
+
+// splitList splits a multi-valued cell on semicolons or commas.
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(v, func(r rune) bool { return r == ';' || r == ',' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// unguard removes the leading ' that the CSV export puts before cells a
+// spreadsheet would otherwise run as a formula.
+func unguard(cell string) string {
+	if len(cell) > 1 && cell[0] == '\'' && strings.ContainsRune("=+-@\t\r", rune(cell[1])) {
+		return cell[1:]
+	}
+	return cell
+}
+
+// isBlank reports whether every cell in a record is empty.
+func isBlank(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
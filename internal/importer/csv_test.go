@@ -0,0 +1,126 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+	"github.com/olgasafonova/productplan-mcp-server/internal/export"
+)
+
+func TestReadBars(t *testing.T) {
+	input := "\ufeffid,Name,lane,legend,start_date,ends_on,percent_done,effort,tags,container,custom:Owner,dropdown:Tier\n" +
+		"1,Search,Backend,Growth,2026-01-01,2026-03-31,40,5,api; q1,yes,Ana,Gold\n" +
+		",,,,,,,,,,,\n" +
+		"2,Billing,Backend,,,,,,,,,\n"
+
+	rows, err := ReadBars(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows (blank line skipped), got %d", len(rows))
+	}
+
+	r := rows[0]
+	if r.Line != 2 || r.Args.Name != "Search" || r.Lane != "Backend" || r.Legend != "Growth" {
+		t.Errorf("unexpected row %+v", r)
+	}
+	if r.Args.StartsOn != "2026-01-01" || r.Args.EndsOn != "2026-03-31" {
+		t.Errorf("unexpected dates %q..%q", r.Args.StartsOn, r.Args.EndsOn)
+	}
+	if r.Args.PercentDone == nil || *r.Args.PercentDone != 40 || r.Args.Effort == nil || *r.Args.Effort != 5 {
+		t.Errorf("unexpected numbers %v %v", r.Args.PercentDone, r.Args.Effort)
+	}
+	if r.Args.Container == nil || !*r.Args.Container {
+		t.Error("expected container=true")
+	}
+	if strings.Join(r.Args.Tags, "|") != "api|q1" {
+		t.Errorf("unexpected tags %v", r.Args.Tags)
+	}
+	if len(r.Args.CustomTextFields) != 1 || r.Args.CustomTextFields[0].Name != "Owner" || r.Args.CustomTextFields[0].Value != "Ana" {
+		t.Errorf("unexpected custom text fields %v", r.Args.CustomTextFields)
+	}
+	if len(r.Args.CustomDropdownFields) != 1 || r.Args.CustomDropdownFields[0].Name != "Tier" {
+		t.Errorf("unexpected custom dropdown fields %v", r.Args.CustomDropdownFields)
+	}
+
+	if rows[1].Line != 4 || rows[1].Args.PercentDone != nil || rows[1].Args.CustomTextFields != nil {
+		t.Errorf("expected empty optional fields on line 4, got %+v", rows[1])
+	}
+}
+
+func TestReadBarsCellErrors(t *testing.T) {
+	input := "name,lane,percent_done,parked,effort\nA,L,half,,\nB,L,,maybe,\nC,L,,,2.5\n"
+
+	rows, err := ReadBars(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rows[0].Err == nil || !strings.Contains(rows[0].Err.Error(), "percent_done") {
+		t.Errorf("expected percent_done error, got %v", rows[0].Err)
+	}
+	if rows[1].Err == nil || !strings.Contains(rows[1].Err.Error(), "parked") {
+		t.Errorf("expected parked error, got %v", rows[1].Err)
+	}
+	if rows[2].Err == nil || !strings.Contains(rows[2].Err.Error(), `effort: "2.5" is not a whole number`) {
+		t.Errorf("expected fractional effort error, got %v", rows[2].Err)
+	}
+}
+
+func TestReadBarsHeaderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		errPart string
+	}{
+		{"empty", "", "empty"},
+		{"unknown column", "name,lane,colour\n", `unknown column "colour"`},
+		{"duplicate column", "name,starts_on,start_date\n", "duplicate column"},
+		{"missing name", "lane,starts_on\n", `"name"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadBars(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.errPart) {
+				t.Errorf("expected error containing %q, got %v", tt.errPart, err)
+			}
+		})
+	}
+}
+
+func TestReadBarsExported(t *testing.T) {
+	var bar api.Bar
+	if err := json.Unmarshal([]byte(`{
+		"id": 10, "name": "=Search", "starts_on": "2026-01-01", "ends_on": "2026-03-31",
+		"effort": 5, "percent_done": 40, "tags": ["api", "q1"], "parked": true,
+		"custom_text_fields": [{"name": "Owner", "value": "Ana"}]
+	}`), &bar); err != nil {
+		t.Fatal(err)
+	}
+	rows := []export.BarRow{{Bar: bar, LaneName: "Backend", LegendLabel: "Growth"}}
+
+	var buf bytes.Buffer
+	cols := export.BarColumns(export.CustomFieldNames(rows), dates.Calendar{})
+	if err := export.WriteCSV(&buf, cols, rows, export.CSVOptions{BOM: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := ReadBars(&buf)
+	if err != nil {
+		t.Fatalf("exported CSV does not import: %v", err)
+	}
+	if len(read) != 1 || read[0].Err != nil {
+		t.Fatalf("unexpected rows %+v", read)
+	}
+	a := read[0].Args
+	if a.Name != "=Search" || read[0].Lane != "Backend" || read[0].Legend != "Growth" ||
+		a.StartsOn != "2026-01-01" || a.EndsOn != "2026-03-31" ||
+		*a.Effort != 5 || *a.PercentDone != 40 || !*a.Parked || *a.Container ||
+		strings.Join(a.Tags, ",") != "api,q1" || a.CustomTextFields[0].Value != "Ana" {
+		t.Errorf("round trip lost data: %+v", read[0])
+	}
+}
@@ -0,0 +1,342 @@
+package launchtemplate
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+)
+
+// Task and section outcomes in a Result.
+const (
+	StatusPlanned = "planned"
+	StatusCreated = "created"
+	StatusExists  = "exists"
+)
+
+// PlannedTask is a template task resolved against a launch.
+type PlannedTask struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Due         string `json:"due,omitempty"`
+	DueDate     string `json:"due_date,omitempty"`
+	Role        string `json:"role,omitempty"`
+	// AssignedUserID is empty when the task has no role or the role is
+	// not mapped.
+	AssignedUserID string `json:"assigned_user_id,omitempty"`
+	Assignee       string `json:"assignee,omitempty"`
+	ID             string `json:"id,omitempty"`
+	Status         string `json:"status"`
+}
+
+// PlannedSection is a template section resolved against a launch.
+type PlannedSection struct {
+	Name   string        `json:"name"`
+	ID     string        `json:"id,omitempty"`
+	Status string        `json:"status"`
+	Tasks  []PlannedTask `json:"tasks"`
+}
+
+// Result describes what applying a template does or did.
+type Result struct {
+	Template   string           `json:"template"`
+	LaunchID   string           `json:"launch_id"`
+	LaunchDate string           `json:"launch_date,omitempty"`
+	DryRun     bool             `json:"dry_run"`
+	Sections   []PlannedSection `json:"sections"`
+	// SectionsCreated and TasksCreated count writes (or planned writes on a
+	// dry run); Skipped counts tasks already on the launch.
+	SectionsCreated int `json:"sections_created"`
+	TasksCreated    int `json:"tasks_created"`
+	Skipped         int `json:"skipped"`
+	// UnmappedRoles lists roles used by tasks with no user assigned.
+	UnmappedRoles []string `json:"unmapped_roles"`
+}
+
+// ApplyOptions tunes Apply.
+type ApplyOptions struct {
+	// Roles maps role names to a user ID, email or name, on top of the
+	// template's own Roles.
+	Roles map[string]string
+	// LaunchDate overrides the launch's date for due dates (YYYY-MM-DD).
+	LaunchDate string
+	// DryRun plans without writing.
+	DryRun bool
+}
+
+// Apply creates the template's sections and tasks on a launch. Sections
+// that already exist by name are reused and tasks already in them by name
+// are skipped, so applying twice adds nothing. Writes are sequential and
+// not atomic: on error the returned Result shows what was created.
+func Apply(ctx context.Context, client *api.Client, launchID string, t *Template, name string, opts ApplyOptions) (*Result, error) {
+	launch, err := client.FetchLaunch(ctx, launchID)
+	if err != nil {
+		return nil, fmt.Errorf("launch %s: %w", launchID, err)
+	}
+	sections, err := client.FetchLaunchSections(ctx, launchID)
+	if err != nil {
+		return nil, fmt.Errorf("launch %s sections: %w", launchID, err)
+	}
+	tasks, err := client.FetchLaunchTasks(ctx, launchID)
+	if err != nil {
+		return nil, fmt.Errorf("launch %s tasks: %w", launchID, err)
+	}
+	users, err := client.FetchUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("users: %w", err)
+	}
+	if opts.LaunchDate != "" {
+		launch.Date = opts.LaunchDate
+	}
+
+	res, err := Plan(t, launch, sections, tasks, users, opts.Roles)
+	if err != nil {
+		return nil, err
+	}
+	res.Template = name
+	res.DryRun = opts.DryRun
+	if opts.DryRun {
+		return res, nil
+	}
+	res.SectionsCreated, res.TasksCreated = 0, 0
+
+	for i := range res.Sections {
+		sec := &res.Sections[i]
+		if sec.ID == "" {
+			var data []byte
+			if data, err = client.CreateLaunchSection(ctx, launchID, map[string]any{"name": sec.Name}); err != nil {
+				return res, fmt.Errorf("create section %q: %w", sec.Name, err)
+			}
+			if sec.ID = api.CreatedID(data).String(); sec.ID == "" {
+				return res, fmt.Errorf("create section %q: response had no id", sec.Name)
+			}
+			sec.Status = StatusCreated
+			res.SectionsCreated++
+		}
+		for j := range sec.Tasks {
+			task := &sec.Tasks[j]
+			if task.Status == StatusExists {
+				continue
+			}
+			payload := map[string]any{"name": task.Name, "section_id": sec.ID}
+			setIf(payload, "description", task.Description)
+			setIf(payload, "due_date", task.DueDate)
+			setIf(payload, "assigned_user_id", task.AssignedUserID)
+			var data []byte
+			if data, err = client.CreateLaunchTask(ctx, launchID, payload); err != nil {
+				return res, fmt.Errorf("create task %q in %q: %w", task.Name, sec.Name, err)
+			}
+			task.ID = api.CreatedID(data).String()
+			task.Status = StatusCreated
+			res.TasksCreated++
+		}
+	}
+	return res, nil
+}
+
+func setIf(payload map[string]any, key, value string) {
+	if value != "" {
+		payload[key] = value
+	}
+}
+
+// Plan resolves a template against a launch without writing: due offsets
+// become dates from the launch date, roles become user IDs, and sections
+// and tasks already on the launch are matched by name.
+func Plan(t *Template, launch api.Launch, sections []api.LaunchSection, tasks []api.LaunchTask, users []api.User, roles map[string]string) (*Result, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	launchDate, hasDate := api.ParseDate(launch.Date)
+
+	assign := make(map[string]string, len(t.Roles)+len(roles))
+	for role, who := range t.Roles {
+		assign[foldKey(role)] = who
+	}
+	for role, who := range roles {
+		assign[foldKey(role)] = who
+	}
+	resolved := make(map[string]api.User, len(assign))
+	for role, who := range assign {
+		u, err := findUser(users, who)
+		if err != nil {
+			return nil, fmt.Errorf("role %q: %w", role, err)
+		}
+		resolved[role] = u
+	}
+
+	sectionIDs := make(map[string]string, len(sections))
+	for _, s := range sections {
+		sectionIDs[foldKey(s.Name)] = s.ID.String()
+	}
+	existing := make(map[[2]string]string, len(tasks))
+	for _, task := range tasks {
+		existing[[2]string{task.SectionID.String(), foldKey(task.Name)}] = task.ID.String()
+	}
+
+	res := &Result{LaunchID: launch.ID.String(), LaunchDate: launch.Date, Sections: []PlannedSection{}, UnmappedRoles: []string{}}
+	unmapped := map[string]bool{}
+	for _, s := range t.Sections {
+		sec := PlannedSection{Name: strings.TrimSpace(s.Name), Status: StatusPlanned, Tasks: []PlannedTask{}}
+		if id, ok := sectionIDs[foldKey(s.Name)]; ok {
+			sec.ID, sec.Status = id, StatusExists
+		} else {
+			res.SectionsCreated++
+		}
+		for _, task := range s.Tasks {
+			p := PlannedTask{Name: strings.TrimSpace(task.Name), Description: task.Description, Due: task.Due, Role: task.Role, Status: StatusPlanned}
+			if days, ok, _ := ParseOffset(task.Due); ok {
+				if !hasDate {
+					return nil, fmt.Errorf("launch %s has no date; set one or pass launch_date to resolve %s", launch.ID, task.Due)
+				}
+				p.DueDate = launchDate.AddDate(0, 0, days).Format(time.DateOnly)
+			}
+			if role := foldKey(task.Role); role != "" {
+				if u, ok := resolved[role]; ok {
+					p.AssignedUserID, p.Assignee = u.ID.String(), u.DisplayName()
+				} else if !unmapped[role] {
+					unmapped[role] = true
+					res.UnmappedRoles = append(res.UnmappedRoles, strings.TrimSpace(task.Role))
+				}
+			}
+			if id, ok := existing[[2]string{sec.ID, foldKey(task.Name)}]; ok && sec.ID != "" {
+				p.ID, p.Status = id, StatusExists
+				res.Skipped++
+			} else {
+				res.TasksCreated++
+			}
+			sec.Tasks = append(sec.Tasks, p)
+		}
+		res.Sections = append(res.Sections, sec)
+	}
+	sort.Strings(res.UnmappedRoles)
+	return res, nil
+}
+
+// findUser matches who against user IDs, then emails and display names,
+// case-insensitively.
+func findUser(users []api.User, who string) (api.User, error) {
+	who = strings.TrimSpace(who)
+	for _, u := range users {
+		if u.ID.String() == who {
+			return u, nil
+		}
+	}
+	for _, u := range users {
+		if strings.EqualFold(u.Email, who) || strings.EqualFold(u.DisplayName(), who) {
+			return u, nil
+		}
+	}
+	return api.User{}, fmt.Errorf("no user matches %q (use list_users for IDs)", who)
+}
+
+func foldKey(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// Extract fetches a launch's checklist and turns it into a template with
+// FromLaunch.
+func Extract(ctx context.Context, client *api.Client, launchID string) (*Template, error) {
+	launch, err := client.FetchLaunch(ctx, launchID)
+	if err != nil {
+		return nil, fmt.Errorf("launch %s: %w", launchID, err)
+	}
+	sections, err := client.FetchLaunchSections(ctx, launchID)
+	if err != nil {
+		return nil, fmt.Errorf("launch %s sections: %w", launchID, err)
+	}
+	tasks, err := client.FetchLaunchTasks(ctx, launchID)
+	if err != nil {
+		return nil, fmt.Errorf("launch %s tasks: %w", launchID, err)
+	}
+	users, err := client.FetchUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("users: %w", err)
+	}
+	return FromLaunch(launch, sections, tasks, users), nil
+}
+
+// FromLaunch extracts a launch's checklist into a template. Due dates
+// become offsets from the launch date (dropped when the launch has none),
+// and each assignee becomes a role named after the user, recorded in Roles
+// so the template reassigns the same people unless roles are remapped.
+// Assignees who share a name get their user ID added to the role.
+func FromLaunch(launch api.Launch, sections []api.LaunchSection, tasks []api.LaunchTask, users []api.User) *Template {
+	launchDate, hasDate := api.ParseDate(launch.Date)
+	byID := make(map[api.ID]api.User, len(users))
+	for _, u := range users {
+		byID[u.ID] = u
+	}
+	roles := assigneeRoles(tasks, byID)
+
+	t := &Template{Name: launch.Name, Roles: map[string]string{}, Sections: []Section{}}
+	sorted := append([]api.LaunchSection(nil), sections...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Position < sorted[j].Position })
+	index := make(map[api.ID]int, len(sorted))
+	for _, s := range sorted {
+		index[s.ID] = len(t.Sections)
+		t.Sections = append(t.Sections, Section{Name: s.Name, Tasks: []Task{}})
+	}
+
+	for _, task := range tasks {
+		i, ok := index[task.SectionID]
+		if !ok {
+			if _, ok = index[""]; !ok {
+				index[""] = len(t.Sections)
+				t.Sections = append(t.Sections, Section{Name: "General", Tasks: []Task{}})
+			}
+			i = index[""]
+		}
+		out := Task{Name: task.Name, Description: task.Description}
+		if due, ok := api.ParseDate(task.DueDate); ok && hasDate {
+			out.Due = FormatOffset(int(due.Sub(launchDate).Hours() / 24))
+		}
+		if task.AssignedUserID != "" {
+			out.Role = roles[task.AssignedUserID]
+			t.Roles[out.Role] = task.AssignedUserID.String()
+		}
+		t.Sections[i].Tasks = append(t.Sections[i].Tasks, out)
+	}
+	if len(t.Roles) == 0 {
+		t.Roles = nil
+	}
+	return t
+}
+
+var roleSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// assigneeRoles names a role for each task assignee. When assignees share
+// a name, each of them gets a role suffixed with their user ID, e.g.
+// "ana-lee-7", so no two people end up in the same role.
+func assigneeRoles(tasks []api.LaunchTask, byID map[api.ID]api.User) map[api.ID]string {
+	roles := make(map[api.ID]string)
+	holders := make(map[string]int)
+	for _, task := range tasks {
+		id := task.AssignedUserID
+		if _, seen := roles[id]; id == "" || seen {
+			continue
+		}
+		roles[id] = roleName(byID[id], id)
+		holders[roles[id]]++
+	}
+	for id, role := range roles {
+		if holders[role] > 1 {
+			roles[id] = role + "-" + strings.Trim(roleSeparators.ReplaceAllString(strings.ToLower(id.String()), "-"), "-")
+		}
+	}
+	return roles
+}
+
+// roleName derives a role from a user's name, e.g. "Ana Lee" -> "ana-lee",
+// falling back to "user-<id>".
+func roleName(u api.User, id api.ID) string {
+	name := strings.Trim(roleSeparators.ReplaceAllString(strings.ToLower(u.DisplayName()), "-"), "-")
+	if name == "" {
+		return "user-" + id.String()
+	}
+	return name
+}
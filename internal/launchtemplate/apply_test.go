@@ -0,0 +1,156 @@
+package launchtemplate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+)
+
+var testUsers = []api.User{
+	{ID: "7", FirstName: "Ana", LastName: "Lee", Email: "ana@example.com"},
+	{ID: "8", Name: "Bo Ek", Email: "bo@example.com"},
+}
+
+func TestPlan(t *testing.T) {
+	tmpl, err := Parse([]byte(standardYAML), FormatYAML)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	launch := api.Launch{ID: "1", Date: "2026-07-01"}
+	sections := []api.LaunchSection{{ID: "20", Name: "legal"}}
+	tasks := []api.LaunchTask{{ID: "200", Name: "Legal Review", SectionID: "20"}}
+
+	res, err := Plan(tmpl, launch, sections, tasks, testUsers, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	legal, marketing := res.Sections[0], res.Sections[1]
+	if legal.Status != StatusExists || legal.Tasks[0].Status != StatusExists || legal.Tasks[0].DueDate != "2026-06-17" || legal.Tasks[0].Assignee != "Ana Lee" {
+		t.Errorf("unexpected legal section %+v", legal)
+	}
+	if marketing.Status != StatusPlanned || marketing.Tasks[0].DueDate != "2026-07-01" || marketing.Tasks[1].DueDate != "2026-07-08" {
+		t.Errorf("unexpected marketing section %+v", marketing)
+	}
+	if res.SectionsCreated != 1 || res.TasksCreated != 2 || res.Skipped != 1 || strings.Join(res.UnmappedRoles, ",") != "pmm" {
+		t.Errorf("unexpected counts %+v", res)
+	}
+
+	// Passed roles override the template's and match by email.
+	if res, err = Plan(tmpl, launch, nil, nil, testUsers, map[string]string{"PMM": "bo@example.com", "legal": "Bo Ek"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Sections[0].Tasks[0].AssignedUserID != "8" || res.Sections[1].Tasks[0].AssignedUserID != "8" || len(res.UnmappedRoles) != 0 {
+		t.Errorf("unexpected assignments %+v", res.Sections)
+	}
+
+	if _, err = Plan(tmpl, launch, nil, nil, testUsers, map[string]string{"pmm": "nobody"}); err == nil {
+		t.Error("expected error for an unknown user")
+	}
+	if _, err = Plan(tmpl, api.Launch{ID: "1"}, nil, nil, testUsers, nil); err == nil || !strings.Contains(err.Error(), "has no date") {
+		t.Errorf("expected missing date error, got %v", err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	var posts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			posts = append(posts, r.URL.Path+" "+body["name"].(string))
+			_, _ = w.Write([]byte(`{"id": 50}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/launches/1":
+			_, _ = w.Write([]byte(`{"id": 1, "name": "v2", "date": "2026-07-01"}`))
+		case "/users":
+			_, _ = w.Write([]byte(`[{"id": 7, "first_name": "Ana", "last_name": "Lee"}]`))
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := api.New(api.Config{Token: "test-token", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	tmpl, err := Parse([]byte(standardYAML), FormatYAML)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	res, err := Apply(context.Background(), client, "1", tmpl, "standard", ApplyOptions{DryRun: true})
+	if err != nil || len(posts) != 0 || res.TasksCreated != 3 {
+		t.Fatalf("dry run: %+v, %v, posts %v", res, err, posts)
+	}
+
+	if res, err = Apply(context.Background(), client, "1", tmpl, "standard", ApplyOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "/launches/1/checklist_sections Legal, /launches/1/tasks Legal review, /launches/1/checklist_sections Marketing, /launches/1/tasks Announcement, /launches/1/tasks Retro"
+	if strings.Join(posts, ", ") != want {
+		t.Errorf("posts = %v", posts)
+	}
+	if res.SectionsCreated != 2 || res.TasksCreated != 3 || res.Sections[0].ID != "50" || res.Sections[0].Tasks[0].Status != StatusCreated {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
+
+func TestFromLaunch(t *testing.T) {
+	launch := api.Launch{ID: "1", Name: "v2", Date: "2026-07-01"}
+	sections := []api.LaunchSection{{ID: "21", Name: "Marketing", Position: 2}, {ID: "20", Name: "Legal", Position: 1}}
+	tasks := []api.LaunchTask{
+		{ID: "200", Name: "Legal review", SectionID: "20", DueDate: "2026-06-17", AssignedUserID: "7"},
+		{ID: "201", Name: "Blog", SectionID: "21", DueDate: "2026-07-02"},
+		{ID: "202", Name: "Loose end", SectionID: "99", AssignedUserID: "9"},
+	}
+
+	tmpl := FromLaunch(launch, sections, tasks, testUsers)
+	if err := tmpl.Validate(); err != nil {
+		t.Fatalf("extracted template is invalid: %v", err)
+	}
+	if len(tmpl.Sections) != 3 || tmpl.Sections[0].Name != "Legal" || tmpl.Sections[2].Name != "General" {
+		t.Fatalf("unexpected sections %+v", tmpl.Sections)
+	}
+	if task := tmpl.Sections[0].Tasks[0]; task.Due != "T-14d" || task.Role != "ana-lee" || tmpl.Roles["ana-lee"] != "7" {
+		t.Errorf("unexpected legal task %+v roles %v", task, tmpl.Roles)
+	}
+	if tmpl.Sections[1].Tasks[0].Due != "T+1d" || tmpl.Sections[2].Tasks[0].Role != "user-9" {
+		t.Errorf("unexpected tasks %+v", tmpl.Sections)
+	}
+}
+
+func TestFromLaunchSharedNames(t *testing.T) {
+	users := append([]api.User{{ID: "9", FirstName: "Ana", LastName: "Lee"}}, testUsers...)
+	tasks := []api.LaunchTask{
+		{ID: "200", Name: "Legal review", AssignedUserID: "7"},
+		{ID: "201", Name: "Press kit", AssignedUserID: "9"},
+		{ID: "202", Name: "Blog", AssignedUserID: "8"},
+	}
+
+	tmpl := FromLaunch(api.Launch{ID: "1", Name: "v2"}, nil, tasks, users)
+	got := tmpl.Sections[0].Tasks
+	if got[0].Role != "ana-lee-7" || got[1].Role != "ana-lee-9" || got[2].Role != "bo-ek" {
+		t.Errorf("unexpected roles %+v", got)
+	}
+	if len(tmpl.Roles) != 3 || tmpl.Roles["ana-lee-7"] != "7" || tmpl.Roles["ana-lee-9"] != "9" {
+		t.Errorf("unexpected role map %v", tmpl.Roles)
+	}
+
+	// Applied to a new launch, each task goes back to its own assignee.
+	res, err := Plan(tmpl, api.Launch{ID: "2", Date: "2026-09-01"}, nil, nil, users, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	planned := res.Sections[0].Tasks
+	if planned[0].AssignedUserID != "7" || planned[1].AssignedUserID != "9" || planned[2].AssignedUserID != "8" {
+		t.Errorf("unexpected assignments %+v", planned)
+	}
+}
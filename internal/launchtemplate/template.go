@@ -0,0 +1,312 @@
+// Package launchtemplate reads, writes and applies launch checklist
+// templates: named sections of tasks whose due dates are offsets from the
+// launch date (T-14d) and whose assignees are roles mapped to users when
+// the template is applied.
+package launchtemplate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvDir names the environment variable that overrides the template directory.
+const EnvDir = "PRODUCTPLAN_TEMPLATES_DIR"
+
+// Template formats, by file extension.
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+// Formats lists the supported file formats.
+var Formats = []string{FormatYAML, FormatJSON}
+
+// Template is a launch checklist.
+type Template struct {
+	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Roles are default role assignments, role name to user ID, email or
+	// name. Assignments passed when applying take precedence.
+	Roles    map[string]string `json:"roles,omitempty" yaml:"roles,omitempty"`
+	Sections []Section         `json:"sections" yaml:"sections"`
+}
+
+// Section is a named group of tasks.
+type Section struct {
+	Name  string `json:"name" yaml:"name"`
+	Tasks []Task `json:"tasks" yaml:"tasks"`
+}
+
+// Task is a checklist task.
+type Task struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Due is an offset from the launch date such as "T-14d", "T-2w", "T"
+	// or "T+3d". Empty means no due date.
+	Due  string `json:"due,omitempty" yaml:"due,omitempty"`
+	Role string `json:"role,omitempty" yaml:"role,omitempty"`
+}
+
+// Validate checks that every section and task is named and every due
+// offset parses.
+func (t *Template) Validate() error {
+	if len(t.Sections) == 0 {
+		return fmt.Errorf("template has no sections")
+	}
+	for i, s := range t.Sections {
+		if strings.TrimSpace(s.Name) == "" {
+			return fmt.Errorf("sections[%d]: name is required", i)
+		}
+		for j, task := range s.Tasks {
+			if strings.TrimSpace(task.Name) == "" {
+				return fmt.Errorf("section %q tasks[%d]: name is required", s.Name, j)
+			}
+			if _, _, err := ParseOffset(task.Due); err != nil {
+				return fmt.Errorf("section %q task %q: %w", s.Name, task.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+var offsetPattern = regexp.MustCompile(`^t(?:([+-])(\d{1,4})([dw]))?$`)
+
+// ParseOffset parses a due offset into days from the launch date: "T-14d"
+// is -14, "T-2w" is -14, "T" is 0 and "T+3d" is 3. ok is false for an
+// empty offset.
+func ParseOffset(s string) (days int, ok bool, err error) {
+	s = strings.ToLower(strings.Join(strings.Fields(s), ""))
+	if s == "" {
+		return 0, false, nil
+	}
+	m := offsetPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false, fmt.Errorf("due %q must look like T-14d, T-2w, T or T+3d", s)
+	}
+	if m[1] == "" {
+		return 0, true, nil
+	}
+	n, _ := strconv.Atoi(m[2])
+	if m[3] == "w" {
+		n *= 7
+	}
+	if m[1] == "-" {
+		n = -n
+	}
+	return n, true, nil
+}
+
+// FormatOffset renders days from the launch date as an offset.
+func FormatOffset(days int) string {
+	switch {
+	case days == 0:
+		return "T"
+	case days < 0:
+		return fmt.Sprintf("T-%dd", -days)
+	default:
+		return fmt.Sprintf("T+%dd", days)
+	}
+}
+
+// Parse decodes a template in the given format and validates it.
+func Parse(data []byte, format string) (*Template, error) {
+	var t Template
+	switch format {
+	case FormatJSON:
+		if err := json.Unmarshal(data, &t); err != nil {
+			return nil, fmt.Errorf("parse template: %w", err)
+		}
+	case FormatYAML:
+		if err := yaml.Unmarshal(data, &t); err != nil {
+			return nil, fmt.Errorf("parse template: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown template format %q", format)
+	}
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Marshal encodes a template in the given format.
+func Marshal(t *Template, format string) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(t, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case FormatYAML:
+		return yaml.Marshal(t)
+	default:
+		return nil, fmt.Errorf("unknown template format %q", format)
+	}
+}
+
+// Library is a directory of template files. Templates are addressed by
+// name, the file name without its extension, so callers cannot reach
+// files outside the directory.
+type Library struct {
+	dir string
+}
+
+// OpenLibrary returns the library in dir; the directory is created on
+// first save.
+func OpenLibrary(dir string) *Library {
+	return &Library{dir: dir}
+}
+
+// DefaultDir returns the template directory: $PRODUCTPLAN_TEMPLATES_DIR
+// when set, otherwise launch-templates in the user config directory.
+func DefaultDir() (string, error) {
+	if d := os.Getenv(EnvDir); d != "" {
+		return d, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "productplan-mcp", "launch-templates"), nil
+}
+
+// Dir returns the library directory.
+func (l *Library) Dir() string {
+	return l.dir
+}
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,99}$`)
+
+// extensions maps file extensions to formats, in lookup order.
+var extensions = []struct{ ext, format string }{
+	{".yaml", FormatYAML},
+	{".yml", FormatYAML},
+	{".json", FormatJSON},
+}
+
+// splitName strips a known extension from name and validates the rest.
+func splitName(name string) (base, format string, err error) {
+	base = strings.TrimSpace(name)
+	for _, e := range extensions {
+		if strings.HasSuffix(strings.ToLower(base), e.ext) {
+			base, format = base[:len(base)-len(e.ext)], e.format
+			break
+		}
+	}
+	if !namePattern.MatchString(base) || strings.Contains(base, "..") {
+		return "", "", fmt.Errorf("template name %q must be letters, digits, '.', '_' or '-'", name)
+	}
+	return base, format, nil
+}
+
+// Load reads the named template, trying .yaml, .yml and .json in turn
+// unless the name carries an extension. It returns the file path too.
+func (l *Library) Load(name string) (*Template, string, error) {
+	base, format, err := splitName(name)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, e := range extensions {
+		if format != "" && e.format != format {
+			continue
+		}
+		path := filepath.Join(l.dir, base+e.ext)
+		var data []byte
+		data, err = os.ReadFile(path) // #nosec G304 -- name is validated and joined to the configured template directory
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("read template: %w", err)
+		}
+		var t *Template
+		if t, err = Parse(data, e.format); err != nil {
+			return nil, "", fmt.Errorf("%s: %w", path, err)
+		}
+		return t, path, nil
+	}
+	names, _ := l.List()
+	if len(names) == 0 {
+		return nil, "", fmt.Errorf("template %q not found in %s", name, l.dir)
+	}
+	return nil, "", fmt.Errorf("template %q not found in %s (available: %s)", name, l.dir, strings.Join(names, ", "))
+}
+
+// Save writes t as the named template in format and returns the path.
+// An existing file is only replaced when overwrite is set.
+func (l *Library) Save(name, format string, t *Template, overwrite bool) (string, error) {
+	base, extFormat, err := splitName(name)
+	if err != nil {
+		return "", err
+	}
+	if format == "" {
+		format = extFormat
+	}
+	if format == "" {
+		format = FormatYAML
+	}
+	data, err := Marshal(t, format)
+	if err != nil {
+		return "", fmt.Errorf("encode template: %w", err)
+	}
+	if err = os.MkdirAll(l.dir, 0o700); err != nil {
+		return "", fmt.Errorf("create template directory: %w", err)
+	}
+	path := filepath.Join(l.dir, base+"."+format)
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !overwrite {
+		flags |= os.O_EXCL
+	}
+	f, err := os.OpenFile(path, flags, 0o600) // #nosec G304 -- name is validated and joined to the configured template directory
+	if errors.Is(err, fs.ErrExist) {
+		return "", fmt.Errorf("template %s already exists; pass overwrite to replace it", path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("write template: %w", err)
+	}
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("write template: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return "", fmt.Errorf("write template: %w", err)
+	}
+	return path, nil
+}
+
+// List returns the template names in the library, sorted.
+func (l *Library) List() ([]string, error) {
+	entries, err := os.ReadDir(l.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list templates: %w", err)
+	}
+	seen := map[string]bool{}
+	names := []string{}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		for _, x := range extensions {
+			if base, ok := strings.CutSuffix(e.Name(), x.ext); ok && !seen[base] {
+				seen[base] = true
+				names = append(names, base)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
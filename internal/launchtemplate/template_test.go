@@ -0,0 +1,103 @@
+package launchtemplate
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const standardYAML = `name: Standard launch
+roles:
+  legal: "7"
+sections:
+  - name: Legal
+    tasks:
+      - name: Legal review
+        due: T-14d
+        role: legal
+  - name: Marketing
+    tasks:
+      - name: Announcement
+        due: T
+        role: pmm
+      - name: Retro
+        due: T + 1w
+`
+
+func TestParseOffset(t *testing.T) {
+	for in, want := range map[string]int{"T-14d": -14, "t-2w": -14, "T": 0, "T+3d": 3, " T + 1w ": 7} {
+		days, ok, err := ParseOffset(in)
+		if err != nil || !ok || days != want {
+			t.Errorf("ParseOffset(%q) = %d, %v, %v; want %d", in, days, ok, err, want)
+		}
+	}
+	if _, ok, err := ParseOffset(""); ok || err != nil {
+		t.Errorf("empty offset: ok=%v err=%v", ok, err)
+	}
+	for _, bad := range []string{"14d", "T-14", "T-3m", "2026-01-01"} {
+		if _, _, err := ParseOffset(bad); err == nil {
+			t.Errorf("ParseOffset(%q): expected error", bad)
+		}
+	}
+	if FormatOffset(-14) != "T-14d" || FormatOffset(0) != "T" || FormatOffset(2) != "T+2d" {
+		t.Error("unexpected FormatOffset output")
+	}
+}
+
+func TestParse(t *testing.T) {
+	tmpl, err := Parse([]byte(standardYAML), FormatYAML)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tmpl.Sections) != 2 || tmpl.Sections[1].Tasks[1].Due != "T + 1w" || tmpl.Roles["legal"] != "7" {
+		t.Errorf("unexpected template %+v", tmpl)
+	}
+
+	if _, err = Parse([]byte(`{"sections": [{"name": "Docs", "tasks": [{"name": "Guide", "due": "soon"}]}]}`), FormatJSON); err == nil || !strings.Contains(err.Error(), `task "Guide"`) {
+		t.Errorf("expected a due error naming the task, got %v", err)
+	}
+	if _, err = Parse([]byte(`name: Empty`), FormatYAML); err == nil {
+		t.Error("expected error for a template without sections")
+	}
+}
+
+func TestLibrary(t *testing.T) {
+	lib := OpenLibrary(filepath.Join(t.TempDir(), "templates"))
+	tmpl, err := Parse([]byte(standardYAML), FormatYAML)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	path, err := lib.Save("standard", "", tmpl, false)
+	if err != nil || filepath.Base(path) != "standard.yaml" {
+		t.Fatalf("Save = %q, %v", path, err)
+	}
+	if _, err = lib.Save("standard", FormatYAML, tmpl, false); err == nil {
+		t.Error("expected error saving over an existing template")
+	}
+	if _, err = lib.Save("standard", FormatYAML, tmpl, true); err != nil {
+		t.Errorf("overwrite: %v", err)
+	}
+	if _, err = lib.Save("minimal.json", "", tmpl, false); err != nil {
+		t.Fatalf("save json: %v", err)
+	}
+
+	loaded, _, err := lib.Load("standard")
+	if err != nil || loaded.Name != "Standard launch" || len(loaded.Sections[1].Tasks) != 2 {
+		t.Errorf("Load = %+v, %v", loaded, err)
+	}
+	if loaded, _, err = lib.Load("minimal"); err != nil || loaded.Roles["legal"] != "7" {
+		t.Errorf("Load json = %+v, %v", loaded, err)
+	}
+	if names, _ := lib.List(); strings.Join(names, ",") != "minimal,standard" {
+		t.Errorf("List = %v", names)
+	}
+	if _, _, err = lib.Load("missing"); err == nil || !strings.Contains(err.Error(), "available: minimal, standard") {
+		t.Errorf("expected not-found error listing templates, got %v", err)
+	}
+	for _, bad := range []string{"../secrets", "a/b", "", ".hidden"} {
+		if _, _, err = lib.Load(bad); err == nil || !strings.Contains(err.Error(), "must be letters") {
+			t.Errorf("Load(%q): expected a name error, got %v", bad, err)
+		}
+	}
+}
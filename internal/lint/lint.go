@@ -0,0 +1,277 @@
+// Package lint checks roadmaps for hygiene problems — stale bars, empty
+// lanes, broken links and the like. Each check is a Rule; a Linter runs a
+// set of rules over a Snapshot of the roadmap and collects their findings.
+package lint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/pkg/productplan"
+)
+
+// Severity ranks findings. Errors are broken data, warnings are likely
+// mistakes, and info findings are housekeeping.
+type Severity string
+
+// Severities, most severe first.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// rank orders severities for sorting and thresholds; lower is more severe.
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 0
+	case SeverityWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// AtLeast reports whether s is at least as severe as threshold.
+func (s Severity) AtLeast(threshold Severity) bool {
+	return s.rank() <= threshold.rank()
+}
+
+// ParseSeverity parses "error", "warning" or "info".
+func ParseSeverity(s string) (Severity, error) {
+	switch Severity(s) {
+	case SeverityError, SeverityWarning, SeverityInfo:
+		return Severity(s), nil
+	}
+	return "", fmt.Errorf("unknown severity %q (use error, warning or info)", s)
+}
+
+// Target identifies the item a finding is about.
+type Target struct {
+	// Type is "bar", "lane", "milestone" or "link".
+	Type string `json:"type"`
+	ID   api.ID `json:"id"`
+	Name string `json:"name"`
+	// BarID is the bar a link belongs to.
+	BarID api.ID `json:"bar_id,omitempty"`
+}
+
+// Finding is one problem found by a rule.
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Target   Target   `json:"target"`
+	Message  string   `json:"message"`
+	Fix      string   `json:"fix"`
+}
+
+// Snapshot is the roadmap data rules inspect.
+type Snapshot struct {
+	Roadmap    api.Roadmap
+	Bars       []api.Bar
+	Lanes      []api.Lane
+	Milestones []api.Milestone
+	// Links maps bar IDs to their external links.
+	Links map[api.ID][]api.BarLink
+	// Today is the evaluation date, at midnight UTC.
+	Today time.Time
+}
+
+// Load fetches everything the built-in rules need. Links are fetched per
+// bar, in parallel. A zero today means the current date.
+func Load(ctx context.Context, client *api.Client, roadmapID string, today time.Time) (*Snapshot, error) {
+	if today.IsZero() {
+		today = time.Now()
+	}
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+
+	roadmap, err := client.FetchRoadmap(ctx, roadmapID)
+	if err != nil {
+		return nil, fmt.Errorf("roadmap: %w", err)
+	}
+	snap := &Snapshot{Roadmap: roadmap, Today: today}
+	if snap.Bars, err = client.FetchRoadmapBars(ctx, roadmapID); err != nil {
+		return nil, fmt.Errorf("bars: %w", err)
+	}
+	if snap.Lanes, err = client.FetchRoadmapLanes(ctx, roadmapID); err != nil {
+		return nil, fmt.Errorf("lanes: %w", err)
+	}
+	if snap.Milestones, err = client.FetchRoadmapMilestones(ctx, roadmapID); err != nil {
+		return nil, fmt.Errorf("milestones: %w", err)
+	}
+
+	fns := make([]func(ctx context.Context) ([]api.BarLink, error), len(snap.Bars))
+	for i, b := range snap.Bars {
+		fns[i] = func(ctx context.Context) ([]api.BarLink, error) {
+			links, err := client.FetchBarLinks(ctx, b.ID.String())
+			if err != nil {
+				return nil, fmt.Errorf("bar %s links: %w", b.ID, err)
+			}
+			return links, nil
+		}
+	}
+	result := productplan.Execute(ctx, productplan.DefaultBatchConfig(), fns)
+	if result.HasErrors() {
+		return nil, result.Errors[0].Err
+	}
+	snap.Links = make(map[api.ID][]api.BarLink, len(snap.Bars))
+	for i, links := range result.Results {
+		snap.Links[snap.Bars[i].ID] = links
+	}
+	return snap, nil
+}
+
+// Rule is one hygiene check. Implement it to plug in a check of your own.
+type Rule interface {
+	// Name is the rule's stable identifier, used to select and skip rules.
+	Name() string
+	// Description says what the rule checks, in one line.
+	Description() string
+	// Check returns the rule's findings for a snapshot.
+	Check(s *Snapshot) []Finding
+}
+
+// funcRule adapts a function to Rule.
+type funcRule struct {
+	name, description string
+	check             func(s *Snapshot) []Finding
+}
+
+// NewRule returns a Rule that runs check.
+func NewRule(name, description string, check func(s *Snapshot) []Finding) Rule {
+	return funcRule{name: name, description: description, check: check}
+}
+
+func (r funcRule) Name() string                { return r.name }
+func (r funcRule) Description() string         { return r.description }
+func (r funcRule) Check(s *Snapshot) []Finding { return r.check(s) }
+
+// Linter runs a set of rules.
+type Linter struct {
+	rules []Rule
+}
+
+// New returns a linter with the given rules.
+func New(rules ...Rule) *Linter {
+	return &Linter{rules: rules}
+}
+
+// Default returns a linter with the built-in rules.
+func Default() *Linter {
+	return New(BuiltinRules()...)
+}
+
+// Register adds a rule. A rule with the same name replaces the existing one.
+func (l *Linter) Register(r Rule) {
+	for i, existing := range l.rules {
+		if existing.Name() == r.Name() {
+			l.rules[i] = r
+			return
+		}
+	}
+	l.rules = append(l.rules, r)
+}
+
+// Rules returns the linter's rules in run order.
+func (l *Linter) Rules() []Rule {
+	return append([]Rule(nil), l.rules...)
+}
+
+// Select returns a linter restricted to the named rules (all when only is
+// empty) minus the skipped ones. Unknown names are an error so typos don't
+// silently disable checks.
+func (l *Linter) Select(only, skip []string) (*Linter, error) {
+	known := make(map[string]bool, len(l.rules))
+	for _, r := range l.rules {
+		known[r.Name()] = true
+	}
+	want := make(map[string]bool, len(only))
+	drop := make(map[string]bool, len(skip))
+	for _, names := range []struct {
+		list []string
+		set  map[string]bool
+	}{{only, want}, {skip, drop}} {
+		for _, name := range names.list {
+			if !known[name] {
+				return nil, fmt.Errorf("unknown lint rule %q (available: %s)", name, strings.Join(l.names(), ", "))
+			}
+			names.set[name] = true
+		}
+	}
+
+	selected := New()
+	for _, r := range l.rules {
+		if (len(want) == 0 || want[r.Name()]) && !drop[r.Name()] {
+			selected.rules = append(selected.rules, r)
+		}
+	}
+	return selected, nil
+}
+
+func (l *Linter) names() []string {
+	names := make([]string, len(l.rules))
+	for i, r := range l.rules {
+		names[i] = r.Name()
+	}
+	return names
+}
+
+// Report is the result of a lint run.
+type Report struct {
+	RoadmapID api.ID    `json:"roadmap_id"`
+	Roadmap   string    `json:"roadmap"`
+	AsOf      string    `json:"as_of"`
+	Rules     []string  `json:"rules"`
+	Errors    int       `json:"errors"`
+	Warnings  int       `json:"warnings"`
+	Infos     int       `json:"infos"`
+	Findings  []Finding `json:"findings"`
+}
+
+// Lint runs every rule over the snapshot. Findings are ordered by severity,
+// then by rule order.
+func (l *Linter) Lint(s *Snapshot) *Report {
+	report := &Report{
+		RoadmapID: s.Roadmap.ID,
+		Roadmap:   s.Roadmap.Name,
+		AsOf:      s.Today.Format(time.DateOnly),
+		Rules:     l.names(),
+		Findings:  []Finding{},
+	}
+	for _, r := range l.rules {
+		for _, f := range r.Check(s) {
+			f.Rule = r.Name()
+			report.Findings = append(report.Findings, f)
+		}
+	}
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return report.Findings[i].Severity.rank() < report.Findings[j].Severity.rank()
+	})
+	for _, f := range report.Findings {
+		switch f.Severity {
+		case SeverityError:
+			report.Errors++
+		case SeverityWarning:
+			report.Warnings++
+		default:
+			report.Infos++
+		}
+	}
+	return report
+}
+
+// Count returns the number of findings at least as severe as threshold.
+func (r *Report) Count(threshold Severity) int {
+	n := 0
+	for _, f := range r.Findings {
+		if f.Severity.AtLeast(threshold) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,157 @@
+package lint
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+)
+
+var today = time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)
+
+func testSnapshot(t *testing.T) *Snapshot {
+	t.Helper()
+	var bars []api.Bar
+	err := json.Unmarshal([]byte(`[
+		{"id": 1, "name": "Checkout", "lane_id": 10, "legend_id": 5, "description": "Faster checkout", "ends_on": "2026-06-01", "percent_done": 60},
+		{"id": 2, "name": "Shipped", "lane_id": 10, "legend_id": 5, "description": "Done", "ends_on": "2026-06-01", "percent_done": 100},
+		{"id": 3, "name": "Epic", "lane_id": 10, "legend_id": 5, "description": "Container", "container": true, "ends_on": "2026-01-01"},
+		{"id": 4, "name": " checkout ", "lane_id": 10, "description": "Again", "ends_on": "2026-09-01"},
+		{"id": 5, "name": "Platform", "lane_id": 10, "legend_id": 5, "description": "Parent", "container": true},
+		{"id": 6, "name": "Child", "lane_id": 10, "legend_id": 5, "description": "  ", "parent_id": 5, "parked": true, "ends_on": "2026-01-01"}
+	]`), &bars)
+	if err != nil {
+		t.Fatalf("decode bars: %v", err)
+	}
+	return &Snapshot{
+		Roadmap: api.Roadmap{ID: "9", Name: "Platform"},
+		Bars:    bars,
+		Lanes:   []api.Lane{{ID: "10", Name: "Web"}, {ID: "11", Name: "Retired"}},
+		Milestones: []api.Milestone{
+			{ID: "20", Title: "Beta", Date: "2026-05-01"},
+			{ID: "21", Title: "GA", Date: "2026-07-01"},
+		},
+		Links: map[api.ID][]api.BarLink{
+			"1": {{ID: "30", URL: "https://example.com/spec", Name: "Spec"}, {ID: "31", URL: "example.com/design"}},
+			"2": {{ID: "32", URL: "https:// broken"}, {ID: "33", URL: "ftp://files.example.com"}},
+		},
+		Today: today,
+	}
+}
+
+// findings groups finding target IDs by rule.
+func findings(r *Report) map[string][]string {
+	out := make(map[string][]string)
+	for _, f := range r.Findings {
+		out[f.Rule] = append(out[f.Rule], f.Target.ID.String())
+	}
+	return out
+}
+
+func TestDefaultRules(t *testing.T) {
+	report := Default().Lint(testSnapshot(t))
+
+	want := map[string]string{
+		"overdue_bar":         "1",
+		"empty_lane":          "11",
+		"empty_container":     "3",
+		"missing_legend":      "4",
+		"missing_description": "6",
+		"duplicate_bar_name":  "4",
+		"past_milestone":      "20",
+		"invalid_link":        "31,32,33",
+	}
+	got := findings(report)
+	for rule, ids := range want {
+		if strings.Join(got[rule], ",") != ids {
+			t.Errorf("%s: got %v, want %s", rule, got[rule], ids)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("unexpected rules fired: %v", got)
+	}
+
+	if report.Errors != 3 || report.Warnings != 3 || report.Infos != 4 {
+		t.Errorf("counts = %d/%d/%d", report.Errors, report.Warnings, report.Infos)
+	}
+	if report.Findings[0].Severity != SeverityError || report.Findings[len(report.Findings)-1].Severity != SeverityInfo {
+		t.Error("findings should be ordered by severity")
+	}
+	for _, f := range report.Findings {
+		if f.Fix == "" || f.Message == "" {
+			t.Errorf("finding without message or fix: %+v", f)
+		}
+	}
+	if report.Count(SeverityWarning) != 6 {
+		t.Errorf("Count(warning) = %d, want 6", report.Count(SeverityWarning))
+	}
+}
+
+func TestSelect(t *testing.T) {
+	l, err := Default().Select([]string{"empty_lane", "past_milestone"}, []string{"past_milestone"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	report := l.Lint(testSnapshot(t))
+	if strings.Join(report.Rules, ",") != "empty_lane" || len(report.Findings) != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	if _, err := Default().Select(nil, []string{"no_such_rule"}); err == nil || !strings.Contains(err.Error(), "overdue_bar") {
+		t.Errorf("expected unknown rule error listing rules, got %v", err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	l := New()
+	l.Register(NewRule("no_bars", "Roadmaps without bars", func(s *Snapshot) []Finding {
+		if len(s.Bars) > 0 {
+			return nil
+		}
+		return []Finding{{Severity: SeverityWarning, Target: Target{Type: "roadmap", ID: s.Roadmap.ID}, Message: "Empty", Fix: "Add bars"}}
+	}))
+	l.Register(NewRule("no_bars", "Replaced", func(*Snapshot) []Finding { return nil }))
+
+	if rules := l.Rules(); len(rules) != 1 || rules[0].Description() != "Replaced" {
+		t.Errorf("expected the second registration to replace the first, got %v", rules)
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	if s, err := ParseSeverity("warning"); err != nil || s != SeverityWarning {
+		t.Errorf("ParseSeverity(warning) = %q, %v", s, err)
+	}
+	if _, err := ParseSeverity("fatal"); err == nil {
+		t.Error("expected error for unknown severity")
+	}
+	if !SeverityError.AtLeast(SeverityWarning) || SeverityInfo.AtLeast(SeverityWarning) {
+		t.Error("unexpected AtLeast ordering")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	responses := map[string]string{
+		"/roadmaps/9":            `{"id": 9, "name": "Platform"}`,
+		"/roadmaps/9/bars":       `[{"id": 1, "name": "A"}, {"id": 2, "name": "B"}]`,
+		"/roadmaps/9/lanes":      `[{"id": 10, "name": "Web"}]`,
+		"/roadmaps/9/milestones": `[]`,
+		"/bars/1/links":          `[{"id": 30, "url": "https://example.com"}]`,
+		"/bars/2/links":          `[]`,
+	}
+	client := apitest.NewClient(t, responses)
+
+	snap, err := Load(context.Background(), client, "9", today)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if snap.Roadmap.Name != "Platform" || len(snap.Bars) != 2 || len(snap.Lanes) != 1 {
+		t.Errorf("unexpected snapshot %+v", snap)
+	}
+	if len(snap.Links["1"]) != 1 || len(snap.Links["2"]) != 0 {
+		t.Errorf("unexpected links %+v", snap.Links)
+	}
+}
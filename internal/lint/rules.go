@@ -0,0 +1,215 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/pkg/productplan"
+)
+
+// BuiltinRules returns the built-in rules in run order.
+func BuiltinRules() []Rule {
+	return []Rule{
+		NewRule("overdue_bar", "Bars past their end date that are under 100% done", overdueBars),
+		NewRule("empty_lane", "Lanes with no bars", emptyLanes),
+		NewRule("empty_container", "Container bars with no children", emptyContainers),
+		NewRule("missing_legend", "Bars without a legend", missingLegends),
+		NewRule("missing_description", "Bars without a description", missingDescriptions),
+		NewRule("duplicate_bar_name", "Bars sharing a name with another bar", duplicateBarNames),
+		NewRule("past_milestone", "Milestones dated in the past", pastMilestones),
+		NewRule("invalid_link", "Bar links whose URL is not a valid http(s) URL", invalidLinks),
+	}
+}
+
+func barTarget(b api.Bar) Target {
+	return Target{Type: "bar", ID: b.ID, Name: b.Name}
+}
+
+// scheduled reports whether a bar is on the timeline: not parked and not
+// a container, whose dates follow its children.
+func scheduled(b api.Bar) bool {
+	return !b.Parked && !b.Container
+}
+
+func overdueBars(s *Snapshot) []Finding {
+	var out []Finding
+	for _, b := range s.Bars {
+		end, ok := api.ParseDate(b.EndsOn)
+		if !scheduled(b) || !ok || !end.Before(s.Today) {
+			continue
+		}
+		done := 0.0
+		if b.PercentDone != nil {
+			done = *b.PercentDone
+		}
+		if done >= 100 {
+			continue
+		}
+		out = append(out, Finding{
+			Severity: SeverityWarning,
+			Target:   barTarget(b),
+			Message:  fmt.Sprintf("Ended %s but is %g%% done", end.Format(time.DateOnly), done),
+			Fix:      "Set percent_done to 100 if the work shipped, or move ends_on to the new expected date (manage_bar update).",
+		})
+	}
+	return out
+}
+
+func emptyLanes(s *Snapshot) []Finding {
+	used := make(map[api.ID]bool, len(s.Lanes))
+	for _, b := range s.Bars {
+		used[b.LaneID] = true
+	}
+	var out []Finding
+	for _, l := range s.Lanes {
+		if used[l.ID] {
+			continue
+		}
+		out = append(out, Finding{
+			Severity: SeverityInfo,
+			Target:   Target{Type: "lane", ID: l.ID, Name: l.Name},
+			Message:  "Lane has no bars",
+			Fix:      "Delete the lane (manage_lane delete) or add the work it was meant for.",
+		})
+	}
+	return out
+}
+
+func emptyContainers(s *Snapshot) []Finding {
+	parents := make(map[api.ID]bool)
+	for _, b := range s.Bars {
+		if b.ParentID != "" {
+			parents[b.ParentID] = true
+		}
+	}
+	var out []Finding
+	for _, b := range s.Bars {
+		if !b.Container || parents[b.ID] {
+			continue
+		}
+		out = append(out, Finding{
+			Severity: SeverityWarning,
+			Target:   barTarget(b),
+			Message:  "Container has no child bars",
+			Fix:      "Move child bars under it (manage_bar update parent_id) or turn off container.",
+		})
+	}
+	return out
+}
+
+func missingLegends(s *Snapshot) []Finding {
+	var out []Finding
+	for _, b := range s.Bars {
+		if b.LegendID != "" {
+			continue
+		}
+		out = append(out, Finding{
+			Severity: SeverityInfo,
+			Target:   barTarget(b),
+			Message:  "Bar has no legend",
+			Fix:      "Assign a legend (manage_bar update legend_id; see get_roadmap_legends).",
+		})
+	}
+	return out
+}
+
+func missingDescriptions(s *Snapshot) []Finding {
+	var out []Finding
+	for _, b := range s.Bars {
+		if strings.TrimSpace(b.Description) != "" {
+			continue
+		}
+		out = append(out, Finding{
+			Severity: SeverityInfo,
+			Target:   barTarget(b),
+			Message:  "Bar has no description",
+			Fix:      "Add a description saying what the work is and why (manage_bar update description).",
+		})
+	}
+	return out
+}
+
+// duplicateBarNames reports every bar after the first with the same name,
+// compared case-insensitively with surrounding space ignored.
+func duplicateBarNames(s *Snapshot) []Finding {
+	first := make(map[string]api.Bar)
+	var out []Finding
+	for _, b := range s.Bars {
+		key := strings.ToLower(strings.TrimSpace(b.Name))
+		if key == "" {
+			continue
+		}
+		original, seen := first[key]
+		if !seen {
+			first[key] = b
+			continue
+		}
+		out = append(out, Finding{
+			Severity: SeverityWarning,
+			Target:   barTarget(b),
+			Message:  fmt.Sprintf("Same name as bar %s", original.ID),
+			Fix:      "Rename one of the bars so they can be told apart, or delete the duplicate (manage_bar delete).",
+		})
+	}
+	return out
+}
+
+func pastMilestones(s *Snapshot) []Finding {
+	var out []Finding
+	for _, m := range s.Milestones {
+		date, ok := api.ParseDate(m.Date)
+		if !ok || !date.Before(s.Today) {
+			continue
+		}
+		out = append(out, Finding{
+			Severity: SeverityInfo,
+			Target:   Target{Type: "milestone", ID: m.ID, Name: m.Label()},
+			Message:  fmt.Sprintf("Milestone date %s has passed", date.Format(time.DateOnly)),
+			Fix:      "Delete the milestone (manage_milestone delete) or move it to its new date.",
+		})
+	}
+	return out
+}
+
+func invalidLinks(s *Snapshot) []Finding {
+	var out []Finding
+	for _, b := range s.Bars {
+		for _, l := range s.Links[b.ID] {
+			problem := urlProblem(l.URL)
+			if problem == "" {
+				continue
+			}
+			name := l.Name
+			if name == "" {
+				name = l.URL
+			}
+			out = append(out, Finding{
+				Severity: SeverityError,
+				Target:   Target{Type: "link", ID: l.ID, Name: name, BarID: b.ID},
+				Message:  fmt.Sprintf("Link on %q has URL %q, which %s", b.Name, l.URL, problem),
+				Fix:      "Delete the link and re-create it with a full http(s) URL (manage_bar_link).",
+			})
+		}
+	}
+	return out
+}
+
+// urlProblem describes why raw is not a usable link URL, or returns "".
+// Beyond productplan.ValidateURL's scheme check, the URL must parse and
+// have a host.
+func urlProblem(raw string) string {
+	raw = strings.TrimSpace(raw)
+	var verr *productplan.ValidationError
+	if errors.As(productplan.ValidateURL("url", raw), &verr) {
+		return verr.Message
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || strings.ContainsAny(u.Host, " \t") {
+		return "must be a valid URL with a host"
+	}
+	return ""
+}
@@ -84,28 +84,41 @@ type Logger interface {
 	WithRequestID(id string) Logger
 }
 
-// JSONLogger implements Logger with JSON output.
+// JSONLogger implements Logger with JSON output. Entries pass through a
+// Redactor, DefaultRedactor unless SetRedactor replaces it, before they
+// are serialised.
 type JSONLogger struct {
-	out    io.Writer
-	level  Level
+	*output
 	fields []Field
-	mu     sync.Mutex
+}
+
+// Sink receives each entry a JSONLogger writes, serialised and redacted,
+// alongside its writer. It must not log.
+type Sink func(level Level, entry json.RawMessage)
+
+// output is the state a JSONLogger shares with the loggers derived from
+// it, so level and redaction changes reach all of them.
+type output struct {
+	mu       sync.Mutex
+	out      io.Writer
+	level    Level
+	redactor *Redactor
+	sink     Sink
 }
 
 // New creates a new JSONLogger writing to stderr.
 func New(level Level) *JSONLogger {
-	return &JSONLogger{
-		out:   os.Stderr,
-		level: level,
-	}
+	return NewWithWriter(os.Stderr, level)
 }
 
-// NewWithWriter creates a JSONLogger with a custom writer (for testing).
+// NewWithWriter creates a JSONLogger with a custom writer, such as a log
+// file or a test buffer.
 func NewWithWriter(w io.Writer, level Level) *JSONLogger {
-	return &JSONLogger{
-		out:   w,
-		level: level,
-	}
+	return &JSONLogger{output: &output{
+		out:      w,
+		level:    level,
+		redactor: DefaultRedactor(),
+	}}
 }
 
 // Nop returns a no-op logger that discards all output.
@@ -123,39 +136,45 @@ func (n *nopLogger) WithFields(fields ...Field) Logger { return n }
 func (n *nopLogger) WithRequestID(id string) Logger    { return n }
 
 func (l *JSONLogger) log(level Level, msg string, fields ...Field) {
+	l.mu.Lock()
 	if level < l.level {
+		l.mu.Unlock()
 		return
 	}
 
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
 	// Build the log entry as a map for flexible field ordering
 	entry := make(map[string]any, len(l.fields)+len(fields)+3)
 	entry["ts"] = time.Now().UTC().Format(time.RFC3339Nano)
 	entry["level"] = level.String()
-	entry["msg"] = msg
+	entry["msg"] = l.redactor.RedactString(msg)
 
 	// Add base fields
 	for _, f := range l.fields {
-		entry[f.Key] = f.Value
+		entry[f.Key] = l.redactor.RedactField(f.Key, f.Value)
 	}
 
 	// Add call-specific fields (can override base fields)
 	for _, f := range fields {
-		entry[f.Key] = f.Value
+		entry[f.Key] = l.redactor.RedactField(f.Key, f.Value)
 	}
 
-	// Encode and write
+	// Encode, redact secrets and write
 	data, err := json.Marshal(entry)
 	if err != nil {
-		_, _ = fmt.Fprintf(l.out, `{"ts":"%s","level":"error","msg":"failed to marshal log entry","error":"%s"}`+"\n",
-			time.Now().UTC().Format(time.RFC3339Nano), err.Error())
-		return
+		data = []byte(fmt.Sprintf(`{"ts":"%s","level":"error","msg":"failed to marshal log entry","error":%q}`,
+			time.Now().UTC().Format(time.RFC3339Nano), err.Error()))
 	}
+	data = redactSecrets(data)
+
+	_, _ = l.out.Write(append(data, '\n'))
+	sink := l.sink
+	l.mu.Unlock()
 
-	_, _ = l.out.Write(data)
-	_, _ = l.out.Write([]byte{'\n'})
+	// The sink runs unlocked so it may take its own locks, e.g. to share
+	// a stream with other writers.
+	if sink != nil {
+		sink(level, data)
+	}
 }
 
 func (l *JSONLogger) Debug(msg string, fields ...Field) {
@@ -174,17 +193,14 @@ func (l *JSONLogger) Error(msg string, fields ...Field) {
 	l.log(LevelError, msg, fields...)
 }
 
-// WithFields returns a new logger with additional base fields.
+// WithFields returns a new logger with additional base fields. It shares
+// the parent's output, level and redaction rules.
 func (l *JSONLogger) WithFields(fields ...Field) Logger {
 	newFields := make([]Field, len(l.fields)+len(fields))
 	copy(newFields, l.fields)
 	copy(newFields[len(l.fields):], fields)
 
-	return &JSONLogger{
-		out:    l.out,
-		level:  l.level,
-		fields: newFields,
-	}
+	return &JSONLogger{output: l.output, fields: newFields}
 }
 
 // WithRequestID returns a new logger with the request ID field.
@@ -199,6 +215,22 @@ func (l *JSONLogger) SetLevel(level Level) {
 	l.level = level
 }
 
+// SetRedactor replaces the redaction rules. Nil turns them off, leaving
+// only registered secrets redacted.
+func (l *JSONLogger) SetRedactor(r *Redactor) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.redactor = r
+}
+
+// SetSink sends every entry written from now on to sink as well. Nil
+// removes the sink.
+func (l *JSONLogger) SetSink(sink Sink) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sink = sink
+}
+
 // GetLevel returns the current log level.
 func (l *JSONLogger) GetLevel() Level {
 	l.mu.Lock()
@@ -343,3 +343,58 @@ func BenchmarkJSONLoggerWithFields(b *testing.B) {
 		child.Info("benchmark message", F("iteration", i))
 	}
 }
+
+func TestAddSecret(t *testing.T) {
+	AddSecret("pp-token-1234567890")
+	AddSecret(`tok"en<&>value`)
+	AddSecret("short")
+
+	var buf bytes.Buffer
+	logger := NewWithWriter(&buf, LevelDebug).WithFields(F("auth", "Bearer pp-token-1234567890"))
+	logger.Error("request with pp-token-1234567890 failed",
+		Error(errors.New(`bad token tok"en<&>value`)),
+		F("nested", map[string]any{"token": "pp-token-1234567890"}),
+		F("word", "short"),
+	)
+
+	out := buf.String()
+	for _, secret := range []string{"pp-token-1234567890", `tok\"en`, `\u003c&\u003e`} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log output contains %q: %s", secret, out)
+		}
+	}
+	if strings.Count(out, Redacted) != 4 || !strings.Contains(out, `"word":"short"`) {
+		t.Errorf("unexpected redaction: %s", out)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("redacted output is not valid JSON: %v", err)
+	}
+}
+
+func TestJSONLoggerSinkAndSharedLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithWriter(&buf, LevelInfo)
+	child := logger.WithFields(Tool("list_roadmaps"))
+
+	var got []string
+	logger.SetSink(func(level Level, entry json.RawMessage) {
+		got = append(got, level.String()+" "+string(entry))
+	})
+	child.Debug("hidden")
+	logger.SetLevel(LevelDebug)
+	child.Debug("shown", F("email", "ana@example.com"))
+
+	if len(got) != 1 || !strings.HasPrefix(got[0], "debug {") || !strings.Contains(got[0], `"tool":"list_roadmaps"`) || strings.Contains(got[0], "ana@") {
+		t.Errorf("unexpected sink entries %v", got)
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("expected the entry in the writer too: %s", buf.String())
+	}
+
+	logger.SetSink(nil)
+	child.Info("after")
+	if len(got) != 1 {
+		t.Error("sink still called after removal")
+	}
+}
@@ -0,0 +1,127 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Default redaction rules. Field names match case-insensitively at any
+// depth of a field's value.
+var (
+	DefaultRedactFields = []string{
+		"authorization", "cookie", "set-cookie", "password", "secret",
+		"token", "api_token", "access_token", "refresh_token",
+		"email", "customer", "customers", "customer_name",
+	}
+	DefaultRedactPatterns = []string{
+		// Bearer tokens, e.g. in an echoed Authorization header.
+		`(?i)\bbearer\s+[A-Za-z0-9._~+/-]+=*`,
+		// Email addresses.
+		`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`,
+	}
+)
+
+// Redactor masks sensitive values in log entries before they are
+// serialised: values of the named fields are replaced wholesale, and
+// pattern matches are replaced inside every string, including the message.
+// Registered secrets (see AddSecret) are redacted whether or not a
+// Redactor is set.
+type Redactor struct {
+	fields   map[string]bool
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor builds a Redactor from field names and regular expressions.
+func NewRedactor(fields, patterns []string) (*Redactor, error) {
+	r := &Redactor{fields: make(map[string]bool, len(fields))}
+	for _, f := range fields {
+		r.fields[strings.ToLower(f)] = true
+	}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("redact pattern %q: %w", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// DefaultRedactor returns a Redactor with the default rules.
+func DefaultRedactor() *Redactor {
+	r, err := NewRedactor(DefaultRedactFields, DefaultRedactPatterns)
+	if err != nil {
+		panic(err) // the defaults are constant
+	}
+	return r
+}
+
+// RedactString replaces pattern matches in s.
+func (r *Redactor) RedactString(s string) string {
+	if r == nil {
+		return s
+	}
+	for _, re := range r.patterns {
+		s = re.ReplaceAllString(s, Redacted)
+	}
+	return s
+}
+
+// RedactField returns value with the rules applied, as it will be
+// serialised under key.
+func (r *Redactor) RedactField(key string, value any) any {
+	if r == nil {
+		return value
+	}
+	if r.fields[strings.ToLower(key)] {
+		return Redacted
+	}
+	return r.redact(value)
+}
+
+func (r *Redactor) redact(v any) any {
+	switch v := v.(type) {
+	case nil, bool, int, int64, float64:
+		return v
+	case string:
+		return r.RedactString(v)
+	case error:
+		return r.RedactString(v.Error())
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			out[k] = r.RedactField(k, val)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, val := range v {
+			out[i] = r.redact(val)
+		}
+		return out
+	case json.RawMessage:
+		var decoded any
+		if err := json.Unmarshal(v, &decoded); err != nil {
+			return r.RedactString(string(v))
+		}
+		return r.redact(decoded)
+	}
+	// Structs, typed maps and slices: redact their JSON form, so field
+	// rules see the names they will be serialised under.
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	return r.redact(json.RawMessage(data))
+}
+
+// Payload returns a field holding a request or response body. Bodies that
+// are JSON are logged as JSON, so field rules apply inside them.
+func Payload(key string, body []byte) Field {
+	if json.Valid(body) {
+		return F(key, json.RawMessage(body))
+	}
+	return F(key, string(body))
+}
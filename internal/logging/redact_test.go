@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRedactorDefaults(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithWriter(&buf, LevelDebug)
+
+	type user struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	logger.Warn("API error for ana@example.com",
+		Error(errors.New(`401: {"error":"invalid header Authorization: Bearer abc.def-123"}`)),
+		F("Authorization", "Bearer abc"),
+		F("user", user{Name: "Ana", Email: "ana@example.com"}),
+		Payload("response_body", []byte(`{"customers":["Acme"],"notes":"mail bo@example.org","id":7}`)),
+		Count(3),
+	)
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log output: %v", err)
+	}
+	out := buf.String()
+	for _, leak := range []string{"ana@example.com", "bo@example.org", "abc.def-123", "Acme"} {
+		if strings.Contains(out, leak) {
+			t.Errorf("log output contains %q: %s", leak, out)
+		}
+	}
+	if entry["msg"] != "API error for "+Redacted || entry["Authorization"] != Redacted || entry["count"] != float64(3) {
+		t.Errorf("unexpected entry %v", entry)
+	}
+	if u := entry["user"].(map[string]any); u["name"] != "Ana" || u["email"] != Redacted {
+		t.Errorf("unexpected user %v", u)
+	}
+	if body := entry["response_body"].(map[string]any); body["id"] != float64(7) || body["customers"] != Redacted || body["notes"] != "mail "+Redacted {
+		t.Errorf("unexpected body %v", body)
+	}
+}
+
+func TestRedactorCustomRules(t *testing.T) {
+	r, err := NewRedactor([]string{"Customer_Name"}, []string{`acct-\d+`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	logger := NewWithWriter(&buf, LevelDebug)
+	logger.SetRedactor(r)
+	logger.WithFields(F("customer_name", "Acme")).Info("moved acct-42", F("email", "ana@example.com"))
+
+	out := buf.String()
+	if strings.Contains(out, "Acme") || strings.Contains(out, "acct-42") || !strings.Contains(out, "ana@example.com") {
+		t.Errorf("unexpected output %s", out)
+	}
+
+	buf.Reset()
+	logger.SetRedactor(nil)
+	logger.Info("plain", F("email", "ana@example.com"), Payload("body", []byte("not json")))
+	if !strings.Contains(buf.String(), "ana@example.com") || !strings.Contains(buf.String(), `"body":"not json"`) {
+		t.Errorf("expected no redaction without a redactor: %s", buf.String())
+	}
+
+	if _, err = NewRedactor(nil, []string{"("}); err == nil {
+		t.Error("expected an invalid pattern error")
+	}
+}
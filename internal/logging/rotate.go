@@ -0,0 +1,179 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rotation defaults.
+const (
+	DefaultMaxSize    = 10 << 20
+	DefaultMaxBackups = 5
+
+	backupTimeFormat = "20060102T150405.000"
+)
+
+// RotateOptions controls when a RotatingFile starts a new file and how
+// many old ones it keeps.
+type RotateOptions struct {
+	// MaxSize is the size in bytes a file may reach before rotation.
+	// Zero means DefaultMaxSize.
+	MaxSize int64
+	// MaxAge rotates a file once it is this old, e.g. daily. Zero turns
+	// age-based rotation off.
+	MaxAge time.Duration
+	// MaxBackups is the number of rotated files kept. Zero means
+	// DefaultMaxBackups; negative keeps all.
+	MaxBackups int
+}
+
+// RotatingFile is an io.WriteCloser that appends to a log file and
+// renames it to a timestamped backup, e.g. server-20261018T190203.log,
+// when it grows past MaxSize or gets older than MaxAge.
+type RotatingFile struct {
+	path string
+	opts RotateOptions
+
+	mu      sync.Mutex
+	file    *os.File // nil after a failed rotation until reopened
+	closed  bool
+	size    int64
+	created time.Time
+	now     func() time.Time
+}
+
+// OpenRotatingFile opens path for appending, creating it and its
+// directory if needed.
+func OpenRotatingFile(path string, opts RotateOptions) (*RotatingFile, error) {
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = DefaultMaxSize
+	}
+	if opts.MaxBackups == 0 {
+		opts.MaxBackups = DefaultMaxBackups
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("create log directory: %w", err)
+	}
+	r := &RotatingFile{path: path, opts: opts, now: time.Now}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// open opens the current file. An existing file's age counts from its
+// last modification, so a stale log left by an earlier run is rotated on
+// the first write.
+func (r *RotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600) // #nosec G304 -- the log path is set by the user
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("open log file: %w", err)
+	}
+	r.file, r.size, r.created = f, info.Size(), r.now()
+	if r.size > 0 {
+		r.created = info.ModTime()
+	}
+	return nil
+}
+
+// Write appends p, rotating first if p would take the file past MaxSize
+// or the file is older than MaxAge.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return 0, os.ErrClosed
+	}
+	if r.file == nil {
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+	tooBig := r.size > 0 && r.size+int64(len(p)) > r.opts.MaxSize
+	tooOld := r.opts.MaxAge > 0 && r.size > 0 && r.now().Sub(r.created) >= r.opts.MaxAge
+	if tooBig || tooOld {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Close closes the current file.
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// rotate renames the current file to a backup and opens a new one. If
+// that fails, the current path is reopened so logging carries on in the
+// old file; if even that fails, the next Write tries again.
+func (r *RotatingFile) rotate() error {
+	err := r.file.Close()
+	r.file = nil
+	if err != nil {
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+	ext := filepath.Ext(r.path)
+	backup := fmt.Sprintf("%s-%s%s", strings.TrimSuffix(r.path, ext), r.now().Format(backupTimeFormat), ext)
+	if err = os.Rename(r.path, backup); err != nil {
+		_ = r.open()
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+	if err = r.open(); err != nil {
+		return err
+	}
+	r.prune()
+	return nil
+}
+
+// prune removes the oldest backups beyond MaxBackups. Failures are
+// ignored; they leave extra files, not lost logs.
+func (r *RotatingFile) prune() {
+	if r.opts.MaxBackups < 0 {
+		return
+	}
+	ext := filepath.Ext(r.path)
+	prefix := strings.TrimSuffix(r.path, ext) + "-"
+	matches, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return
+	}
+	var backups []string
+	for _, m := range matches {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(m, prefix), ext)
+		if _, err = time.Parse(backupTimeFormat, stamp); err == nil {
+			backups = append(backups, m)
+		}
+	}
+	if len(backups) <= r.opts.MaxBackups {
+		return
+	}
+	// Timestamps sort lexically, oldest first.
+	sort.Strings(backups)
+	for _, b := range backups[:len(backups)-r.opts.MaxBackups] {
+		_ = os.Remove(b)
+	}
+}
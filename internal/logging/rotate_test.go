@@ -0,0 +1,111 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFileSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logs", "server.log")
+	clock := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	r, err := OpenRotatingFile(path, RotateOptions{MaxSize: 10, MaxBackups: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.now = func() time.Time { clock = clock.Add(time.Second); return clock }
+	if err = os.WriteFile(filepath.Join(dir, "logs", "server-notes.log"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{"aaaa\n", "bbbb\n", "cccc\n", "dddd\n", "eeee\n", "ffff\n", "gggg\n"} {
+		if _, err = r.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = r.Write([]byte("late\n")); err == nil {
+		t.Error("expected an error writing to a closed file")
+	}
+
+	current, _ := os.ReadFile(path)
+	if string(current) != "gggg\n" {
+		t.Errorf("current file = %q", current)
+	}
+	backups, _ := filepath.Glob(filepath.Join(dir, "logs", "server-2026*.log"))
+	if len(backups) != 2 {
+		t.Fatalf("expected 2 backups, got %v", backups)
+	}
+	newest, _ := os.ReadFile(backups[1])
+	if string(newest) != "eeee\nffff\n" || !strings.Contains(filepath.Base(backups[1]), "server-20261018T0900") {
+		t.Errorf("newest backup %s = %q", backups[1], newest)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "logs", "server-notes.log")); err != nil {
+		t.Error("pruning removed a file that is not a backup")
+	}
+}
+
+func TestRotatingFileAge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	clock := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	r, err := OpenRotatingFile(path, RotateOptions{MaxAge: 24 * time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	r.now = func() time.Time { return clock }
+	r.created = clock
+
+	_, _ = r.Write([]byte("day one\n"))
+	clock = clock.Add(23 * time.Hour)
+	_, _ = r.Write([]byte("still day one\n"))
+	clock = clock.Add(time.Hour)
+	_, _ = r.Write([]byte("day two\n"))
+
+	current, _ := os.ReadFile(path)
+	backups, _ := filepath.Glob(filepath.Join(filepath.Dir(path), "server-*.log"))
+	if string(current) != "day two\n" || len(backups) != 1 {
+		t.Errorf("current = %q, backups = %v", current, backups)
+	}
+}
+
+func TestRotatingFileRecovers(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "server.log")
+	clock := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	r, err := OpenRotatingFile(path, RotateOptions{MaxSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	r.now = func() time.Time { return clock }
+
+	// A directory where the backup should go makes the rename fail.
+	blocker := filepath.Join(dir, "server-"+clock.Format(backupTimeFormat)+".log")
+	if err = os.Mkdir(blocker, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = r.Write([]byte("aaaaaaaa\n")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = r.Write([]byte("bbbb\n")); err == nil {
+		t.Error("expected a rotation error")
+	}
+
+	if err = os.Remove(blocker); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = r.Write([]byte("cccc\n")); err != nil {
+		t.Fatalf("expected writes to recover after a failed rotation: %v", err)
+	}
+	current, _ := os.ReadFile(path)
+	backup, _ := os.ReadFile(blocker)
+	if string(current) != "cccc\n" || string(backup) != "aaaaaaaa\n" {
+		t.Errorf("current = %q, backup = %q", current, backup)
+	}
+}
@@ -0,0 +1,65 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// Redacted replaces secret values in log output.
+const Redacted = "[REDACTED]"
+
+// minSecretLen keeps short values, which would match ordinary words and
+// numbers, out of the secret set.
+const minSecretLen = 8
+
+// secrets holds values, such as API tokens, that no logger may write.
+var secrets struct {
+	mu     sync.RWMutex
+	values [][]byte
+}
+
+// AddSecret registers a value that must never appear in log output, such as
+// an API token. Every JSONLogger replaces it with Redacted wherever it
+// occurs in an entry, including inside messages and error strings. Values
+// shorter than eight bytes are ignored.
+func AddSecret(value string) {
+	if len(value) < minSecretLen {
+		return
+	}
+	forms := [][]byte{[]byte(value)}
+	// Entries are checked after JSON encoding, so also match the escaped
+	// form of values holding quotes, backslashes or HTML characters.
+	if quoted, err := json.Marshal(value); err == nil {
+		if escaped := quoted[1 : len(quoted)-1]; !bytes.Equal(escaped, forms[0]) {
+			forms = append(forms, escaped)
+		}
+	}
+
+	secrets.mu.Lock()
+	defer secrets.mu.Unlock()
+	for _, form := range forms {
+		known := false
+		for _, v := range secrets.values {
+			if bytes.Equal(v, form) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			secrets.values = append(secrets.values, form)
+		}
+	}
+}
+
+// redactSecrets replaces every registered secret in an encoded entry.
+func redactSecrets(data []byte) []byte {
+	secrets.mu.RLock()
+	defer secrets.mu.RUnlock()
+	for _, v := range secrets.values {
+		if bytes.Contains(data, v) {
+			data = bytes.ReplaceAll(data, v, []byte(Redacted))
+		}
+	}
+	return data
+}
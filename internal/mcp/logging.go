@@ -0,0 +1,79 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/logging"
+)
+
+// LogController is a logger whose verbosity and output the client can
+// change through logging/setLevel. *logging.JSONLogger implements it; a
+// server whose logger does not leaves the logging capability out.
+type LogController interface {
+	SetLevel(level logging.Level)
+	SetSink(sink logging.Sink)
+}
+
+// mcpLevels maps the syslog levels MCP clients send to logger levels.
+var mcpLevels = map[string]logging.Level{
+	"debug":     logging.LevelDebug,
+	"info":      logging.LevelInfo,
+	"notice":    logging.LevelInfo,
+	"warning":   logging.LevelWarn,
+	"error":     logging.LevelError,
+	"critical":  logging.LevelError,
+	"alert":     logging.LevelError,
+	"emergency": logging.LevelError,
+}
+
+// mcpLevel names a logger level as MCP does.
+func mcpLevel(level logging.Level) string {
+	if level == logging.LevelWarn {
+		return "warning"
+	}
+	return level.String()
+}
+
+func (s *Server) logController() LogController {
+	c, _ := s.logger.(LogController)
+	return c
+}
+
+// setLogLevel handles logging/setLevel: it sets the logger's level and
+// from then on forwards every entry to the client as notifications/message.
+func (s *Server) setLogLevel(params json.RawMessage) (any, *RPCError) {
+	c := s.logController()
+	if c == nil {
+		return nil, NewError(ErrMethodNotFound, "Method not found: logging/setLevel")
+	}
+	var p SetLevelParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, NewError(ErrInvalidParams, err.Error())
+	}
+	level, ok := mcpLevels[p.Level]
+	if !ok {
+		return nil, NewError(ErrInvalidParams, fmt.Sprintf("unknown log level %q", p.Level))
+	}
+	c.SetLevel(level)
+	c.SetSink(s.notifyLog)
+	return struct{}{}, nil
+}
+
+// notifyLog sends one log entry to the client. If sending fails, it stops
+// forwarding entries and logs why, so a broken client connection does not
+// fail every later log call the same way.
+func (s *Server) notifyLog(level logging.Level, entry json.RawMessage) {
+	err := s.send(JSONRPCNotification{
+		JSONRPC: "2.0",
+		Method:  "notifications/message",
+		Params:  LogMessageParams{Level: mcpLevel(level), Logger: s.name, Data: entry},
+	})
+	if err == nil {
+		return
+	}
+	if c := s.logController(); c != nil {
+		c.SetSink(nil)
+	}
+	s.logger.Warn("stopped sending log notifications", logging.Error(err))
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync"
 
 	"github.com/olgasafonova/productplan-mcp-server/internal/logging"
 )
@@ -20,6 +21,10 @@ type Server struct {
 	logger       logging.Logger
 	reader       io.Reader
 	writer       io.Writer
+
+	// writeMu serialises responses and log notifications, which tool
+	// handlers may trigger from other goroutines.
+	writeMu sync.Mutex
 }
 
 // ServerOption configures a Server.
@@ -104,15 +109,11 @@ func (s *Server) Run(ctx context.Context) error {
 			continue
 		}
 
-		respJSON, err := json.Marshal(resp)
-		if err != nil {
-			s.logger.Error("failed to marshal response",
+		if err := s.send(resp); err != nil {
+			s.logger.Error("failed to send response",
 				logging.Error(err),
 			)
-			continue
 		}
-
-		_, _ = fmt.Fprintln(s.writer, string(respJSON))
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -127,17 +128,24 @@ func (s *Server) handleRequest(ctx context.Context, req JSONRPCRequest) JSONRPCR
 
 	switch req.Method {
 	case "initialize":
-		resp.Result = InitializeResult{
+		result := InitializeResult{
 			ProtocolVersion: ProtocolVersion,
 			ServerInfo:      ServerInfo{Name: s.name, Version: s.version},
 			Capabilities:    Capabilities{Tools: map[string]any{}},
 			Instructions:    s.instructions,
 		}
+		if s.logController() != nil {
+			result.Capabilities.Logging = &struct{}{}
+		}
+		resp.Result = result
 
 	case "notifications/initialized":
 		// No response needed for notifications
 		return JSONRPCResponse{}
 
+	case "logging/setLevel":
+		resp.Result, resp.Error = s.setLogLevel(req.Params)
+
 	case "tools/list":
 		resp.Result = ToolsListResult{Tools: s.registry.Tools()}
 
@@ -175,6 +183,18 @@ func (s *Server) handleRequest(ctx context.Context, req JSONRPCRequest) JSONRPCR
 	return resp
 }
 
+// send writes one JSON-RPC message as a line.
+func (s *Server) send(msg any) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_, err = fmt.Fprintln(s.writer, string(data))
+	return err
+}
+
 // ProcessRequest handles a single request for testing.
 func (s *Server) ProcessRequest(ctx context.Context, req JSONRPCRequest) JSONRPCResponse {
 	return s.handleRequest(ctx, req)
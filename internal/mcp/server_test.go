@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 
@@ -377,6 +378,105 @@ func TestServerRun(t *testing.T) {
 	}
 }
 
+func TestServerLoggingSetLevel(t *testing.T) {
+	registry := NewRegistry()
+	registry.RegisterFunc(
+		Tool{Name: "ping", Description: "Ping tool"},
+		func(ctx context.Context, args map[string]any) (json.RawMessage, error) {
+			return json.RawMessage(`{"pong": true}`), nil
+		},
+	)
+
+	input := strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"initialize"}
+{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"ping","arguments":{}}}
+{"jsonrpc":"2.0","id":3,"method":"logging/setLevel","params":{"level":"loud"}}
+{"jsonrpc":"2.0","id":4,"method":"logging/setLevel","params":{"level":"debug"}}
+{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"ping","arguments":{}}}
+`)
+	var output, logs bytes.Buffer
+	server := NewServer("test", "1.0.0", registry,
+		WithIO(input, &output),
+		WithLogger(logging.NewWithWriter(&logs, logging.LevelInfo)),
+	)
+	if err := server.Run(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var responses []JSONRPCResponse
+	var notes []LogMessageParams
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		var msg struct {
+			JSONRPCResponse
+			Method string           `json:"method"`
+			Params LogMessageParams `json:"params"`
+		}
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			t.Fatalf("invalid line %q: %v", line, err)
+		}
+		if msg.Method == "notifications/message" {
+			notes = append(notes, msg.Params)
+		} else {
+			responses = append(responses, msg.JSONRPCResponse)
+		}
+	}
+
+	if len(responses) != 5 || !strings.Contains(output.String(), `"logging":{}`) {
+		t.Fatalf("unexpected responses %s", output.String())
+	}
+	if responses[2].Error == nil || responses[2].Error.Code != ErrInvalidParams || responses[3].Error != nil {
+		t.Errorf("unexpected setLevel responses %+v, %+v", responses[2].Error, responses[3].Error)
+	}
+	// Debug entries for request 5 only: notifications start with setLevel.
+	if len(notes) == 0 {
+		t.Fatal("expected log notifications after logging/setLevel")
+	}
+	for _, n := range notes {
+		if n.Level != "debug" || n.Logger != "test" || bytes.Contains(n.Data, []byte(`"id":2`)) {
+			t.Errorf("unexpected notification %+v (%s)", n, n.Data)
+		}
+	}
+	if !strings.Contains(logs.String(), `"tool":"ping"`) {
+		t.Errorf("expected debug entries in the log after setLevel: %s", logs.String())
+	}
+}
+
+// failingWriter fails every write, like a closed stdout.
+type failingWriter struct{ writes int }
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return 0, errors.New("broken pipe")
+}
+
+func TestServerLoggingSendFailure(t *testing.T) {
+	var logs bytes.Buffer
+	out := &failingWriter{}
+	logger := logging.NewWithWriter(&logs, logging.LevelInfo)
+	server := NewServer("test", "1.0.0", NewRegistry(), WithIO(strings.NewReader(""), out), WithLogger(logger))
+	server.ProcessRequest(context.Background(), JSONRPCRequest{JSONRPC: "2.0", ID: 1, Method: "logging/setLevel", Params: json.RawMessage(`{"level":"info"}`)})
+
+	logger.Info("first")
+	logger.Info("second")
+	if out.writes != 1 {
+		t.Errorf("expected notifications to stop after a failed send, got %d writes", out.writes)
+	}
+	if !strings.Contains(logs.String(), "stopped sending log notifications") || !strings.Contains(logs.String(), "broken pipe") {
+		t.Errorf("expected the send error to be logged: %s", logs.String())
+	}
+}
+
+func TestServerLoggingUnsupported(t *testing.T) {
+	server := NewServer("test", "1.0.0", NewRegistry(), WithLogger(logging.Nop()))
+	resp := server.ProcessRequest(context.Background(), JSONRPCRequest{JSONRPC: "2.0", ID: 1, Method: "initialize"})
+	if result := resp.Result.(InitializeResult); result.Capabilities.Logging != nil {
+		t.Error("logging capability advertised without a controllable logger")
+	}
+	resp = server.ProcessRequest(context.Background(), JSONRPCRequest{JSONRPC: "2.0", ID: 2, Method: "logging/setLevel", Params: json.RawMessage(`{"level":"debug"}`)})
+	if resp.Error == nil || resp.Error.Code != ErrMethodNotFound {
+		t.Errorf("expected method not found, got %+v", resp.Error)
+	}
+}
+
 func TestServerRunEmptyLines(t *testing.T) {
 	registry := NewRegistry()
 	input := strings.NewReader(`
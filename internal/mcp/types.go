@@ -22,6 +22,14 @@ type JSONRPCResponse struct {
 	Error   *RPCError `json:"error,omitempty"`
 }
 
+// JSONRPCNotification represents an outgoing JSON-RPC 2.0 notification,
+// which has no ID and gets no response.
+type JSONRPCNotification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
 // RPCError represents a JSON-RPC 2.0 error.
 type RPCError struct {
 	Code    int    `json:"code"`
@@ -82,16 +90,20 @@ type OutputSchema struct {
 	Required   []string            `json:"required,omitempty"`
 }
 
-// Property defines a single property in the input schema.
+// Property defines a single property in the input schema. Properties and
+// Required describe nested objects, which output schemas use to document
+// structured results field by field.
 type Property struct {
-	Type        string    `json:"type"`
-	Description string    `json:"description"`
-	Enum        []string  `json:"enum,omitempty"`
-	Minimum     *float64  `json:"minimum,omitempty"`
-	Maximum     *float64  `json:"maximum,omitempty"`
-	Pattern     string    `json:"pattern,omitempty"`
-	Items       *Property `json:"items,omitempty"`
-	Examples    []any     `json:"examples,omitempty"`
+	Type        string              `json:"type"`
+	Description string              `json:"description"`
+	Enum        []string            `json:"enum,omitempty"`
+	Minimum     *float64            `json:"minimum,omitempty"`
+	Maximum     *float64            `json:"maximum,omitempty"`
+	Pattern     string              `json:"pattern,omitempty"`
+	Items       *Property           `json:"items,omitempty"`
+	Properties  map[string]Property `json:"properties,omitempty"`
+	Required    []string            `json:"required,omitempty"`
+	Examples    []any               `json:"examples,omitempty"`
 }
 
 // ToolContent represents content returned from a tool call.
@@ -159,6 +171,21 @@ type ServerInfo struct {
 // Capabilities describes what the server supports.
 type Capabilities struct {
 	Tools map[string]any `json:"tools"`
+	// Logging is set when the client may call logging/setLevel.
+	Logging *struct{} `json:"logging,omitempty"`
+}
+
+// SetLevelParams represents the parameters for a logging/setLevel request.
+type SetLevelParams struct {
+	Level string `json:"level"`
+}
+
+// LogMessageParams represents the parameters of a notifications/message
+// notification carrying one server log entry.
+type LogMessageParams struct {
+	Level  string          `json:"level"`
+	Logger string          `json:"logger,omitempty"`
+	Data   json.RawMessage `json:"data"`
 }
 
 // ToolsListResult represents the result of a tools/list request.
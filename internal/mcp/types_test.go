@@ -181,6 +181,28 @@ func TestToolMarshal(t *testing.T) {
 	}
 }
 
+func TestNestedOutputSchemaMarshal(t *testing.T) {
+	schema := OutputSchema{
+		Type: "object",
+		Properties: map[string]Property{
+			"items": {Type: "array", Description: "Items", Items: &Property{
+				Type: "object", Description: "One item",
+				Properties: map[string]Property{"id": {Type: "string", Description: "The ID"}},
+				Required:   []string{"id"},
+			}},
+		},
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	want := `"items":{"type":"object","description":"One item","properties":{"id":{"type":"string","description":"The ID"}},"required":["id"]}`
+	if !strings.Contains(string(data), want) {
+		t.Errorf("expected nested schema %s in %s", want, data)
+	}
+}
+
 func TestToolCallParamsUnmarshal(t *testing.T) {
 	input := `{"name":"get_roadmap","arguments":{"roadmap_id":"123"}}`
 	var params ToolCallParams
@@ -0,0 +1,230 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/analysis"
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+	"github.com/olgasafonova/productplan-mcp-server/internal/lint"
+	"github.com/olgasafonova/productplan-mcp-server/internal/mcp"
+)
+
+// analysisResponse wraps a computed result in a FormattedResponse.
+func analysisResponse(summary string, result any) (json.RawMessage, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(FormattedResponse{Summary: summary, Data: data})
+}
+
+func okrProgressHandler(client *api.Client, cal dates.Calendar) mcp.Handler {
+	return typedHandler[OKRProgressArgs](func(ctx context.Context, a OKRProgressArgs) (json.RawMessage, error) {
+		opts := analysis.OKROptions{ObjectiveIDs: a.ObjectiveIDs, TimeFrame: a.TimeFrame, Calendar: cal}
+		if a.RiskMargin != nil {
+			opts.RiskMargin = *a.RiskMargin
+		}
+		report, err := analysis.OKRProgress(ctx, client, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		n := len(report.Objectives)
+		summary := fmt.Sprintf("%d %s, %d at risk", n, pluralize("objective", n), report.AtRiskCount)
+		return analysisResponse(summary, report)
+	})
+}
+
+func objectiveCoverageHandler(client *api.Client, links analysis.LinkConventions) mcp.Handler {
+	return typedHandler[ObjectiveCoverageArgs](func(ctx context.Context, a ObjectiveCoverageArgs) (json.RawMessage, error) {
+		opts := analysis.CoverageOptions{RoadmapIDs: a.RoadmapIDs, Links: links, IncludeParked: a.IncludeParked}
+		if a.TagPrefix != nil {
+			opts.Links.TagPrefix = *a.TagPrefix
+		}
+		if a.CustomField != nil {
+			opts.Links.CustomField = *a.CustomField
+		}
+		report, err := analysis.StrategicCoverage(ctx, client, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		summary := fmt.Sprintf("%d of %d %s linked to objectives (%.1f%%), %d %s without supporting work",
+			report.LinkedBarCount, report.BarCount, pluralize("bar", report.BarCount), report.CoveragePct,
+			len(report.UncoveredObjectives), pluralize("objective", len(report.UncoveredObjectives)))
+		return analysisResponse(summary, report)
+	})
+}
+
+func analyzeDependenciesHandler(client *api.Client) mcp.Handler {
+	return typedHandler[GetRoadmapArgs](func(ctx context.Context, a GetRoadmapArgs) (json.RawMessage, error) {
+		report, err := analysis.AnalyzeDependencies(ctx, client, a.RoadmapID)
+		if err != nil {
+			return nil, err
+		}
+
+		edges, cycles, violations := len(report.Edges), len(report.Cycles), len(report.Violations)
+		summary := fmt.Sprintf("%d %s, %d %s, %d scheduling %s",
+			edges, pluralize("connection", edges), cycles, pluralize("cycle", cycles), violations, pluralize("violation", violations))
+		if n := len(report.CriticalPath.Bars); n > 0 {
+			summary += fmt.Sprintf("; critical path %d %s, %d days", n, pluralize("bar", n), report.CriticalPath.DurationDays)
+		}
+		return analysisResponse(summary, report)
+	})
+}
+
+func detectScheduleConflictsHandler(client *api.Client, cal dates.Calendar) mcp.Handler {
+	return typedHandler[DetectScheduleConflictsArgs](func(ctx context.Context, a DetectScheduleConflictsArgs) (json.RawMessage, error) {
+		opts := analysis.ConflictOptions{MaxConcurrent: intOr(a.MaxConcurrent, 0), Period: a.Period, Calendar: cal}
+		if a.Capacity != nil {
+			opts.Capacity = *a.Capacity
+		}
+		report, err := analysis.DetectScheduleConflicts(ctx, client, a.RoadmapID, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		overloaded := 0
+		for _, lane := range report.Lanes {
+			if len(lane.Overlaps) > 0 {
+				overloaded++
+			}
+		}
+		issues := len(report.DateIssues)
+		summary := fmt.Sprintf("%d %s over %d concurrent %s in %d %s, %d %s with date issues",
+			report.OverlapCount, pluralize("overlap", report.OverlapCount), report.MaxConcurrent, pluralize("bar", report.MaxConcurrent),
+			overloaded, pluralize("lane", overloaded), issues, pluralize("bar", issues))
+		return analysisResponse(summary, report)
+	})
+}
+
+func lintRoadmapHandler(client *api.Client) mcp.Handler {
+	return typedHandler[LintRoadmapArgs](func(ctx context.Context, a LintRoadmapArgs) (json.RawMessage, error) {
+		linter, err := lint.Default().Select(a.Rules, a.Skip)
+		if err != nil {
+			return nil, err
+		}
+		snap, err := lint.Load(ctx, client, a.RoadmapID, time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		report := linter.Lint(snap)
+
+		n := len(report.Findings)
+		summary := fmt.Sprintf("%d %s: %d %s, %d %s, %d info", n, pluralize("finding", n),
+			report.Errors, pluralize("error", report.Errors), report.Warnings, pluralize("warning", report.Warnings), report.Infos)
+		return analysisResponse(summary, report)
+	})
+}
+
+func findStaleItemsHandler(client *api.Client) mcp.Handler {
+	return typedHandler[FindStaleItemsArgs](func(ctx context.Context, a FindStaleItemsArgs) (json.RawMessage, error) {
+		report, err := analysis.FindStaleItems(ctx, client, analysis.StaleOptions{
+			Days:       intOr(a.Days, analysis.DefaultStaleDays),
+			Kinds:      a.Kinds,
+			RoadmapIDs: a.RoadmapIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		summary := fmt.Sprintf("%d %s untouched for %d+ days in %d %s", report.Total, pluralize("item", report.Total),
+			report.Days, len(report.Groups), pluralize("group", len(report.Groups)))
+		return analysisResponse(summary, report)
+	})
+}
+
+func clusterIdeasHandler(client *api.Client) mcp.Handler {
+	return typedHandler[ClusterIdeasArgs](func(ctx context.Context, a ClusterIdeasArgs) (json.RawMessage, error) {
+		opts := analysis.ClusterOptions{MinSize: intOr(a.MinSize, 2), IncludeClosed: a.IncludeClosed}
+		if a.Threshold != nil {
+			opts.Threshold = *a.Threshold
+		}
+		report, err := analysis.ClusterIdeas(ctx, client, opts)
+		if err != nil {
+			return nil, err
+		}
+		n := len(report.Clusters)
+		summary := fmt.Sprintf("%d %s covering %d of %d %s", n, pluralize("cluster", n),
+			report.Clustered, report.Ideas, pluralize("idea", report.Ideas))
+		return analysisResponse(summary, report)
+	})
+}
+
+func rankCustomerDemandHandler(client *api.Client) mcp.Handler {
+	return typedHandler[RankCustomerDemandArgs](func(ctx context.Context, a RankCustomerDemandArgs) (json.RawMessage, error) {
+		opts := analysis.DemandOptions{
+			CustomerValues: a.CustomerValues,
+			Limit:          intOr(a.Limit, analysis.DefaultDemandLimit),
+			IncludeClosed:  a.IncludeClosed,
+		}
+		if a.DefaultValue != nil {
+			opts.DefaultValue = *a.DefaultValue
+		}
+		report, err := analysis.RankCustomerDemand(ctx, client, opts)
+		if err != nil {
+			return nil, err
+		}
+		summary := fmt.Sprintf("Demand from %d %s", report.Ideas, pluralize("idea", report.Ideas))
+		if len(report.Opportunities) > 0 {
+			summary += fmt.Sprintf("; top opportunity %q (score %g)", report.Opportunities[0].Name, report.Opportunities[0].Score)
+		}
+		if len(report.Tags) > 0 {
+			summary += fmt.Sprintf("; top tag %q (score %g)", report.Tags[0].Name, report.Tags[0].Score)
+		}
+		return analysisResponse(summary, report)
+	})
+}
+
+func launchReadinessHandler(client *api.Client) mcp.Handler {
+	return typedHandler[GetLaunchArgs](func(ctx context.Context, a GetLaunchArgs) (json.RawMessage, error) {
+		report, err := analysis.LaunchReadinessReport(ctx, client, a.LaunchID, time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		summary := fmt.Sprintf("Launch %q: %s", report.Name, strings.ToUpper(strings.ReplaceAll(report.Verdict, "_", "-")))
+		if report.Percent != nil {
+			summary += fmt.Sprintf("; %d of %d tasks done", report.Done, report.Tasks)
+		}
+		if d := report.DaysUntilLaunch; d != nil {
+			switch {
+			case *d >= 0:
+				summary += fmt.Sprintf("; %d %s to launch", *d, pluralize("day", *d))
+			default:
+				summary += fmt.Sprintf("; launch date passed %d %s ago", -*d, pluralize("day", -*d))
+			}
+		}
+		return analysisResponse(summary, report)
+	})
+}
+
+func userWorkloadHandler(client *api.Client, cal dates.Calendar) mcp.Handler {
+	return typedHandler[UserWorkloadArgs](func(ctx context.Context, a UserWorkloadArgs) (json.RawMessage, error) {
+		report, err := analysis.UserWorkload(ctx, client, analysis.WorkloadOptions{
+			WeeklyLimit: intOr(a.WeeklyLimit, analysis.DefaultWeeklyTaskLimit),
+			RoadmapIDs:  a.RoadmapIDs,
+			SkipBars:    a.SkipBars,
+			Calendar:    cal,
+		})
+		if err != nil {
+			return nil, err
+		}
+		var overloaded []string
+		for _, u := range report.Users {
+			if u.Overloaded {
+				overloaded = append(overloaded, u.Name)
+			}
+		}
+		n := len(report.Users)
+		summary := fmt.Sprintf("%d %s with open work; %d overloaded", n, pluralize("user", n), len(overloaded))
+		if len(overloaded) > 0 {
+			summary += " (" + strings.Join(overloaded, ", ") + ")"
+		}
+		summary += fmt.Sprintf("; %d unassigned %s", report.Unassigned, pluralize("task", report.Unassigned))
+		return analysisResponse(summary, report)
+	})
+}
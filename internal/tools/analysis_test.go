@@ -0,0 +1,309 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/analysis"
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+	"github.com/olgasafonova/productplan-mcp-server/internal/lint"
+)
+
+func TestOKRProgressHandler(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/strategy/objectives":               `[{"id": 1, "name": "Grow", "time_frame": "2020"}]`,
+		"/strategy/objectives/1/key_results": `[{"id": 11, "name": "MRR", "current_value": 10, "target_value": 100}]`,
+	})
+
+	result, err := okrProgressHandler(client, dates.Calendar{}).Handle(context.Background(), map[string]any{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary, report := decodeResponse[analysis.OKRReport](t, result)
+	if summary != "1 objective, 1 at risk" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	if len(report.Objectives) != 1 || report.Objectives[0].Status != analysis.StatusAtRisk {
+		t.Errorf("expected the 2020 objective at 10%% to be at risk, got %+v", report.Objectives)
+	}
+}
+
+func TestOKRProgressArgsValidate(t *testing.T) {
+	margin := 0.0
+	err := OKRProgressArgs{RiskMargin: &margin}.Validate()
+	if err == nil || !strings.Contains(err.Error(), "risk_margin") {
+		t.Errorf("expected risk_margin error, got %v", err)
+	}
+}
+
+func TestObjectiveCoverageHandler(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/strategy/objectives":               `[{"id": 1, "name": "Grow"}, {"id": 2, "name": "Retain"}]`,
+		"/strategy/objectives/1/key_results": `[]`,
+		"/strategy/objectives/2/key_results": `[]`,
+		"/roadmaps/7":                        `{"id": 7, "name": "Platform"}`,
+		"/roadmaps/7/bars": `[
+			{"id": 70, "name": "Billing", "tags": ["goal/Grow"]},
+			{"id": 71, "name": "Refactor", "tags": ["okr:Grow"]}
+		]`,
+	})
+
+	handler := objectiveCoverageHandler(client, analysis.DefaultLinkConventions())
+	result, err := handler.Handle(context.Background(), map[string]any{
+		"roadmap_ids": []any{"7"},
+		"tag_prefix":  "goal/",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary, report := decodeResponse[analysis.CoverageReport](t, result)
+	if summary != "1 of 2 bars linked to objectives (50.0%), 1 objective without supporting work" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	if report.Conventions.TagPrefix != "goal/" || report.Conventions.CustomField != "Objective" {
+		t.Errorf("expected tag_prefix override on top of defaults, got %+v", report.Conventions)
+	}
+	if len(report.UncoveredObjectives) != 1 || report.UncoveredObjectives[0].Name != "Retain" {
+		t.Errorf("expected Retain uncovered, got %+v", report.UncoveredObjectives)
+	}
+}
+
+func TestObjectiveCoverageArgsValidate(t *testing.T) {
+	if err := (ObjectiveCoverageArgs{RoadmapIDs: []string{" "}}).Validate(); err == nil {
+		t.Error("expected error for empty roadmap ID")
+	}
+	if err := (ObjectiveCoverageArgs{}).Validate(); err != nil {
+		t.Errorf("expected no error for all roadmaps, got %v", err)
+	}
+}
+
+func TestAnalyzeDependenciesHandler(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/roadmaps/9":         `{"id": 9, "name": "Platform"}`,
+		"/roadmaps/9/bars":    `[{"id": 1, "name": "API", "starts_on": "2026-01-01", "ends_on": "2026-01-31"}, {"id": 2, "name": "UI", "starts_on": "2026-01-20", "ends_on": "2026-02-28"}]`,
+		"/bars/1/connections": `[{"id": 50, "target_bar_id": 2}]`,
+		"/bars/2/connections": `[]`,
+	})
+
+	result, err := analyzeDependenciesHandler(client).Handle(context.Background(), map[string]any{"roadmap_id": "9"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary, report := decodeResponse[analysis.DependencyReport](t, result)
+	if summary != "1 connection, 0 cycles, 1 scheduling violation; critical path 2 bars, 71 days" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	if len(report.Violations) != 1 || report.Violations[0].OverlapDays != 12 {
+		t.Errorf("expected a 12-day overlap, got %+v", report.Violations)
+	}
+
+	if _, err := analyzeDependenciesHandler(client).Handle(context.Background(), map[string]any{}); err == nil {
+		t.Error("expected error without roadmap_id")
+	}
+}
+
+func TestDetectScheduleConflictsHandler(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/roadmaps/9":       `{"id": 9, "name": "Platform"}`,
+		"/roadmaps/9/lanes": `[{"id": 3, "name": "Backend"}]`,
+		"/roadmaps/9/bars": `[
+			{"id": 1, "lane_id": 3, "name": "A", "starts_on": "2026-01-01", "ends_on": "2026-01-31"},
+			{"id": 2, "lane_id": 3, "name": "B", "starts_on": "2026-01-10", "ends_on": "2026-01-20"},
+			{"id": 3, "lane_id": 3, "name": "C"}
+		]`,
+	})
+
+	result, err := detectScheduleConflictsHandler(client, dates.Calendar{}).Handle(context.Background(), map[string]any{
+		"roadmap_id":     "9",
+		"max_concurrent": 1,
+		"period":         "week",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary, report := decodeResponse[analysis.ConflictReport](t, result)
+	if summary != "1 overlap over 1 concurrent bar in 1 lane, 1 bar with date issues" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	if report.Period != "week" || report.Lanes[0].Overlaps[0].StartsOn != "2026-01-10" {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+func TestDetectScheduleConflictsArgsValidate(t *testing.T) {
+	zero, negative := 0, -1.0
+	tests := []DetectScheduleConflictsArgs{
+		{},
+		{RoadmapID: "9", MaxConcurrent: &zero},
+		{RoadmapID: "9", Period: "year"},
+		{RoadmapID: "9", Capacity: &negative},
+	}
+	for _, a := range tests {
+		if err := a.Validate(); err == nil {
+			t.Errorf("expected error for %+v", a)
+		}
+	}
+}
+
+func TestLintRoadmapHandler(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/roadmaps/9":            `{"id": 9, "name": "Platform"}`,
+		"/roadmaps/9/bars":       `[{"id": 1, "name": "A", "lane_id": 3, "legend_id": 4, "description": "x"}]`,
+		"/roadmaps/9/lanes":      `[{"id": 3, "name": "Web"}, {"id": 5, "name": "Empty"}]`,
+		"/roadmaps/9/milestones": `[]`,
+		"/bars/1/links":          `[{"id": 7, "url": "not a url"}]`,
+	})
+
+	result, err := lintRoadmapHandler(client).Handle(context.Background(), map[string]any{
+		"roadmap_id": "9",
+		"skip":       []any{"past_milestone"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary, report := decodeResponse[lint.Report](t, result)
+	if summary != "2 findings: 1 error, 0 warnings, 1 info" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	if report.Findings[0].Rule != "invalid_link" || report.Findings[1].Rule != "empty_lane" {
+		t.Errorf("unexpected findings %+v", report.Findings)
+	}
+
+	_, err = lintRoadmapHandler(client).Handle(context.Background(), map[string]any{"roadmap_id": "9", "rules": []any{"typo"}})
+	if err == nil || !strings.Contains(err.Error(), "unknown lint rule") {
+		t.Errorf("expected unknown rule error, got %v", err)
+	}
+}
+
+func TestFindStaleItemsHandler(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/discovery/ideas": `[{"id": 1, "name": "Old", "owner_name": "Ana", "updated_at": "2020-01-01"}, {"id": 2, "name": "Also old", "owner_name": "Ana", "updated_at": "2020-06-01"}]`,
+	})
+
+	result, err := findStaleItemsHandler(client).Handle(context.Background(), map[string]any{
+		"days":  30,
+		"kinds": []any{"idea"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary, report := decodeResponse[analysis.StaleReport](t, result)
+	if summary != "2 items untouched for 30+ days in 1 group" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	if report.Groups[0].Group != "Ana" || report.Groups[0].Items[0].ID != "1" {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	zero := 0
+	if err := (FindStaleItemsArgs{Days: &zero}).Validate(); err == nil {
+		t.Error("expected error for days 0")
+	}
+}
+
+func TestClusterIdeasHandler(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/discovery/ideas": `[
+			{"id": 1, "name": "Slack notifications", "customers": ["Acme"]},
+			{"id": 2, "name": "Notifications in Slack", "customers": ["Globex"]},
+			{"id": 3, "name": "Gantt view"}
+		]`,
+	})
+
+	result, err := clusterIdeasHandler(client).Handle(context.Background(), map[string]any{"threshold": 0.6})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary, report := decodeResponse[analysis.IdeaClusterReport](t, result)
+	if summary != "1 cluster covering 2 of 3 ideas" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	if report.Clusters[0].CustomerCount != 2 || report.Clusters[0].Keep.ID != "1" {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	high := 1.5
+	if err := (ClusterIdeasArgs{Threshold: &high}).Validate(); err == nil {
+		t.Error("expected error for threshold above 1")
+	}
+}
+
+func TestRankCustomerDemandHandler(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/discovery/ideas": `[
+			{"id": 1, "customers": ["Acme"], "tags": ["Billing"], "opportunity_ids": [5]},
+			{"id": 2, "customers": ["Globex"], "tags": ["Search"]}
+		]`,
+		"/discovery/opportunities": `[{"id": 5, "problem_statement": "Invoices are confusing"}]`,
+	})
+
+	result, err := rankCustomerDemandHandler(client).Handle(context.Background(), map[string]any{
+		"customer_values": map[string]any{"Globex": 3},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary, report := decodeResponse[analysis.DemandReport](t, result)
+	if summary != `Demand from 2 ideas; top opportunity "Invoices are confusing" (score 1); top tag "Search" (score 3)` {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	if !report.Weighted || report.Customers[0].Name != "Globex" {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	if err := (RankCustomerDemandArgs{CustomerValues: map[string]float64{"Acme": -1}}).Validate(); err == nil {
+		t.Error("expected error for a negative customer value")
+	}
+}
+
+func TestLaunchReadinessHandler(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/launches/1":                    `{"id": 1, "name": "v2"}`,
+		"/launches/1/checklist_sections": `[{"id": 10, "name": "Engineering"}]`,
+		"/launches/1/tasks":              `[{"id": 100, "name": "Docs", "section_id": 10, "status": "done"}, {"id": 101, "name": "QA", "section_id": 10, "assigned_user_id": 7}]`,
+		"/users":                         `[{"id": 7, "first_name": "Ana", "last_name": "Lee"}]`,
+	})
+
+	result, err := launchReadinessHandler(client).Handle(context.Background(), map[string]any{"launch_id": "1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary, report := decodeResponse[analysis.LaunchReadiness](t, result)
+	if summary != `Launch "v2": GO; 1 of 2 tasks done` {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	if report.DaysUntilLaunch != nil || report.Sections[0].Tasks != 2 {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	if _, err := launchReadinessHandler(client).Handle(context.Background(), map[string]any{}); err == nil {
+		t.Error("expected error without launch_id")
+	}
+}
+
+func TestUserWorkloadHandler(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/users":            `[{"id": 7, "first_name": "Ana", "last_name": "Lee"}]`,
+		"/teams":            `[]`,
+		"/launches":         `[{"id": 1, "name": "v2"}]`,
+		"/launches/1/tasks": `[{"id": 100, "name": "Docs", "assigned_user_id": 7, "due_date": "2020-01-01"}, {"id": 101, "name": "QA"}]`,
+	})
+
+	result, err := userWorkloadHandler(client, dates.Calendar{}).Handle(context.Background(), map[string]any{"weekly_limit": 1, "skip_bars": true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary, report := decodeResponse[analysis.WorkloadReport](t, result)
+	if summary != "1 user with open work; 1 overloaded (Ana Lee); 1 unassigned task" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	if report.Users[0].Overdue != 1 || report.WeeklyLimit != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	if _, err := userWorkloadHandler(client, dates.Calendar{}).Handle(context.Background(), map[string]any{"weekly_limit": 0}); err == nil {
+		t.Error("expected error for weekly_limit 0")
+	}
+}
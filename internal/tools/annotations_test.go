@@ -129,8 +129,8 @@ func TestReadOnlyToolsHaveOutputSchema(t *testing.T) {
 		}
 	}
 
-	if roCount != 35 {
-		t.Errorf("expected 35 read-only tools, found %d", roCount)
+	if roCount != 38 {
+		t.Errorf("expected 38 read-only tools, found %d", roCount)
 	}
 }
 
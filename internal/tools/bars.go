@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/olgasafonova/productplan-mcp-server/internal/analysis"
 	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/barpayload"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
 	"github.com/olgasafonova/productplan-mcp-server/internal/mcp"
 )
 
@@ -16,20 +19,6 @@ func setIfNotEmpty(payload map[string]any, key, value string) {
 	}
 }
 
-// setIfNotNil adds a key-value pair to the payload if the pointer is not nil.
-func setIfNotNil[T any](payload map[string]any, key string, value *T) {
-	if value != nil {
-		payload[key] = *value
-	}
-}
-
-// setIfNotEmptySlice adds a key-value pair to the payload if the slice is not empty.
-func setIfNotEmptySlice[T any](payload map[string]any, key string, value []T) {
-	if len(value) > 0 {
-		payload[key] = value
-	}
-}
-
 func getBarHandler(client *api.Client) mcp.Handler {
 	return typedHandler[GetBarArgs](func(ctx context.Context, a GetBarArgs) (json.RawMessage, error) {
 		data, err := client.GetBar(ctx, a.BarID)
@@ -50,6 +39,24 @@ func getBarChildrenHandler(client *api.Client) mcp.Handler {
 	})
 }
 
+func getBarTreeHandler(client *api.Client) mcp.Handler {
+	return typedHandler[GetBarTreeArgs](func(ctx context.Context, a GetBarTreeArgs) (json.RawMessage, error) {
+		tree, err := analysis.GetBarTree(ctx, client, a.BarID, intOr(a.MaxDepth, analysis.DefaultTreeDepth))
+		if err != nil {
+			return nil, err
+		}
+		summary := fmt.Sprintf("Bar tree for %q: %d %s, %d %s deep", tree.Root.Name, tree.Bars, pluralize("bar", tree.Bars),
+			tree.Depth, pluralize("level", tree.Depth))
+		if tree.Cycles > 0 {
+			summary += fmt.Sprintf("; %d %s skipped", tree.Cycles, pluralize("cycle", tree.Cycles))
+		}
+		if tree.Truncated > 0 {
+			summary += fmt.Sprintf("; %d %s cut off at max_depth", tree.Truncated, pluralize("bar", tree.Truncated))
+		}
+		return analysisResponse(summary, tree)
+	})
+}
+
 func getBarCommentsHandler(client *api.Client) mcp.Handler {
 	return typedHandler[GetBarArgs](func(ctx context.Context, a GetBarArgs) (json.RawMessage, error) {
 		data, err := client.GetBarComments(ctx, a.BarID)
@@ -80,25 +87,29 @@ func getBarLinksHandler(client *api.Client) mcp.Handler {
 	})
 }
 
-func manageBarHandler(client *api.Client) mcp.Handler {
+// manageBarHandler creates, updates or deletes a bar. Start and end dates
+// may be expressions, resolved against cal before the request.
+func manageBarHandler(client *api.Client, cal dates.Calendar) mcp.Handler {
 	return typedHandler[ManageBarArgs](func(ctx context.Context, a ManageBarArgs) (json.RawMessage, error) {
 		var data json.RawMessage
 		var err error
 
+		var notes []string
+		if a.Action == "create" || a.Action == "update" {
+			if notes, err = resolveDates(ctx, client, cal, a.RoadmapID,
+				dateArg{"starts_on", &a.StartsOn}, dateArg{"ends_on", &a.EndsOn}); err != nil {
+				return nil, err
+			}
+		}
+
 		switch a.Action {
 		case "create":
-			payload := map[string]any{
-				"roadmap_id": a.RoadmapID,
-				"lane_id":    a.LaneID,
-				"name":       a.Name,
-			}
-			addBarOptionalFields(payload, a)
-			data, err = client.CreateBar(ctx, payload)
+			data, err = client.CreateBar(ctx, barpayload.Create(a.Fields))
 		case "update":
 			payload := make(map[string]any)
 			setIfNotEmpty(payload, "name", a.Name)
 			setIfNotEmpty(payload, "lane_id", a.LaneID)
-			addBarOptionalFields(payload, a)
+			barpayload.AddOptional(payload, a.Fields)
 			data, err = client.UpdateBar(ctx, a.BarID, payload)
 		case "delete":
 			data, err = client.DeleteBar(ctx, a.BarID)
@@ -109,28 +120,14 @@ func manageBarHandler(client *api.Client) mcp.Handler {
 		if err != nil {
 			return nil, err
 		}
-		return FormatAction(data, a.Action, "bar", a.BarID)
+		resp, err := FormatAction(data, a.Action, "bar", a.BarID)
+		if err != nil {
+			return nil, err
+		}
+		return withDateNotes(resp, notes)
 	})
 }
 
-// addBarOptionalFields adds optional bar fields to the payload.
-func addBarOptionalFields(payload map[string]any, a ManageBarArgs) {
-	setIfNotEmpty(payload, "starts_on", a.StartsOn)
-	setIfNotEmpty(payload, "ends_on", a.EndsOn)
-	setIfNotEmpty(payload, "description", a.Description)
-	setIfNotEmpty(payload, "legend_id", a.LegendID)
-	setIfNotEmpty(payload, "parent_id", a.ParentID)
-	setIfNotEmpty(payload, "strategic_value", a.StrategicValue)
-	setIfNotEmpty(payload, "notes", a.Notes)
-	setIfNotNil(payload, "percent_done", a.PercentDone)
-	setIfNotNil(payload, "container", a.Container)
-	setIfNotNil(payload, "parked", a.Parked)
-	setIfNotNil(payload, "effort", a.Effort)
-	setIfNotEmptySlice(payload, "tags", a.Tags)
-	setIfNotEmptySlice(payload, "custom_text_fields", a.CustomTextFields)
-	setIfNotEmptySlice(payload, "custom_dropdown_fields", a.CustomDropdownFields)
-}
-
 func manageBarConnectionHandler(client *api.Client) mcp.Handler {
 	return typedHandler[ManageBarConnectionArgs](func(ctx context.Context, a ManageBarConnectionArgs) (json.RawMessage, error) {
 		var data json.RawMessage
@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+)
+
+// dateArg points at a date argument that may hold an expression.
+type dateArg struct {
+	field string
+	value *string
+}
+
+// resolveDates replaces date expressions in args with YYYY-MM-DD dates in
+// place and returns a note for each one it resolved, to echo in the
+// response. YYYY-MM-DD values pass through untouched. milestone:<name>
+// references are looked up among roadmapID's milestones, fetched once.
+func resolveDates(ctx context.Context, client *api.Client, cal dates.Calendar, roadmapID string, args ...dateArg) ([]string, error) {
+	var milestones []api.Milestone
+	r := dates.Resolver{Calendar: cal, Milestone: func(name string) (time.Time, error) {
+		if roadmapID == "" {
+			return time.Time{}, fmt.Errorf("milestone references need roadmap_id")
+		}
+		if milestones == nil {
+			var err error
+			if milestones, err = client.FetchRoadmapMilestones(ctx, roadmapID); err != nil {
+				return time.Time{}, fmt.Errorf("roadmap %s milestones: %w", roadmapID, err)
+			}
+		}
+		return milestoneDate(milestones, name)
+	}}
+
+	var notes []string
+	for _, a := range args {
+		expr := strings.TrimSpace(*a.value)
+		if expr == "" || dates.IsISODate(expr) {
+			continue
+		}
+		d, err := r.Resolve(expr)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", a.field, err)
+		}
+		*a.value = d.Format(time.DateOnly)
+		notes = append(notes, fmt.Sprintf("%s %q = %s", a.field, expr, *a.value))
+	}
+	return notes, nil
+}
+
+// milestoneDate finds a milestone by name, case-insensitively.
+func milestoneDate(milestones []api.Milestone, name string) (time.Time, error) {
+	labels := make([]string, 0, len(milestones))
+	for _, m := range milestones {
+		if !strings.EqualFold(strings.TrimSpace(m.Label()), name) {
+			labels = append(labels, m.Label())
+			continue
+		}
+		d, ok := api.ParseDate(m.Date)
+		if !ok {
+			return time.Time{}, fmt.Errorf("milestone %q has no date", m.Label())
+		}
+		return d, nil
+	}
+	if len(labels) == 0 {
+		return time.Time{}, fmt.Errorf("no milestone named %q; the roadmap has none", name)
+	}
+	return time.Time{}, fmt.Errorf("no milestone named %q (milestones: %s)", name, strings.Join(labels, ", "))
+}
+
+// withDateNotes appends resolved date notes to a formatted response's
+// summary, so the caller sees which dates its expressions became.
+func withDateNotes(raw json.RawMessage, notes []string) (json.RawMessage, error) {
+	if len(notes) == 0 {
+		return raw, nil
+	}
+	var resp FormattedResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, err
+	}
+	resp.Summary += " (resolved " + strings.Join(notes, ", ") + ")"
+	return json.Marshal(resp)
+}
@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+)
+
+func TestManageBarResolvesDateExpressions(t *testing.T) {
+	var sent map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/roadmaps/1/milestones":
+			_, _ = w.Write([]byte(`[{"id": 9, "title": "Public Beta", "date": "2026-07-01"}]`))
+		case "/bars":
+			_ = json.NewDecoder(r.Body).Decode(&sent)
+			_, _ = w.Write([]byte(`{"id": 5}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := api.New(api.Config{Token: "test-token", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	result, err := manageBarHandler(client, dates.Calendar{FiscalYearStart: time.February}).Handle(context.Background(), map[string]any{
+		"action": "create", "roadmap_id": "1", "lane_id": "2", "name": "GA",
+		"starts_on": "milestone:public beta+7d", "ends_on": "end of Q3 2027",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sent["starts_on"] != "2026-07-08" || sent["ends_on"] != "2026-10-31" {
+		t.Errorf("unexpected payload %v", sent)
+	}
+	summary, _ := decodeResponse[map[string]any](t, result)
+	if !strings.Contains(summary, `starts_on "milestone:public beta+7d" = 2026-07-08`) || !strings.Contains(summary, `ends_on "end of Q3 2027" = 2026-10-31`) {
+		t.Errorf("unexpected summary %q", summary)
+	}
+
+	// Plain dates pass through without a note.
+	if result, err = manageBarHandler(client, dates.Calendar{}).Handle(context.Background(), map[string]any{
+		"action": "create", "roadmap_id": "1", "lane_id": "2", "name": "GA", "starts_on": "2026-01-05",
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary, _ = decodeResponse[map[string]any](t, result); summary != "Bar created successfully" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+
+	for _, args := range []map[string]any{
+		{"action": "create", "roadmap_id": "1", "lane_id": "2", "name": "GA", "ends_on": "milestone:GA"},
+		{"action": "create", "roadmap_id": "1", "lane_id": "2", "name": "GA", "ends_on": "whenever"},
+	} {
+		if _, err = manageBarHandler(client, dates.Calendar{}).Handle(context.Background(), args); err == nil || !strings.Contains(err.Error(), "ends_on") {
+			t.Errorf("expected an ends_on error for %v, got %v", args["ends_on"], err)
+		}
+	}
+	if _, err = manageLaunchTaskHandler(client, dates.Calendar{}).Handle(context.Background(), map[string]any{
+		"action": "update", "launch_id": "1", "task_id": "2", "due_date": "milestone:Public Beta",
+	}); err == nil || !strings.Contains(err.Error(), "need roadmap_id") {
+		t.Errorf("expected a milestone error for a task, got %v", err)
+	}
+}
@@ -32,6 +32,17 @@ func readOutputSchema() *mcp.OutputSchema {
 	}
 }
 
+// dateExprProperty describes a date argument that also takes expressions,
+// resolved by the handler before the request. Milestone references need a
+// roadmap, so tools without one leave them out of the hint.
+func dateExprProperty(what string, milestones bool, example string) mcp.Property {
+	hint := "+2w, next monday, end of Q3 2026"
+	if milestones {
+		hint += ", milestone:Beta+7d"
+	}
+	return mcp.Property{Type: "string", Description: what + ": YYYY-MM-DD or an expression (" + hint + ")", Examples: []any{example, "end of Q3 2026"}}
+}
+
 // BuildAllTools returns all ProductPlan tool definitions for MCP.
 func BuildAllTools() []mcp.Tool {
 	var tools []mcp.Tool
@@ -54,6 +65,15 @@ func BuildAllTools() []mcp.Tool {
 	// Utility
 	tools = append(tools, utilityTools()...)
 
+	// Exports and reports
+	tools = append(tools, reportTools()...)
+
+	// Analyses
+	tools = append(tools, analysisTools()...)
+
+	// Planning
+	tools = append(tools, planningTools()...)
+
 	// Auto-annotate based on the tool name prefix.
 	//
 	// Read-only (get_*, list_*, check_*, health_check):
@@ -291,7 +311,8 @@ FAILS WHEN: create without name, update/delete without lane_id (get IDs from get
 USE WHEN: "Add launch milestone", "Move demo date", "Delete milestone"
 Actions: create (title+date), update (milestone_id), delete (milestone_id)
 Returns the created/updated milestone object, or confirmation on delete.
-FAILS WHEN: create without title or date, update/delete without milestone_id (get IDs from get_roadmap_milestones), date not in YYYY-MM-DD format.`,
+Dates may be expressions such as "end of Q3 2026", "+2w" or "milestone:Beta+7d"; quarters follow the configured fiscal year, and the summary shows the date each resolved to.
+FAILS WHEN: create without title or date, update/delete without milestone_id (get IDs from get_roadmap_milestones), date expression cannot be resolved.`,
 			InputSchema: mcp.InputSchema{
 				Type: "object",
 				Properties: map[string]mcp.Property{
@@ -299,7 +320,7 @@ FAILS WHEN: create without title or date, update/delete without milestone_id (ge
 					"roadmap_id":   {Type: "string", Description: "Roadmap ID"},
 					"milestone_id": {Type: "string", Description: "Milestone ID (for update/delete)"},
 					"title":        {Type: "string", Description: "Milestone title"},
-					"date":         {Type: "string", Description: "YYYY-MM-DD format", Pattern: `^\d{4}-\d{2}-\d{2}$`, Examples: []any{"2025-06-01"}},
+					"date":         dateExprProperty("Milestone date", true, "2025-06-01"),
 				},
 				Required: []string{"action", "roadmap_id"},
 			},
@@ -345,6 +366,24 @@ FAILS WHEN: bar_id not found. Returns empty list if bar has no children (not all
 				Required: []string{"bar_id"},
 			},
 		},
+		{
+			Name: "get_bar_tree",
+			Description: `Get a bar's whole hierarchy as a nested tree with rolled-up dates, effort and progress, plus an indented text outline.
+
+USE WHEN: "Break down this epic", "Show everything under this container", "How far along is this initiative overall?"
+Walks child bars recursively, fetching each level in parallel (one call per bar). Each node carries span_start/span_end covering its own and its descendants' dates, total_effort summed over leaf bars, and avg_percent_done averaged over leaf bars that report it.
+A bar that appears twice (a cycle) is marked and not expanded again; bars at max_depth are marked truncated. For one level only, use get_bar_children.
+FAILS WHEN: bar_id not found (get valid IDs from get_roadmap_bars).`,
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"bar_id":    {Type: "string", Description: "Root bar ID"},
+					"max_depth": {Type: "integer", Description: "Levels below the root to load (default 10)", Minimum: floatPtr(1), Maximum: floatPtr(20)},
+				},
+				Required: []string{"bar_id"},
+			},
+			OutputSchema: analysisOutputSchema(barTreeData),
+		},
 		{
 			Name: "get_bar_comments",
 			Description: `Get comments on a bar.
@@ -401,6 +440,7 @@ func barManageTools() []mcp.Tool {
 USE WHEN: "Add feature", "Update dates", "Delete item", "Change color"
 Actions: create (roadmap_id+lane_id+name), update (bar_id), delete (bar_id)
 Returns the created/updated bar object with all fields, or confirmation on delete.
+Dates may be expressions such as "end of Q3 2026", "next monday" or "milestone:Beta+7d" (milestones need roadmap_id); quarters follow the configured fiscal year, and the summary shows the date each resolved to.
 FAILS WHEN: create without roadmap_id, lane_id, or name (all three required). Update/delete without bar_id. Use get_roadmap_legends for valid legend_id values. WARNING: delete is permanent and cannot be undone.`,
 			InputSchema: mcp.InputSchema{
 				Type: "object",
@@ -410,8 +450,8 @@ FAILS WHEN: create without roadmap_id, lane_id, or name (all three required). Up
 					"roadmap_id":             {Type: "string", Description: "Roadmap ID (for create)"},
 					"lane_id":                {Type: "string", Description: "Lane ID (for create; update to move)"},
 					"name":                   {Type: "string", Description: "Bar name"},
-					"starts_on":              {Type: "string", Description: "Start date YYYY-MM-DD", Pattern: `^\d{4}-\d{2}-\d{2}$`, Examples: []any{"2025-03-15"}},
-					"ends_on":                {Type: "string", Description: "End date YYYY-MM-DD", Pattern: `^\d{4}-\d{2}-\d{2}$`, Examples: []any{"2025-06-30"}},
+					"starts_on":              dateExprProperty("Start date", true, "2025-03-15"),
+					"ends_on":                dateExprProperty("End date", true, "2025-06-30"),
 					"description":            {Type: "string", Description: "Description (markdown)"},
 					"legend_id":              {Type: "string", Description: "Color from get_roadmap_legends"},
 					"percent_done":           {Type: "integer", Description: "Progress 0-100", Minimum: floatPtr(0), Maximum: floatPtr(100)},
@@ -745,7 +785,7 @@ FAILS WHEN: create without problem_statement, update without opportunity_id (get
 					"opportunity_id":    {Type: "string", Description: "Opportunity ID (for update)"},
 					"problem_statement": {Type: "string", Description: "Problem statement (title)"},
 					"description":       {Type: "string", Description: "Description"},
-					"workflow_status":   {Type: "string", Description: "Opportunity workflow status", Enum: []string{"draft", "in_discovery", "validated", "invalidated", "completed"}},
+					"workflow_status":   {Type: "string", Description: "Opportunity workflow status", Enum: opportunityStatuses},
 				},
 				Required: []string{"action"},
 			},
@@ -909,6 +949,7 @@ FAILS WHEN: launch_id or task_id not found.`,
 USE WHEN: "Add task", "Mark complete", "Assign task", "Delete task"
 Actions: create (name+section_id), update (task_id), delete (task_id)
 Returns the created/updated task object, or confirmation on delete.
+due_date may be an expression such as "+2w" or "friday"; the summary shows the date it resolved to.
 FAILS WHEN: create without name or section_id, update/delete without task_id (get IDs from get_launch_tasks). Use list_users to get valid assigned_user_id values.`,
 			InputSchema: mcp.InputSchema{
 				Type: "object",
@@ -919,7 +960,7 @@ FAILS WHEN: create without name or section_id, update/delete without task_id (ge
 					"section_id":       {Type: "string", Description: "Section ID (for create)"},
 					"name":             {Type: "string", Description: "Task name"},
 					"description":      {Type: "string", Description: "Task description"},
-					"due_date":         {Type: "string", Description: "YYYY-MM-DD", Pattern: `^\d{4}-\d{2}-\d{2}$`},
+					"due_date":         dateExprProperty("Due date", false, "2025-04-01"),
 					"assigned_user_id": {Type: "string", Description: "User ID to assign (get from list_users)"},
 					"status":           {Type: "string", Description: "Task status", Enum: []string{"to_do", "in_progress", "completed", "blocked"}},
 				},
@@ -979,5 +1020,31 @@ For individual user details, use list_users instead.`,
 				Properties: map[string]mcp.Property{},
 			},
 		},
+		{
+			Name: "get_timeframe",
+			Description: `Map dates to the server's fiscal calendar: fiscal year, half, quarter, month and week, with the bounds of each.
+
+USE WHEN: "What fiscal quarter is it?", "When does this quarter end?", "Which quarter is 2026-11-03 in?", "What week starts Monday?"
+Quarters, halves and years follow the configured fiscal year start, and weeks the configured week start; calendar describes both. Use this before quoting quarter dates rather than assuming calendar quarters.
+Each entry of dates may be YYYY-MM-DD or an expression such as "today", "+2w", "end of next quarter" or "start of FY2027". Defaults to today.`,
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"dates": {Type: "array", Description: "Dates or date expressions to map (default: today)", Items: &mcp.Property{Type: "string", Description: "Date or expression"}},
+				},
+			},
+			OutputSchema: analysisOutputSchema(timeframeData),
+		},
+		{
+			Name: "list_workspaces",
+			Description: `List the ProductPlan workspaces (accounts) this server can reach.
+
+USE WHEN: "Which accounts are connected?", "Compare sandbox and production roadmaps"
+Returns each workspace's name, API base URL and default roadmap; the default workspace serves calls without a workspace argument. When more than one is configured, every tool that calls ProductPlan takes an optional workspace argument naming one of them.`,
+			InputSchema: mcp.InputSchema{
+				Type:       "object",
+				Properties: map[string]mcp.Property{},
+			},
+		},
 	}
 }
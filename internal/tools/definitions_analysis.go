@@ -0,0 +1,863 @@
+package tools
+
+import (
+	"strings"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/analysis"
+	"github.com/olgasafonova/productplan-mcp-server/internal/lint"
+	"github.com/olgasafonova/productplan-mcp-server/internal/mcp"
+)
+
+// analysisOutputSchema describes a FormattedResponse whose data is the
+// given computed object. Analysis tools document their data field by field
+// so Code Mode clients can rely on the shape.
+func analysisOutputSchema(data mcp.Property) *mcp.OutputSchema {
+	return &mcp.OutputSchema{
+		Type: "object",
+		Properties: map[string]mcp.Property{
+			"summary": {Type: "string", Description: "Human-readable summary of the result"},
+			"data":    data,
+		},
+		Required: []string{"summary", "data"},
+	}
+}
+
+// pctProperty is a nullable percentage field.
+func pctProperty(description string) mcp.Property {
+	return mcp.Property{Type: "number", Description: description + " (0-100, null when unknown)"}
+}
+
+// spanProperty describes a labelled period.
+func spanProperty(description, example string) mcp.Property {
+	return mcp.Property{
+		Type:        "object",
+		Description: description,
+		Properties: map[string]mcp.Property{
+			"label": {Type: "string", Description: "Period name, e.g. " + example},
+			"start": {Type: "string", Description: "First day (YYYY-MM-DD)"},
+			"end":   {Type: "string", Description: "Last day (YYYY-MM-DD)"},
+		},
+		Required: []string{"label", "start", "end"},
+	}
+}
+
+// timeframeData describes the get_timeframe result.
+var timeframeData = mcp.Property{
+	Type:        "object",
+	Description: "Dates placed on the fiscal calendar",
+	Properties: map[string]mcp.Property{
+		"calendar": {Type: "object", Description: "Configured calendar", Properties: map[string]mcp.Property{
+			"fiscal_year_start": {Type: "string", Description: "First month of the fiscal year"},
+			"week_start":        {Type: "string", Description: "First day of the week"},
+			"description":       {Type: "string", Description: "Calendar in words"},
+		}},
+		"today": {Type: "string", Description: "Date relative expressions resolved against (YYYY-MM-DD)"},
+		"timeframes": {Type: "array", Description: "One entry per input date, in input order", Items: &mcp.Property{
+			Type:        "object",
+			Description: "A date's periods",
+			Properties: map[string]mcp.Property{
+				"input":           {Type: "string", Description: "Date or expression as given"},
+				"date":            {Type: "string", Description: "Resolved date (YYYY-MM-DD)"},
+				"weekday":         {Type: "string", Description: "Day of the week"},
+				"fiscal_year":     {Type: "integer", Description: "Fiscal year, named after the calendar year it ends in"},
+				"quarter_number":  {Type: "integer", Description: "Fiscal quarter (1-4)"},
+				"half_number":     {Type: "integer", Description: "Fiscal half (1-2)"},
+				"day_of_quarter":  {Type: "integer", Description: "Day within the quarter, from 1"},
+				"days_in_quarter": {Type: "integer", Description: "Length of the quarter in days"},
+				"year":            spanProperty("Fiscal year", "FY2027 (or 2026 for the calendar year)"),
+				"half":            spanProperty("Fiscal half", "FY2027 H1"),
+				"quarter":         spanProperty("Fiscal quarter", "FY2027 Q3"),
+				"month":           spanProperty("Calendar month", "2026-10"),
+				"week":            spanProperty("Week, from the configured week start", "2026-W42 (the ISO week holding most of its days)"),
+			},
+			Required: []string{"input", "date", "fiscal_year", "quarter_number", "year", "half", "quarter", "month", "week"},
+		}},
+	},
+	Required: []string{"calendar", "timeframes"},
+}
+
+// okrProgressData describes the okr_progress result.
+var okrProgressData = mcp.Property{
+	Type:        "object",
+	Description: "OKR progress rollup",
+	Properties: map[string]mcp.Property{
+		"as_of":         {Type: "string", Description: "Evaluation date (YYYY-MM-DD)"},
+		"risk_margin":   {Type: "number", Description: "Percentage points progress may trail elapsed time before at_risk"},
+		"at_risk_count": {Type: "integer", Description: "Number of objectives with status at_risk"},
+		"objectives": {Type: "array", Description: "One entry per objective", Items: &mcp.Property{
+			Type:        "object",
+			Description: "Objective progress",
+			Properties: map[string]mcp.Property{
+				"id":           {Type: "string", Description: "Objective ID"},
+				"name":         {Type: "string", Description: "Objective name"},
+				"time_frame":   {Type: "string", Description: "Time frame label as entered in ProductPlan"},
+				"progress_pct": pctProperty("Mean progress of measurable key results"),
+				"elapsed_pct":  pctProperty("Share of the time frame that has passed"),
+				"status": {Type: "string", Description: "Rollup status", Enum: []string{
+					"complete", "on_track", "at_risk", "not_started", "no_data", "no_time_frame",
+				}},
+				"key_results": {Type: "array", Description: "Key results with computed progress", Items: &mcp.Property{
+					Type:        "object",
+					Description: "Key result progress",
+					Properties: map[string]mcp.Property{
+						"id":            {Type: "string", Description: "Key result ID"},
+						"name":          {Type: "string", Description: "Key result name"},
+						"start_value":   {Type: "number", Description: "Starting value (null when missing; treated as 0)"},
+						"current_value": {Type: "number", Description: "Current value (null when missing)"},
+						"target_value":  {Type: "number", Description: "Target value (null when missing)"},
+						"progress_pct":  pctProperty("(current - start) / (target - start)"),
+						"note":          {Type: "string", Description: "Why progress could not be computed"},
+					},
+					Required: []string{"id", "name", "progress_pct"},
+				}},
+			},
+			Required: []string{"id", "name", "status", "key_results"},
+		}},
+		"time_frames": {Type: "array", Description: "Objectives rolled up per time frame, in chronological order", Items: &mcp.Property{
+			Type:        "object",
+			Description: "Time frame rollup",
+			Properties: map[string]mcp.Property{
+				"time_frame":   {Type: "string", Description: "Time frame label"},
+				"objectives":   {Type: "integer", Description: "Objectives in this time frame"},
+				"progress_pct": pctProperty("Mean objective progress"),
+				"elapsed_pct":  pctProperty("Share of the time frame that has passed"),
+				"at_risk":      {Type: "integer", Description: "Objectives at risk"},
+			},
+			Required: []string{"time_frame", "objectives", "at_risk"},
+		}},
+	},
+	Required: []string{"as_of", "at_risk_count", "objectives", "time_frames"},
+}
+
+// barRefProperty describes a bar reference in coverage results.
+func barRefProperty(description string) *mcp.Property {
+	return &mcp.Property{
+		Type:        "object",
+		Description: description,
+		Properties: map[string]mcp.Property{
+			"id":         {Type: "string", Description: "Bar ID"},
+			"name":       {Type: "string", Description: "Bar name"},
+			"roadmap_id": {Type: "string", Description: "Roadmap ID"},
+			"roadmap":    {Type: "string", Description: "Roadmap name"},
+			"via":        {Type: "string", Description: "How the bar is linked", Enum: []string{"api", "tag", "custom_field", "parent"}},
+		},
+		Required: []string{"id", "name", "roadmap_id", "roadmap"},
+	}
+}
+
+// objectiveRefProperties are the fields identifying an objective.
+func objectiveRefProperties() map[string]mcp.Property {
+	return map[string]mcp.Property{
+		"id":         {Type: "string", Description: "Objective ID"},
+		"name":       {Type: "string", Description: "Objective name"},
+		"time_frame": {Type: "string", Description: "Time frame label as entered in ProductPlan"},
+	}
+}
+
+// objectiveCoverageData describes the objective_coverage result.
+var objectiveCoverageData = func() mcp.Property {
+	covered := objectiveRefProperties()
+	covered["bar_count"] = mcp.Property{Type: "integer", Description: "Bars supporting the objective directly or through a key result"}
+	covered["bars"] = mcp.Property{Type: "array", Description: "Supporting bars, each listed once", Items: barRefProperty("Linked bar")}
+	covered["key_results"] = mcp.Property{Type: "array", Description: "Key results with the bars linked to each", Items: &mcp.Property{
+		Type:        "object",
+		Description: "Key result coverage",
+		Properties: map[string]mcp.Property{
+			"id":   {Type: "string", Description: "Key result ID"},
+			"name": {Type: "string", Description: "Key result name"},
+			"bars": {Type: "array", Description: "Bars linked to this key result", Items: barRefProperty("Linked bar")},
+		},
+		Required: []string{"id", "name", "bars"},
+	}}
+
+	return mcp.Property{
+		Type:        "object",
+		Description: "Strategic coverage of roadmap bars",
+		Properties: map[string]mcp.Property{
+			"conventions": {Type: "object", Description: "Link conventions applied", Properties: map[string]mcp.Property{
+				"tag_prefix":   {Type: "string", Description: "Tag prefix naming an objective or key result"},
+				"custom_field": {Type: "string", Description: "Custom field naming an objective or key result"},
+			}},
+			"bar_count":        {Type: "integer", Description: "Bars considered"},
+			"linked_bar_count": {Type: "integer", Description: "Bars linked to at least one objective"},
+			"coverage_pct":     {Type: "number", Description: "Share of bars with a strategic link (0-100)"},
+			"objectives": {Type: "array", Description: "One entry per objective", Items: &mcp.Property{
+				Type:        "object",
+				Description: "Objective coverage",
+				Properties:  covered,
+				Required:    []string{"id", "name", "bar_count", "bars", "key_results"},
+			}},
+			"uncovered_objectives": {Type: "array", Description: "Objectives with no supporting bars", Items: &mcp.Property{
+				Type:        "object",
+				Description: "Objective",
+				Properties:  objectiveRefProperties(),
+				Required:    []string{"id", "name"},
+			}},
+			"unlinked_bars": {Type: "array", Description: "Bars with no strategic link", Items: barRefProperty("Unlinked bar")},
+			"unmatched_links": {Type: "array", Description: "Tag or field values that name no known objective or key result", Items: &mcp.Property{
+				Type:        "object",
+				Description: "Unmatched link",
+				Properties: map[string]mcp.Property{
+					"bar":    *barRefProperty("Bar carrying the value"),
+					"value":  {Type: "string", Description: "The value as written"},
+					"source": {Type: "string", Description: "Where the value came from", Enum: []string{"api", "tag", "custom_field"}},
+				},
+				Required: []string{"bar", "value", "source"},
+			}},
+		},
+		Required: []string{"bar_count", "linked_bar_count", "coverage_pct", "objectives", "uncovered_objectives", "unlinked_bars", "unmatched_links"},
+	}
+}()
+
+// dependencyBarProperty describes a bar in the dependency graph.
+func dependencyBarProperty(description string) *mcp.Property {
+	return &mcp.Property{
+		Type:        "object",
+		Description: description,
+		Properties: map[string]mcp.Property{
+			"id":        {Type: "string", Description: "Bar ID"},
+			"name":      {Type: "string", Description: "Bar name (\"Bar <id>\" for external bars)"},
+			"starts_on": {Type: "string", Description: "Start date (YYYY-MM-DD, empty when unscheduled)"},
+			"ends_on":   {Type: "string", Description: "End date (YYYY-MM-DD, empty when unscheduled)"},
+			"external":  {Type: "boolean", Description: "True for bars on another roadmap"},
+		},
+		Required: []string{"id", "name"},
+	}
+}
+
+// analyzeDependenciesData describes the analyze_dependencies result.
+var analyzeDependenciesData = mcp.Property{
+	Type:        "object",
+	Description: "Dependency graph analysis",
+	Properties: map[string]mcp.Property{
+		"roadmap_id": {Type: "string", Description: "Roadmap ID"},
+		"roadmap":    {Type: "string", Description: "Roadmap name"},
+		"bar_count":  {Type: "integer", Description: "Bars on the roadmap"},
+		"bars":       {Type: "array", Description: "Bars with at least one dependency, in roadmap order", Items: dependencyBarProperty("Graph node")},
+		"edges": {Type: "array", Description: "Deduplicated connections", Items: &mcp.Property{
+			Type:        "object",
+			Description: "Connection: to depends on from",
+			Properties: map[string]mcp.Property{
+				"connection_id": {Type: "string", Description: "Connection ID (for manage_bar_connection delete)"},
+				"from":          {Type: "string", Description: "Predecessor bar ID"},
+				"to":            {Type: "string", Description: "Dependent bar ID"},
+				"in_cycle":      {Type: "boolean", Description: "True when the edge is part of a cycle"},
+			},
+			Required: []string{"connection_id", "from", "to"},
+		}},
+		"cycles": {Type: "array", Description: "Groups of bars that depend on each other in a loop", Items: &mcp.Property{
+			Type: "array", Description: "Bars in one cycle", Items: dependencyBarProperty("Bar in the cycle"),
+		}},
+		"critical_path": {Type: "object", Description: "Chain of dependent bars with the greatest total duration (cycle edges excluded)", Properties: map[string]mcp.Property{
+			"bars":          {Type: "array", Description: "Bars in dependency order (empty without dependencies)", Items: dependencyBarProperty("Bar on the path")},
+			"duration_days": {Type: "integer", Description: "Sum of bar durations in days; unscheduled bars count as 0"},
+			"starts_on":     {Type: "string", Description: "Start of the first scheduled bar"},
+			"ends_on":       {Type: "string", Description: "End of the last scheduled bar"},
+		}, Required: []string{"bars", "duration_days"}},
+		"violations": {Type: "array", Description: "Dependents scheduled to start before their predecessor ends", Items: &mcp.Property{
+			Type:        "object",
+			Description: "Scheduling violation",
+			Properties: map[string]mcp.Property{
+				"predecessor":  *dependencyBarProperty("Bar that must finish first"),
+				"dependent":    *dependencyBarProperty("Bar that starts too early"),
+				"overlap_days": {Type: "integer", Description: "Days the two bars overlap (end dates inclusive)"},
+			},
+			Required: []string{"predecessor", "dependent", "overlap_days"},
+		}},
+		"mermaid": {Type: "string", Description: "Mermaid flowchart of the graph; critical path outlined, cycles filled red, violations dashed"},
+	},
+	Required: []string{"roadmap_id", "bar_count", "bars", "edges", "cycles", "critical_path", "violations", "mermaid"},
+}
+
+// scheduledBarProperty describes a bar with its dates.
+func scheduledBarProperty(description string) *mcp.Property {
+	return &mcp.Property{
+		Type:        "object",
+		Description: description,
+		Properties: map[string]mcp.Property{
+			"id":        {Type: "string", Description: "Bar ID"},
+			"name":      {Type: "string", Description: "Bar name"},
+			"starts_on": {Type: "string", Description: "Start date as entered"},
+			"ends_on":   {Type: "string", Description: "End date as entered"},
+		},
+		Required: []string{"id", "name", "starts_on", "ends_on"},
+	}
+}
+
+// scheduleConflictsData describes the detect_schedule_conflicts result.
+var scheduleConflictsData = mcp.Property{
+	Type:        "object",
+	Description: "Schedule conflicts and load per lane",
+	Properties: map[string]mcp.Property{
+		"roadmap_id":     {Type: "string", Description: "Roadmap ID"},
+		"roadmap":        {Type: "string", Description: "Roadmap name"},
+		"max_concurrent": {Type: "integer", Description: "Bars a lane may run at once before an overlap is flagged"},
+		"period":         {Type: "string", Description: "Load period", Enum: []string{"week", "month", "quarter"}},
+		"capacity":       {Type: "number", Description: "Effort per lane per period before over_capacity (absent when not set)"},
+		"overlap_count":  {Type: "integer", Description: "Overlaps across all lanes"},
+		"lanes": {Type: "array", Description: "One entry per lane, in roadmap order", Items: &mcp.Property{
+			Type:        "object",
+			Description: "Lane schedule",
+			Properties: map[string]mcp.Property{
+				"lane_id":         {Type: "string", Description: "Lane ID"},
+				"lane":            {Type: "string", Description: "Lane name (\"(no lane)\" for bars in unknown lanes)"},
+				"bar_count":       {Type: "integer", Description: "Scheduled bars, excluding containers and parked bars"},
+				"peak_concurrent": {Type: "integer", Description: "Most bars running on a single day"},
+				"overlaps": {Type: "array", Description: "Stretches with more than max_concurrent bars running", Items: &mcp.Property{
+					Type:        "object",
+					Description: "Overlap",
+					Properties: map[string]mcp.Property{
+						"starts_on": {Type: "string", Description: "First overloaded day"},
+						"ends_on":   {Type: "string", Description: "Last overloaded day"},
+						"peak":      {Type: "integer", Description: "Most bars running on one day in this stretch"},
+						"bars":      {Type: "array", Description: "Bars running during the stretch", Items: scheduledBarProperty("Bar")},
+					},
+					Required: []string{"starts_on", "ends_on", "peak", "bars"},
+				}},
+				"load": {Type: "array", Description: "Effort per period, prorated by each bar's days in the period", Items: &mcp.Property{
+					Type:        "object",
+					Description: "Load bucket",
+					Properties: map[string]mcp.Property{
+						"period":        {Type: "string", Description: "Week (2026-W05), month (2026-03) or fiscal quarter (FY2027 Q1, or 2026 Q1 for calendar quarters)"},
+						"starts_on":     {Type: "string", Description: "First day of the period"},
+						"effort":        {Type: "number", Description: "Prorated effort"},
+						"bars":          {Type: "integer", Description: "Bars active in the period"},
+						"unestimated":   {Type: "integer", Description: "Active bars without an effort value"},
+						"over_capacity": {Type: "boolean", Description: "True when effort exceeds capacity"},
+					},
+					Required: []string{"period", "starts_on", "effort", "bars", "unestimated"},
+				}},
+			},
+			Required: []string{"lane_id", "lane", "bar_count", "peak_concurrent", "overlaps", "load"},
+		}},
+		"date_issues": {Type: "array", Description: "Bars whose dates cannot be scheduled as entered", Items: &mcp.Property{
+			Type:        "object",
+			Description: "Date issue",
+			Properties: map[string]mcp.Property{
+				"bar":  *scheduledBarProperty("Bar"),
+				"lane": {Type: "string", Description: "Lane name"},
+				"issue": {Type: "string", Description: "Problem with the dates", Enum: []string{
+					"end_before_start", "missing_dates", "missing_start", "missing_end", "unparseable_date", "parked_with_dates",
+				}},
+			},
+			Required: []string{"bar", "lane", "issue"},
+		}},
+	},
+	Required: []string{"roadmap_id", "max_concurrent", "period", "overlap_count", "lanes", "date_issues"},
+}
+
+// lintRoadmapData describes the lint_roadmap result.
+var lintRoadmapData = mcp.Property{
+	Type:        "object",
+	Description: "Roadmap hygiene findings",
+	Properties: map[string]mcp.Property{
+		"roadmap_id": {Type: "string", Description: "Roadmap ID"},
+		"roadmap":    {Type: "string", Description: "Roadmap name"},
+		"as_of":      {Type: "string", Description: "Evaluation date (YYYY-MM-DD)"},
+		"rules":      {Type: "array", Description: "Rules that ran", Items: &mcp.Property{Type: "string", Description: "Rule name"}},
+		"errors":     {Type: "integer", Description: "Findings with severity error"},
+		"warnings":   {Type: "integer", Description: "Findings with severity warning"},
+		"infos":      {Type: "integer", Description: "Findings with severity info"},
+		"findings": {Type: "array", Description: "Findings, most severe first", Items: &mcp.Property{
+			Type:        "object",
+			Description: "Finding",
+			Properties: map[string]mcp.Property{
+				"rule":     {Type: "string", Description: "Rule that produced the finding"},
+				"severity": {Type: "string", Description: "error (broken data), warning (likely mistake) or info (housekeeping)", Enum: []string{"error", "warning", "info"}},
+				"target": {Type: "object", Description: "Item the finding is about", Properties: map[string]mcp.Property{
+					"type":   {Type: "string", Description: "Item type", Enum: []string{"bar", "lane", "milestone", "link"}},
+					"id":     {Type: "string", Description: "Item ID"},
+					"name":   {Type: "string", Description: "Item name"},
+					"bar_id": {Type: "string", Description: "Bar the link belongs to (links only)"},
+				}, Required: []string{"type", "id", "name"}},
+				"message": {Type: "string", Description: "What is wrong"},
+				"fix":     {Type: "string", Description: "Suggested fix, naming the tool to use"},
+			},
+			Required: []string{"rule", "severity", "target", "message", "fix"},
+		}},
+	},
+	Required: []string{"roadmap_id", "as_of", "rules", "errors", "warnings", "infos", "findings"},
+}
+
+// staleItemsData describes the find_stale_items result.
+var staleItemsData = mcp.Property{
+	Type:        "object",
+	Description: "Items untouched for at least the threshold, grouped for triage",
+	Properties: map[string]mcp.Property{
+		"as_of": {Type: "string", Description: "Evaluation date (YYYY-MM-DD)"},
+		"days":  {Type: "integer", Description: "Threshold in days since the last update"},
+		"total": {Type: "integer", Description: "Stale items across all kinds"},
+		"kinds": {Type: "array", Description: "Counts per kind", Items: &mcp.Property{
+			Type:        "object",
+			Description: "Kind summary",
+			Properties: map[string]mcp.Property{
+				"kind":    {Type: "string", Description: "Item kind", Enum: analysis.StaleKinds},
+				"checked": {Type: "integer", Description: "Open items checked"},
+				"stale":   {Type: "integer", Description: "Items at or over the threshold"},
+				"undated": {Type: "integer", Description: "Items with no usable timestamp"},
+			},
+			Required: []string{"kind", "checked", "stale", "undated"},
+		}},
+		"groups": {Type: "array", Description: "Groups, the one holding the oldest item first", Items: &mcp.Property{
+			Type:        "object",
+			Description: "Stale items sharing a kind and owner or lane",
+			Properties: map[string]mcp.Property{
+				"kind":        {Type: "string", Description: "Item kind", Enum: analysis.StaleKinds},
+				"group":       {Type: "string", Description: "\"Roadmap / Lane\" for bars, assignee for launch tasks, owner for ideas and opportunities"},
+				"count":       {Type: "integer", Description: "Items in the group"},
+				"oldest_days": {Type: "integer", Description: "Age of the oldest item in days"},
+				"items": {Type: "array", Description: "Items, oldest first", Items: &mcp.Property{
+					Type:        "object",
+					Description: "Stale item",
+					Properties: map[string]mcp.Property{
+						"kind":       {Type: "string", Description: "Item kind"},
+						"id":         {Type: "string", Description: "Item ID"},
+						"name":       {Type: "string", Description: "Name; launch tasks are prefixed with their launch"},
+						"status":     {Type: "string", Description: "Status, where the item has one"},
+						"updated_at": {Type: "string", Description: "Last update (YYYY-MM-DD)"},
+						"age_days":   {Type: "integer", Description: "Days since the last update"},
+					},
+					Required: []string{"kind", "id", "name", "updated_at", "age_days"},
+				}},
+			},
+			Required: []string{"kind", "group", "count", "oldest_days", "items"},
+		}},
+	},
+	Required: []string{"as_of", "days", "total", "kinds", "groups"},
+}
+
+// ideaClustersData describes the cluster_ideas result.
+var ideaClustersData = mcp.Property{
+	Type:        "object",
+	Description: "Near-duplicate idea clusters with merge suggestions",
+	Properties: map[string]mcp.Property{
+		"ideas":     {Type: "integer", Description: "Ideas compared"},
+		"threshold": {Type: "number", Description: "Similarity threshold used"},
+		"clustered": {Type: "integer", Description: "Ideas that fell into a cluster"},
+		"clusters": {Type: "array", Description: "Clusters, most customers first", Items: &mcp.Property{
+			Type:        "object",
+			Description: "Cluster of similar ideas",
+			Properties: map[string]mcp.Property{
+				"keywords":       {Type: "array", Description: "Terms that best describe the cluster", Items: &mcp.Property{Type: "string", Description: "Term"}},
+				"size":           {Type: "integer", Description: "Ideas in the cluster"},
+				"customer_count": {Type: "integer", Description: "Distinct customers across the cluster"},
+				"customers":      {Type: "array", Description: "Distinct customers", Items: &mcp.Property{Type: "string", Description: "Customer"}},
+				"tags":           {Type: "array", Description: "Distinct tags", Items: &mcp.Property{Type: "string", Description: "Tag"}},
+				"keep":           {Type: "object", Description: "Suggested idea to keep: most customers, then most opportunities", Properties: clusterIdeaProperties()},
+				"merge": {Type: "array", Description: "Ideas to merge into keep, most similar first", Items: &mcp.Property{
+					Type:        "object",
+					Description: "Merge candidate",
+					Properties: func() map[string]mcp.Property {
+						p := clusterIdeaProperties()
+						p["similarity"] = mcp.Property{Type: "number", Description: "Cosine similarity to keep, 0-1"}
+						return p
+					}(),
+				}},
+			},
+			Required: []string{"keywords", "size", "customer_count", "customers", "tags", "keep", "merge"},
+		}},
+	},
+	Required: []string{"ideas", "threshold", "clustered", "clusters"},
+}
+
+// clusterIdeaProperties describes an idea within a cluster.
+func clusterIdeaProperties() map[string]mcp.Property {
+	return map[string]mcp.Property{
+		"id":                  {Type: "string", Description: "Idea ID"},
+		"name":                {Type: "string", Description: "Idea name"},
+		"status":              {Type: "string", Description: "Idea status"},
+		"customer_count":      {Type: "integer", Description: "Customers on the idea"},
+		"opportunities_count": {Type: "integer", Description: "Linked opportunities"},
+	}
+}
+
+// demandEntryList describes one ranked demand list.
+func demandEntryList(description, nameDescription string) mcp.Property {
+	return mcp.Property{Type: "array", Description: description, Items: &mcp.Property{
+		Type:        "object",
+		Description: "Ranked entry",
+		Properties: map[string]mcp.Property{
+			"id":            {Type: "string", Description: "Opportunity ID (opportunities only)"},
+			"name":          {Type: "string", Description: nameDescription},
+			"ideas":         {Type: "integer", Description: "Ideas behind the entry"},
+			"requests":      {Type: "integer", Description: "Idea-customer pairs"},
+			"customers":     {Type: "integer", Description: "Distinct customers"},
+			"score":         {Type: "number", Description: "Weighted demand"},
+			"top_customers": {Type: "array", Description: "Most valuable customers asking", Items: &mcp.Property{Type: "string", Description: "Customer"}},
+		},
+		Required: []string{"name", "ideas", "requests", "customers", "score"},
+	}}
+}
+
+// customerDemandData describes the rank_customer_demand result.
+var customerDemandData = mcp.Property{
+	Type:        "object",
+	Description: "Demand rankings by opportunity, tag and customer",
+	Properties: map[string]mcp.Property{
+		"ideas":            {Type: "integer", Description: "Ideas counted"},
+		"weighted":         {Type: "boolean", Description: "Whether customer_values weighted the scores"},
+		"opportunities":    demandEntryList("Opportunities, highest score first", "Problem statement"),
+		"tags":             demandEntryList("Tags, highest score first", "Tag"),
+		"customers":        demandEntryList("Customers, highest score first", "Customer"),
+		"unmatched_values": {Type: "array", Description: "customer_values names that matched no customer", Items: &mcp.Property{Type: "string", Description: "Customer name"}},
+	},
+	Required: []string{"ideas", "weighted", "opportunities", "tags", "customers", "unmatched_values"},
+}
+
+// lintRuleList documents the built-in lint rules for the tool description.
+func lintRuleList() string {
+	var b strings.Builder
+	for _, r := range lint.BuiltinRules() {
+		b.WriteString("- " + r.Name() + ": " + r.Description() + "\n")
+	}
+	return b.String()
+}
+
+// lintRuleNames returns the built-in rule names for the input schema.
+func lintRuleNames() []string {
+	rules := lint.BuiltinRules()
+	names := make([]string, len(rules))
+	for i, r := range rules {
+		names[i] = r.Name()
+	}
+	return names
+}
+
+// readinessTaskItems describes a task that needs attention.
+var readinessTaskItems = &mcp.Property{
+	Type:        "object",
+	Description: "Open task",
+	Properties: map[string]mcp.Property{
+		"id":           {Type: "string", Description: "Task ID"},
+		"name":         {Type: "string", Description: "Task name"},
+		"section":      {Type: "string", Description: "Section name"},
+		"status":       {Type: "string", Description: "Task status"},
+		"due_date":     {Type: "string", Description: "Due date"},
+		"assignee":     {Type: "string", Description: "Assigned user's name, or ID when unknown"},
+		"days_overdue": {Type: "integer", Description: "Days past the due date (overdue tasks only)"},
+	},
+	Required: []string{"id", "name", "section"},
+}
+
+// launchReadinessData describes the launch_readiness result.
+var launchReadinessData = mcp.Property{
+	Type:        "object",
+	Description: "Launch readiness",
+	Properties: map[string]mcp.Property{
+		"as_of":             {Type: "string", Description: "Evaluation date (YYYY-MM-DD)"},
+		"id":                {Type: "string", Description: "Launch ID"},
+		"name":              {Type: "string", Description: "Launch name"},
+		"date":              {Type: "string", Description: "Launch date"},
+		"status":            {Type: "string", Description: "Launch status"},
+		"days_until_launch": {Type: "integer", Description: "Days until the launch date; negative once passed, null without a date"},
+		"tasks":             {Type: "integer", Description: "Checklist tasks"},
+		"done":              {Type: "integer", Description: "Completed tasks"},
+		"percent":           {Type: "number", Description: "Percent of tasks done, null without tasks"},
+		"sections": {Type: "array", Description: "Completion per section, in checklist order", Items: &mcp.Property{
+			Type:        "object",
+			Description: "Section completion",
+			Properties: map[string]mcp.Property{
+				"id":      {Type: "string", Description: "Section ID (empty for unsectioned tasks)"},
+				"name":    {Type: "string", Description: "Section name"},
+				"tasks":   {Type: "integer", Description: "Tasks in the section"},
+				"done":    {Type: "integer", Description: "Completed tasks"},
+				"percent": {Type: "number", Description: "Percent done, null without tasks"},
+			},
+			Required: []string{"name", "tasks", "done", "percent"},
+		}},
+		"overdue":          {Type: "array", Description: "Open tasks past their due date, most overdue first", Items: readinessTaskItems},
+		"unassigned":       {Type: "array", Description: "Open tasks with no assignee", Items: readinessTaskItems},
+		"due_after_launch": {Type: "array", Description: "Open tasks due after the launch date", Items: readinessTaskItems},
+		"verdict":          {Type: "string", Description: "Overall status", Enum: analysis.ReadinessVerdicts},
+		"reasons":          {Type: "array", Description: "Why the verdict was given", Items: &mcp.Property{Type: "string", Description: "Reason"}},
+	},
+	Required: []string{"as_of", "id", "name", "days_until_launch", "tasks", "done", "percent", "sections", "overdue", "unassigned", "due_after_launch", "verdict", "reasons"},
+}
+
+// workloadWeekItems describes open tasks due in one week.
+var workloadWeekItems = &mcp.Property{
+	Type:        "object",
+	Description: "Open tasks due in one week",
+	Properties: map[string]mcp.Property{
+		"week":  {Type: "string", Description: "ISO week holding most of the week's days, e.g. 2026-W25"},
+		"start": {Type: "string", Description: "Monday of the week (YYYY-MM-DD)"},
+		"tasks": {Type: "integer", Description: "Open tasks due that week"},
+	},
+	Required: []string{"week", "start", "tasks"},
+}
+
+// userWorkloadData describes the user_workload result.
+var userWorkloadData = mcp.Property{
+	Type:        "object",
+	Description: "Open work per user and team",
+	Properties: map[string]mcp.Property{
+		"as_of":        {Type: "string", Description: "Evaluation date (YYYY-MM-DD)"},
+		"weekly_limit": {Type: "integer", Description: "Tasks due in one week from which a user is overloaded"},
+		"users": {Type: "array", Description: "Users with open tasks or bars, overloaded first", Items: &mcp.Property{
+			Type:        "object",
+			Description: "User workload",
+			Properties: map[string]mcp.Property{
+				"user_id":    {Type: "string", Description: "User ID (the owner name for bar owners not matched to a user)"},
+				"name":       {Type: "string", Description: "User name, or ID when unknown"},
+				"email":      {Type: "string", Description: "Email"},
+				"teams":      {Type: "array", Description: "Team names", Items: &mcp.Property{Type: "string", Description: "Team name"}},
+				"open_tasks": {Type: "integer", Description: "Open launch tasks assigned"},
+				"overdue":    {Type: "integer", Description: "Open tasks past their due date"},
+				"undated":    {Type: "integer", Description: "Open tasks without a due date"},
+				"bars":       {Type: "integer", Description: "Active bars owned"},
+				"weeks":      {Type: "array", Description: "Weeks with tasks due, earliest first; overdue tasks count in the current week", Items: workloadWeekItems},
+				"peak_week":  {Type: "object", Description: "Week with the most tasks due, null without dated tasks", Properties: workloadWeekItems.Properties},
+				"overloaded": {Type: "boolean", Description: "Whether the peak week reaches weekly_limit"},
+				"tasks": {Type: "array", Description: "Open tasks, by due date", Items: &mcp.Property{
+					Type:        "object",
+					Description: "Open task",
+					Properties: map[string]mcp.Property{
+						"id":          {Type: "string", Description: "Task ID"},
+						"name":        {Type: "string", Description: "Task name"},
+						"launch_id":   {Type: "string", Description: "Launch ID"},
+						"launch_name": {Type: "string", Description: "Launch name"},
+						"due_date":    {Type: "string", Description: "Due date"},
+						"overdue":     {Type: "boolean", Description: "Whether the task is past due"},
+					},
+					Required: []string{"id", "name", "launch_id", "launch_name"},
+				}},
+			},
+			Required: []string{"user_id", "name", "teams", "open_tasks", "overdue", "undated", "bars", "weeks", "peak_week", "overloaded", "tasks"},
+		}},
+		"teams": {Type: "array", Description: "Teams, most open tasks first", Items: &mcp.Property{
+			Type:        "object",
+			Description: "Team workload",
+			Properties: map[string]mcp.Property{
+				"id":         {Type: "string", Description: "Team ID"},
+				"name":       {Type: "string", Description: "Team name"},
+				"members":    {Type: "integer", Description: "Team members"},
+				"open_tasks": {Type: "integer", Description: "Open tasks assigned to members"},
+				"overdue":    {Type: "integer", Description: "Overdue tasks assigned to members"},
+				"bars":       {Type: "integer", Description: "Active bars owned by members"},
+				"overloaded": {Type: "array", Description: "Overloaded members", Items: &mcp.Property{Type: "string", Description: "User name"}},
+			},
+			Required: []string{"id", "name", "members", "open_tasks", "overdue", "bars", "overloaded"},
+		}},
+		"unassigned": {Type: "integer", Description: "Open launch tasks with no assignee"},
+		"bar_owners": {Type: "boolean", Description: "Whether any bar carried owner data; when false, bar counts are not meaningful"},
+	},
+	Required: []string{"as_of", "weekly_limit", "users", "teams", "unassigned", "bar_owners"},
+}
+
+// barNodeProperties describes one node of a get_bar_tree result. Children
+// share the shape, which the schema states in prose rather than recursing.
+var barNodeProperties = map[string]mcp.Property{
+	"id":               {Type: "string", Description: "Bar ID"},
+	"name":             {Type: "string", Description: "Bar name"},
+	"container":        {Type: "boolean", Description: "Whether the bar is a container"},
+	"starts_on":        {Type: "string", Description: "The bar's own start date"},
+	"ends_on":          {Type: "string", Description: "The bar's own end date"},
+	"effort":           {Type: "number", Description: "The bar's own effort, null when unset"},
+	"percent_done":     {Type: "number", Description: "The bar's own percent done, null when unset"},
+	"span_start":       {Type: "string", Description: "Earliest start across the bar and its descendants (YYYY-MM-DD)"},
+	"span_end":         {Type: "string", Description: "Latest end across the bar and its descendants (YYYY-MM-DD)"},
+	"total_effort":     {Type: "number", Description: "Effort summed over leaf bars, or the bar's own; null when none is set"},
+	"avg_percent_done": pctProperty("Percent done averaged over leaf bars that report it, or the bar's own"),
+	"descendants":      {Type: "integer", Description: "Bars below this one, cycles excluded"},
+	"cycle":            {Type: "boolean", Description: "The bar already appears elsewhere in the tree and is not expanded"},
+	"truncated":        {Type: "boolean", Description: "The bar is at max_depth and its children were not loaded"},
+	"children":         {Type: "array", Description: "Child nodes, same shape as this one", Items: &mcp.Property{Type: "object", Description: "Child node"}},
+}
+
+// barTreeData describes the get_bar_tree result.
+var barTreeData = mcp.Property{
+	Type:        "object",
+	Description: "Bar hierarchy",
+	Properties: map[string]mcp.Property{
+		"root": {Type: "object", Description: "Root node", Properties: barNodeProperties,
+			Required: []string{"id", "name", "total_effort", "avg_percent_done", "descendants", "children"}},
+		"bars":      {Type: "integer", Description: "Distinct bars in the tree, the root included"},
+		"depth":     {Type: "integer", Description: "Deepest level loaded (0 for a bar without children)"},
+		"cycles":    {Type: "integer", Description: "Repeated bars that were not expanded"},
+		"truncated": {Type: "integer", Description: "Bars cut off at max_depth"},
+		"outline":   {Type: "string", Description: "The tree as an indented text outline"},
+	},
+	Required: []string{"root", "bars", "depth", "cycles", "truncated", "outline"},
+}
+
+// analysisTools returns tool definitions that compute over ProductPlan data.
+func analysisTools() []mcp.Tool {
+	return []mcp.Tool{
+		derivedReadOnly(mcp.Tool{
+			Name: "okr_progress",
+			Description: `Compute OKR progress: each key result's progress from its starting, current and target values, rolled up per objective and per time frame, with at-risk flags.
+
+USE WHEN: "How are our OKRs tracking?", "Which objectives are at risk?", "Q3 OKR progress"
+Key result progress = (current - start) / (target - start), clamped to 0-100%, so decreasing targets work. Values like "45%" or "$1,200" are parsed.
+An objective is at_risk when the time elapsed in its time frame (e.g. "Q3 2026", "H1 2026", "FY2026") exceeds its progress by more than risk_margin points. Time frames are read on the configured fiscal calendar, so with a February start "Q1 2027" begins in February 2026.
+Prefer this over list_key_results when you need numbers: it computes them consistently.`,
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"objective_ids": {Type: "array", Description: "Limit to these objectives (default: all)", Items: &mcp.Property{Type: "string", Description: "Objective ID"}},
+					"time_frame":    {Type: "string", Description: "Limit to objectives with this time frame label, e.g. \"Q3 2026\""},
+					"risk_margin":   {Type: "number", Description: "Percentage points progress may trail elapsed time before flagging at_risk (default 10)", Minimum: floatPtr(0), Maximum: floatPtr(100)},
+				},
+			},
+			OutputSchema: analysisOutputSchema(okrProgressData),
+		}),
+		derivedReadOnly(mcp.Tool{
+			Name: "objective_coverage",
+			Description: `Join roadmap bars with objectives and key results to report strategic coverage: which objectives have no supporting work, and which bars have no strategic link.
+
+USE WHEN: "Which OKRs have nothing on the roadmap?", "What work isn't tied to a goal?", "Strategic alignment check"
+Links come from objective/key result IDs on bars when the API provides them, otherwise from tags with a prefix (default "okr:", e.g. "okr:Grow revenue") or a custom field (default "Objective"). Values match an objective or key result by ID or name. Child bars without a link inherit their parent's.
+unmatched_links lists tag or field values that match nothing, usually typos or renamed objectives.`,
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"roadmap_ids":    {Type: "array", Description: "Roadmaps to include (default: all)", Items: &mcp.Property{Type: "string", Description: "Roadmap ID"}},
+					"tag_prefix":     {Type: "string", Description: "Tag prefix that names an objective (overrides the configured convention; empty disables tags)"},
+					"custom_field":   {Type: "string", Description: "Custom field that names an objective (overrides the configured convention; empty disables the field)"},
+					"include_parked": {Type: "boolean", Description: "Count parked bars as roadmap work (default false)"},
+				},
+			},
+			OutputSchema: analysisOutputSchema(objectiveCoverageData),
+		}),
+		derivedReadOnly(mcp.Tool{
+			Name: "analyze_dependencies",
+			Description: `Analyze the dependency graph of a roadmap built from bar connections: cycles, the critical path, and bars scheduled to start before their predecessor ends. Includes a Mermaid flowchart.
+
+USE WHEN: "What's blocking the launch?", "Show me the critical path", "Are there circular dependencies?", "Which dependencies are scheduled wrong?"
+A connection from bar A to bar B means B depends on A. The critical path is the chain with the greatest total bar duration. End dates are inclusive, so a dependent starting on its predecessor's last day is a 1-day overlap.
+Render the mermaid field in a code block to show the graph. Fetches connections for every bar, so large roadmaps take a few seconds.`,
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"roadmap_id": {Type: "string", Description: "Roadmap ID"},
+				},
+				Required: []string{"roadmap_id"},
+			},
+			OutputSchema: analysisOutputSchema(analyzeDependenciesData),
+		}),
+		derivedReadOnly(mcp.Tool{
+			Name: "detect_schedule_conflicts",
+			Description: `Scan a roadmap lane by lane for overloaded schedules: stretches where more than max_concurrent bars run at once, effort load per week, month or fiscal quarter, and bars with unusable dates.
+
+USE WHEN: "Is the backend lane overloaded?", "Where do we have too much going on?", "Which bars have bad dates?", "Effort per month by team"
+Effort is spread over the periods a bar spans in proportion to its days in each; weeks and quarters follow the configured week start and fiscal year (see get_timeframe). Container bars are left out of overlaps and load since they span their children; parked bars are off the schedule.
+date_issues lists bars ending before they start, bars missing dates, and parked bars that still carry dates.`,
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"roadmap_id":     {Type: "string", Description: "Roadmap ID"},
+					"max_concurrent": {Type: "integer", Description: "Bars a lane may run at once before flagging an overlap (default 3)", Minimum: floatPtr(1), Maximum: floatPtr(100)},
+					"period":         {Type: "string", Description: "Load period (default month)", Enum: []string{"week", "month", "quarter"}},
+					"capacity":       {Type: "number", Description: "Effort a lane can absorb per period; buckets above it are flagged over_capacity"},
+				},
+				Required: []string{"roadmap_id"},
+			},
+			OutputSchema: analysisOutputSchema(scheduleConflictsData),
+		}),
+		derivedReadOnly(mcp.Tool{
+			Name: "lint_roadmap",
+			Description: `Check a roadmap for hygiene problems and return findings with a severity and a suggested fix.
+
+USE WHEN: "Clean up this roadmap", "What's stale or broken?", "Roadmap hygiene check", "Any overdue bars?"
+Rules:
+` + lintRuleList() + `Use rules to run only some checks, or skip to leave some out. Fetches links for every bar, so large roadmaps take a few seconds.`,
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"roadmap_id": {Type: "string", Description: "Roadmap ID"},
+					"rules":      {Type: "array", Description: "Run only these rules (default: all)", Items: &mcp.Property{Type: "string", Description: "Rule name", Enum: lintRuleNames()}},
+					"skip":       {Type: "array", Description: "Rules to leave out", Items: &mcp.Property{Type: "string", Description: "Rule name", Enum: lintRuleNames()}},
+				},
+				Required: []string{"roadmap_id"},
+			},
+			OutputSchema: analysisOutputSchema(lintRoadmapData),
+		}),
+		derivedReadOnly(mcp.Tool{
+			Name: "find_stale_items",
+			Description: `List bars, ideas, opportunities and launch tasks nobody has touched for a number of days, grouped and sorted into a triage agenda.
+
+USE WHEN: "What has gone stale?", "Agenda for backlog triage", "Ideas nobody has looked at in months", "Forgotten launch tasks"
+Age is days since updated_at (created_at for ideas and opportunities that lack it). Finished work is skipped: closed or done statuses, bars at 100%, and container bars.
+Bars are grouped by roadmap and lane, launch tasks by assignee, ideas and opportunities by owner. Groups holding the oldest items come first.`,
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"days":        {Type: "integer", Description: "Days without an update before an item is stale (default 90)", Minimum: floatPtr(1), Maximum: floatPtr(3650)},
+					"kinds":       {Type: "array", Description: "Kinds to check (default: all)", Items: &mcp.Property{Type: "string", Description: "Item kind", Enum: analysis.StaleKinds}},
+					"roadmap_ids": {Type: "array", Description: "Roadmaps whose bars to check (default: all)", Items: &mcp.Property{Type: "string", Description: "Roadmap ID"}},
+				},
+			},
+			OutputSchema: analysisOutputSchema(staleItemsData),
+		}),
+		derivedReadOnly(mcp.Tool{
+			Name: "cluster_ideas",
+			Description: `Group near-duplicate ideas by text similarity of name and description and suggest which to merge.
+
+USE WHEN: "Find duplicate ideas", "Which requests are the same thing?", "Dedupe the idea backlog", "Group similar customer ideas"
+Similarity is TF-IDF cosine computed locally over all ideas (every page is fetched); nothing leaves the server. Ideas whose similarity reaches threshold are linked, and linked ideas form a cluster, so check each merge candidate's similarity before merging.
+Each cluster shows combined distinct customers and tags, and suggests the idea with the most customers as the one to keep. Closed ideas (done, rejected, ...) are skipped unless include_closed is set.`,
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"threshold":      {Type: "number", Description: "Cosine similarity from which two ideas count as duplicates (default 0.5; lower finds looser matches)", Minimum: floatPtr(0.05), Maximum: floatPtr(1)},
+					"min_size":       {Type: "integer", Description: "Smallest cluster to report (default 2)", Minimum: floatPtr(2), Maximum: floatPtr(1000)},
+					"include_closed": {Type: "boolean", Description: "Include ideas with a closed status (default false)"},
+				},
+			},
+			OutputSchema: analysisOutputSchema(ideaClustersData),
+		}),
+		derivedReadOnly(mcp.Tool{
+			Name: "rank_customer_demand",
+			Description: `Rank which problems customers ask for most: opportunities, tags and customers by request volume, optionally weighted by customer value.
+
+USE WHEN: "What are customers asking for most?", "Which opportunity has the most demand?", "Top requests from enterprise accounts", "Which tags matter to our biggest customers?"
+Ideas are joined to their customers and tags, and opportunities to ideas through links on either side. Requests count idea-customer pairs; score sums the value of the distinct customers asking, so one customer filing five ideas is not five customers. A customer's own score is its value times its requests.
+Pass customer_values (e.g. ARR or tier weights) to weight customers by name; others are worth default_value. Closed ideas and opportunities are skipped unless include_closed is set.`,
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"customer_values": {Type: "object", Description: "Customer name to value, matched case-insensitively", Examples: []any{map[string]any{"Acme": 120000, "Globex": 45000}}},
+					"default_value":   {Type: "number", Description: "Value of customers not in customer_values (default 1)"},
+					"limit":           {Type: "integer", Description: "Entries per ranking (default 20)", Minimum: floatPtr(1), Maximum: floatPtr(200)},
+					"include_closed":  {Type: "boolean", Description: "Include closed ideas and opportunities (default false)"},
+				},
+			},
+			OutputSchema: analysisOutputSchema(customerDemandData),
+		}),
+		derivedReadOnly(mcp.Tool{
+			Name: "launch_readiness",
+			Description: `Assess whether a launch is ready: completion per checklist section, overdue and unassigned tasks, days until the launch date, and an overall go/no-go verdict.
+
+USE WHEN: "Are we ready to launch?", "Is the v2 launch on track?", "What's blocking the launch?", "Who owns the open launch tasks?"
+Fetches the launch, its sections, its tasks and the account's users in one call, with assignees resolved to names.
+Verdict: no_go when a task is overdue or the launch date has passed with tasks open; at_risk when open tasks are unassigned or due after the launch date, or there are no tasks; otherwise go. Reasons explain the verdict.
+FAILS WHEN: launch not found (get IDs from list_launches).`,
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"launch_id": {Type: "string", Description: "Launch ID"},
+				},
+				Required: []string{"launch_id"},
+			},
+			OutputSchema: analysisOutputSchema(launchReadinessData),
+		}),
+		derivedReadOnly(mcp.Tool{
+			Name: "user_workload",
+			Description: `Show who is overloaded: open launch tasks per user by due week, active bars owned, and totals per team.
+
+USE WHEN: "Who is overloaded?", "Who has too much due this week?", "Team capacity check", "How is work spread across the team?"
+Tasks are grouped by assignee and week of their due date, weeks starting on the configured week start; overdue tasks count in the current week. A user is overloaded when one week holds weekly_limit or more tasks.
+Bars count when the API exposes an owner and the bar is still active (not a container, not 100% done, not ended). bar_owners is false when no bar carried an owner. Use skip_bars to avoid fetching every roadmap.
+Team totals come from list_teams membership. Finished tasks are skipped.`,
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"weekly_limit": {Type: "integer", Description: "Tasks due in one week from which a user is overloaded (default 5)", Minimum: floatPtr(1), Maximum: floatPtr(100)},
+					"roadmap_ids":  {Type: "array", Description: "Roadmaps whose bars count toward ownership (default: all)", Items: &mcp.Property{Type: "string", Description: "Roadmap ID"}},
+					"skip_bars":    {Type: "boolean", Description: "Leave bar ownership out (default false)"},
+				},
+			},
+			OutputSchema: analysisOutputSchema(userWorkloadData),
+		}),
+	}
+}
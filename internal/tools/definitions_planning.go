@@ -0,0 +1,267 @@
+package tools
+
+import (
+	"github.com/olgasafonova/productplan-mcp-server/internal/analysis"
+	"github.com/olgasafonova/productplan-mcp-server/internal/launchtemplate"
+	"github.com/olgasafonova/productplan-mcp-server/internal/mcp"
+)
+
+// planningTool marks a tool that computes over the API and may write
+// results back. Writes only add or update, so it is not destructive, but
+// it is not read-only either. It keeps the analysis tools' structured
+// output.
+func planningTool(tool mcp.Tool) mcp.Tool {
+	tool.Annotations = &mcp.ToolAnnotations{
+		DestructiveHint: boolPtr(false),
+	}
+	return tool
+}
+
+// estimateProperties describe a stored estimate, shared by input and output.
+func estimateProperties() map[string]mcp.Property {
+	return map[string]mcp.Property{
+		"reach":      {Type: "number", Description: "RICE reach: people or accounts affected per period (default: customers asking, else linked ideas)"},
+		"impact":     {Type: "number", Description: "Impact (RICE: 0.25-3, ICE: 1-10)"},
+		"confidence": {Type: "number", Description: "Confidence as a fraction, 0-1 (e.g. 0.8)"},
+		"effort":     {Type: "number", Description: "RICE effort, e.g. person-months; must be greater than 0"},
+		"ease":       {Type: "number", Description: "ICE ease, 1-10"},
+		"criteria":   {Type: "object", Description: "Custom criterion name to value (0-10 suggested) for the weighted model"},
+	}
+}
+
+// opportunityScoresData describes the score_opportunities result.
+var opportunityScoresData = mcp.Property{
+	Type:        "object",
+	Description: "Ranked opportunity backlog",
+	Properties: map[string]mcp.Property{
+		"model":    {Type: "string", Description: "Scoring model", Enum: analysis.ScoringModels},
+		"formula":  {Type: "string", Description: "How the score is computed"},
+		"weights":  {Type: "object", Description: "Criterion weights (weighted model only)"},
+		"scored":   {Type: "integer", Description: "Opportunities with a score"},
+		"unscored": {Type: "integer", Description: "Opportunities missing an input"},
+		"opportunities": {Type: "array", Description: "Scored opportunities by rank, then unscored ones", Items: &mcp.Property{
+			Type:        "object",
+			Description: "Opportunity score",
+			Properties: map[string]mcp.Property{
+				"rank":              {Type: "integer", Description: "1-based rank among scored opportunities"},
+				"id":                {Type: "string", Description: "Opportunity ID"},
+				"problem_statement": {Type: "string", Description: "Problem statement"},
+				"status":            {Type: "string", Description: "Workflow status"},
+				"score":             {Type: "number", Description: "Score, null when an input is missing"},
+				"missing":           {Type: "array", Description: "Inputs to estimate before this can be scored", Items: &mcp.Property{Type: "string", Description: "Input name"}},
+				"inputs": {Type: "object", Description: "Values the score used", Properties: func() map[string]mcp.Property {
+					p := estimateProperties()
+					p["ideas"] = mcp.Property{Type: "integer", Description: "Linked ideas"}
+					p["customers"] = mcp.Property{Type: "integer", Description: "Distinct customers on linked ideas"}
+					p["demand"] = mcp.Property{Type: "number", Description: "Customer demand, weighted by customer_values"}
+					p["reach_source"] = mcp.Property{Type: "string", Description: "Where reach came from", Enum: []string{analysis.ReachEstimate, analysis.ReachCustomers, analysis.ReachIdeas}}
+					return p
+				}()},
+			},
+			Required: []string{"id", "problem_statement", "score", "inputs"},
+		}},
+		"written": {Type: "array", Description: "Opportunities whose description was updated (write_back only)", Items: &mcp.Property{Type: "string", Description: "Opportunity ID"}},
+	},
+	Required: []string{"model", "formula", "scored", "unscored", "opportunities"},
+}
+
+// promotedItemData describes the promote_to_roadmap result.
+var promotedItemData = mcp.Property{
+	Type:        "object",
+	Description: "Bar created from discovery work",
+	Properties: map[string]mcp.Property{
+		"source_type":     {Type: "string", Description: "Promoted item type", Enum: []string{"idea", "opportunity"}},
+		"source_id":       {Type: "string", Description: "Idea or opportunity ID"},
+		"source_name":     {Type: "string", Description: "Idea name or opportunity problem statement"},
+		"source_url":      {Type: "string", Description: "Link added to the bar, back to the source"},
+		"bar_id":          {Type: "string", Description: "Created bar ID"},
+		"roadmap_id":      {Type: "string", Description: "Roadmap the bar is on"},
+		"lane_id":         {Type: "string", Description: "Lane the bar is in"},
+		"name":            {Type: "string", Description: "Bar name"},
+		"tags":            {Type: "array", Description: "Bar tags", Items: &mcp.Property{Type: "string", Description: "Tag"}},
+		"workflow_status": {Type: "string", Description: "Opportunity's new workflow status (opportunities only)", Enum: opportunityStatuses},
+	},
+	Required: []string{"source_type", "source_id", "source_url", "bar_id", "roadmap_id", "lane_id", "name", "tags"},
+}
+
+// templateTaskProperties describe a template task as stored in a file.
+var templateTaskProperties = map[string]mcp.Property{
+	"name":        {Type: "string", Description: "Task name"},
+	"description": {Type: "string", Description: "Task description"},
+	"due":         {Type: "string", Description: "Due offset from the launch date, e.g. T-14d, T-2w, T or T+3d"},
+	"role":        {Type: "string", Description: "Role the task is assigned to"},
+}
+
+// launchTemplateData describes the apply_launch_template result.
+var launchTemplateData = mcp.Property{
+	Type:        "object",
+	Description: "Template applied to a launch",
+	Properties: map[string]mcp.Property{
+		"template":    {Type: "string", Description: "Template name"},
+		"launch_id":   {Type: "string", Description: "Launch ID"},
+		"launch_date": {Type: "string", Description: "Date due offsets were resolved from"},
+		"dry_run":     {Type: "boolean", Description: "True when nothing was written"},
+		"sections": {Type: "array", Description: "Template sections in order", Items: &mcp.Property{
+			Type:        "object",
+			Description: "Section",
+			Properties: map[string]mcp.Property{
+				"name":   {Type: "string", Description: "Section name"},
+				"id":     {Type: "string", Description: "Section ID (empty on a dry run for new sections)"},
+				"status": {Type: "string", Description: "Outcome", Enum: []string{launchtemplate.StatusPlanned, launchtemplate.StatusCreated, launchtemplate.StatusExists}},
+				"tasks": {Type: "array", Description: "Tasks in the section", Items: &mcp.Property{
+					Type:        "object",
+					Description: "Task",
+					Properties: map[string]mcp.Property{
+						"name":             {Type: "string", Description: "Task name"},
+						"description":      {Type: "string", Description: "Task description"},
+						"due":              {Type: "string", Description: "Due offset from the template"},
+						"due_date":         {Type: "string", Description: "Resolved due date (YYYY-MM-DD)"},
+						"role":             {Type: "string", Description: "Role from the template"},
+						"assigned_user_id": {Type: "string", Description: "User the role mapped to"},
+						"assignee":         {Type: "string", Description: "Assigned user's name"},
+						"id":               {Type: "string", Description: "Task ID once created or matched"},
+						"status":           {Type: "string", Description: "Outcome", Enum: []string{launchtemplate.StatusPlanned, launchtemplate.StatusCreated, launchtemplate.StatusExists}},
+					},
+					Required: []string{"name", "status"},
+				}},
+			},
+			Required: []string{"name", "status", "tasks"},
+		}},
+		"sections_created": {Type: "integer", Description: "Sections created (or to create on a dry run)"},
+		"tasks_created":    {Type: "integer", Description: "Tasks created (or to create on a dry run)"},
+		"skipped":          {Type: "integer", Description: "Tasks already on the launch"},
+		"unmapped_roles":   {Type: "array", Description: "Roles with no user; their tasks are left unassigned", Items: &mcp.Property{Type: "string", Description: "Role"}},
+	},
+	Required: []string{"template", "launch_id", "dry_run", "sections", "sections_created", "tasks_created", "skipped", "unmapped_roles"},
+}
+
+// savedTemplateData describes the save_launch_as_template result.
+var savedTemplateData = mcp.Property{
+	Type:        "object",
+	Description: "Template file written from a launch",
+	Properties: map[string]mcp.Property{
+		"path":     {Type: "string", Description: "Template file path"},
+		"sections": {Type: "integer", Description: "Sections in the template"},
+		"tasks":    {Type: "integer", Description: "Tasks in the template"},
+		"template": {Type: "object", Description: "The template as saved", Properties: map[string]mcp.Property{
+			"name":        {Type: "string", Description: "Template name, from the launch"},
+			"description": {Type: "string", Description: "Template description"},
+			"roles":       {Type: "object", Description: "Role to user ID, one role per assignee"},
+			"sections": {Type: "array", Description: "Sections in checklist order", Items: &mcp.Property{
+				Type:        "object",
+				Description: "Section",
+				Properties: map[string]mcp.Property{
+					"name":  {Type: "string", Description: "Section name"},
+					"tasks": {Type: "array", Description: "Tasks", Items: &mcp.Property{Type: "object", Description: "Task", Properties: templateTaskProperties}},
+				},
+			}},
+		}},
+	},
+	Required: []string{"path", "sections", "tasks", "template"},
+}
+
+// planningTools returns tools that compute over the API and can write the
+// result back.
+func planningTools() []mcp.Tool {
+	estimate := estimateProperties()
+	estimate["opportunity_id"] = mcp.Property{Type: "string", Description: "Opportunity ID"}
+
+	return []mcp.Tool{
+		planningTool(mcp.Tool{
+			Name: "score_opportunities",
+			Description: `Score and rank opportunities with RICE, ICE or weighted criteria, and optionally write each score into the opportunity description.
+
+USE WHEN: "Prioritise our opportunities", "RICE score the backlog", "Rank opportunities by demand and strategic fit", "Save these estimates and rescore"
+Inputs come from ProductPlan (linked ideas, distinct customers, demand weighted by customer_values) plus estimates. Estimates passed in are saved locally and reused on later calls, so only send what changed.
+Models:
+- rice: reach × impact × confidence ÷ effort. Reach defaults to the customers asking, else the linked ideas.
+- ice: impact × (confidence × 10) × ease.
+- weighted: Σ(weight × criterion) ÷ Σ|weight|. "ideas" and "demand" are built-in criteria scaled 0-10; other names come from each estimate's criteria. Use a negative weight for costs.
+Opportunities missing an input are listed after the ranking with the inputs to estimate.
+write_back adds or replaces a "Priority score (...)" line in each scored opportunity's description.`,
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"model":   {Type: "string", Description: "Scoring model (default rice)", Enum: analysis.ScoringModels},
+					"weights": {Type: "object", Description: "Criterion name to weight (weighted model)", Examples: []any{map[string]any{"demand": 2, "strategic_fit": 3, "effort": -1}}},
+					"estimates": {Type: "array", Description: "Estimates to save before scoring; fields left out keep their stored value", Items: &mcp.Property{
+						Type:        "object",
+						Description: "Estimates for one opportunity",
+						Properties:  estimate,
+						Required:    []string{"opportunity_id"},
+					}},
+					"opportunity_ids": {Type: "array", Description: "Rank only these opportunities (default: all open ones)", Items: &mcp.Property{Type: "string", Description: "Opportunity ID"}},
+					"customer_values": {Type: "object", Description: "Customer name to value for weighting demand, as in rank_customer_demand"},
+					"include_closed":  {Type: "boolean", Description: "Include closed opportunities (default false)"},
+					"write_back":      {Type: "boolean", Description: "Write each score into its opportunity description (default false)"},
+				},
+			},
+			OutputSchema: analysisOutputSchema(opportunityScoresData),
+		}),
+		planningTool(mcp.Tool{
+			Name: "promote_to_roadmap",
+			Description: `Turn an idea or opportunity into a roadmap bar, linked back to its source.
+
+USE WHEN: "Put this opportunity on the roadmap", "Promote idea 42 to the Q3 lane", "Move validated discovery work into delivery"
+Creates a bar named after the idea or the opportunity's problem statement (override with name), carrying over its description and tags plus any extra tags. Adds a link on the bar back to the source, and sets an opportunity's workflow_status (default completed).
+Each call creates a new bar. If a step after the bar is created fails, the error names that bar; fix it there rather than promoting again.
+FAILS WHEN: source, roadmap or lane not found (get IDs from list_ideas, list_opportunities and get_roadmap_lanes), or workflow_status is passed for an idea.`,
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"source_type":     {Type: "string", Description: "What to promote", Enum: []string{"idea", "opportunity"}},
+					"source_id":       {Type: "string", Description: "Idea or opportunity ID"},
+					"roadmap_id":      {Type: "string", Description: "Target roadmap ID"},
+					"lane_id":         {Type: "string", Description: "Target lane ID"},
+					"name":            {Type: "string", Description: "Bar name (default: idea name or problem statement)"},
+					"starts_on":       dateExprProperty("Bar start date", true, "2025-03-15"),
+					"ends_on":         dateExprProperty("Bar end date", true, "2025-06-30"),
+					"tags":            {Type: "array", Description: "Tags to add on top of the source's tags", Items: &mcp.Property{Type: "string", Description: "Tag"}},
+					"workflow_status": {Type: "string", Description: "Opportunity's new workflow status (default completed)", Enum: opportunityStatuses},
+				},
+				Required: []string{"source_type", "source_id", "roadmap_id", "lane_id"},
+			},
+			OutputSchema: analysisOutputSchema(promotedItemData),
+		}),
+		planningTool(mcp.Tool{
+			Name: "apply_launch_template",
+			Description: `Create a launch's checklist sections and tasks from a saved YAML or JSON template.
+
+USE WHEN: "Set up the standard checklist on this launch", "Apply our launch template", "Add legal, docs and enablement tasks to launch 12"
+Templates live in the launch template directory (PRODUCTPLAN_TEMPLATES_DIR) and are named by file name without extension. Due dates are offsets from the launch date (T-14d, T-2w, T, T+3d); pass launch_date when the launch has none. Task roles are mapped to users through roles (user ID, email or name), on top of the template's own roles; tasks with unmapped roles are created unassigned and listed.
+Sections that already exist by name are reused and tasks already in them are skipped, so applying twice adds nothing. Use dry_run to preview.
+FAILS WHEN: template or launch not found, a role maps to no user, or a due offset needs a launch date that is missing.`,
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"launch_id":   {Type: "string", Description: "Launch ID"},
+					"template":    {Type: "string", Description: "Template name, e.g. standard for standard.yaml"},
+					"roles":       {Type: "object", Description: "Role to user ID, email or name", Examples: []any{map[string]any{"legal": "ana@example.com", "pmm": "42"}}},
+					"launch_date": {Type: "string", Description: "Date to resolve due offsets from (YYYY-MM-DD, default: the launch's date)", Pattern: `^\d{4}-\d{2}-\d{2}$`},
+					"dry_run":     {Type: "boolean", Description: "Show what would be created without writing (default false)"},
+				},
+				Required: []string{"launch_id", "template"},
+			},
+			OutputSchema: analysisOutputSchema(launchTemplateData),
+		}),
+		planningTool(mcp.Tool{
+			Name: "save_launch_as_template",
+			Description: `Save an existing launch's checklist as a reusable template file.
+
+USE WHEN: "Turn this launch into a template", "Save the v2 checklist for next time"
+Writes the launch's sections and tasks to the launch template directory (PRODUCTPLAN_TEMPLATES_DIR). Due dates become offsets from the launch date, and each assignee becomes a role named after them, recorded with their user ID under roles. Edit the file to rename roles, then apply it with apply_launch_template.
+FAILS WHEN: launch not found, or a template with that name exists and overwrite is not set.`,
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"launch_id": {Type: "string", Description: "Launch ID"},
+					"name":      {Type: "string", Description: "Template name (letters, digits, '.', '_' or '-')", Pattern: `^[A-Za-z0-9][A-Za-z0-9_.-]*$`},
+					"format":    {Type: "string", Description: "File format (default yaml)", Enum: launchtemplate.Formats},
+					"overwrite": {Type: "boolean", Description: "Replace an existing template of the same name (default false)"},
+				},
+				Required: []string{"launch_id", "name"},
+			},
+			OutputSchema: analysisOutputSchema(savedTemplateData),
+		}),
+	}
+}
@@ -0,0 +1,61 @@
+package tools
+
+import "github.com/olgasafonova/productplan-mcp-server/internal/mcp"
+
+// derivedReadOnly marks a tool that computes over read-only API calls but
+// whose name falls outside the get_/list_ convention BuildAllTools keys on.
+// It applies the same annotations and FormattedResponse output schema that
+// BuildAllTools gives get_*/list_* tools.
+func derivedReadOnly(tool mcp.Tool) mcp.Tool {
+	tool.Annotations = &mcp.ToolAnnotations{
+		ReadOnlyHint:   true,
+		IdempotentHint: true,
+	}
+	if tool.OutputSchema == nil {
+		tool.OutputSchema = readOutputSchema()
+	}
+	return tool
+}
+
+// reportTools returns export and report tool definitions.
+func reportTools() []mcp.Tool {
+	return []mcp.Tool{
+		derivedReadOnly(mcp.Tool{
+			Name: "export_ics",
+			Description: `Export roadmap milestones, launches and launch task due dates as an iCalendar (.ics) file.
+
+USE WHEN: "Put our milestones in my calendar", "Calendar feed of launch dates"
+Returns event_count and the ics text. Event UIDs are stable, so re-importing updates existing entries instead of duplicating them.
+FAILS WHEN: a roadmap_id is not found (use list_roadmaps), or roadmap_ids is empty and include_launches=false.`,
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"roadmap_ids":      {Type: "array", Description: "Roadmaps whose milestones to export", Items: &mcp.Property{Type: "string", Description: "Roadmap ID"}},
+					"include_launches": {Type: "boolean", Description: "Include launch dates (default true)"},
+					"include_tasks":    {Type: "boolean", Description: "Include launch task due dates (default true)"},
+				},
+			},
+		}),
+		derivedReadOnly(mcp.Tool{
+			Name: "generate_roadmap_report",
+			Description: `Render a Markdown status report per roadmap: each lane's bars grouped by now/next/later (or by fiscal quarter) with percent-done bars, upcoming milestones, and recent comments.
+
+USE WHEN: "Write the weekly roadmap update", "Summarise the roadmap for Slack", "What's in flight this quarter?"
+Returns reports[] with roadmap_id, roadmap and markdown. Set compact=true for a short Slack-ready summary (no comments).
+Now = started and not finished; Next = starting within 90 days; Later = after that; finished bars stay for 14 days. Parked bars are left out.
+Quarters follow the configured fiscal year, labelled "FY2027 Q1" (or "2026 Q3" for calendar quarters).
+FAILS WHEN: roadmap_ids is empty or a roadmap_id is not found (use list_roadmaps).`,
+			InputSchema: mcp.InputSchema{
+				Type: "object",
+				Properties: map[string]mcp.Property{
+					"roadmap_ids":    {Type: "array", Description: "Roadmaps to report on, one document each", Items: &mcp.Property{Type: "string", Description: "Roadmap ID"}},
+					"group_by":       {Type: "string", Description: "Group bars by horizon (now/next/later, default) or by fiscal quarter", Enum: []string{"horizon", "quarter"}},
+					"milestone_days": {Type: "integer", Description: "Include milestones within this many days (default 30, 0 to omit)", Minimum: floatPtr(0), Maximum: floatPtr(365)},
+					"comment_limit":  {Type: "integer", Description: "Number of recent comments to include (default 5, 0 to omit)", Minimum: floatPtr(0), Maximum: floatPtr(50)},
+					"compact":        {Type: "boolean", Description: "Render a short Slack-ready summary instead of the full document"},
+				},
+				Required: []string{"roadmap_ids"},
+			},
+		}),
+	}
+}
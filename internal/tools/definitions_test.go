@@ -11,8 +11,8 @@ func TestBuildAllTools(t *testing.T) {
 		t.Fatal("expected tools to be registered")
 	}
 
-	if len(tools) != 47 {
-		t.Errorf("expected 47 tools, got %d", len(tools))
+	if len(tools) != 66 {
+		t.Errorf("expected 66 tools, got %d", len(tools))
 	}
 }
 
@@ -34,6 +34,7 @@ func TestBuildAllToolsNames(t *testing.T) {
 		// Bars
 		"get_bar",
 		"get_bar_children",
+		"get_bar_tree",
 		"get_bar_comments",
 		"get_bar_connections",
 		"get_bar_links",
@@ -73,6 +74,26 @@ func TestBuildAllToolsNames(t *testing.T) {
 		"health_check",
 		"list_users",
 		"list_teams",
+		"get_timeframe",
+		// Exports and reports
+		"export_ics",
+		"generate_roadmap_report",
+		// Analyses
+		"okr_progress",
+		"objective_coverage",
+		"analyze_dependencies",
+		"detect_schedule_conflicts",
+		"lint_roadmap",
+		"find_stale_items",
+		"cluster_ideas",
+		"rank_customer_demand",
+		"launch_readiness",
+		"user_workload",
+		// Planning
+		"score_opportunities",
+		"promote_to_roadmap",
+		"apply_launch_template",
+		"save_launch_as_template",
 	}
 
 	names := make(map[string]bool)
@@ -132,8 +153,8 @@ func TestRoadmapTools(t *testing.T) {
 func TestBarTools(t *testing.T) {
 	tools := barTools()
 
-	if len(tools) != 8 {
-		t.Errorf("expected 8 bar tools, got %d", len(tools))
+	if len(tools) != 9 {
+		t.Errorf("expected 9 bar tools, got %d", len(tools))
 	}
 }
 
@@ -164,8 +185,59 @@ func TestLaunchTools(t *testing.T) {
 func TestUtilityTools(t *testing.T) {
 	tools := utilityTools()
 
+	if len(tools) != 6 {
+		t.Errorf("expected 6 utility tools, got %d", len(tools))
+	}
+}
+
+func TestReportTools(t *testing.T) {
+	tools := reportTools()
+
+	if len(tools) != 2 {
+		t.Errorf("expected 2 report tools, got %d", len(tools))
+	}
+	for _, tool := range tools {
+		if tool.Annotations == nil || !tool.Annotations.ReadOnlyHint {
+			t.Errorf("report tool %q should be annotated read-only", tool.Name)
+		}
+		if tool.OutputSchema == nil {
+			t.Errorf("report tool %q should declare an OutputSchema", tool.Name)
+		}
+	}
+}
+
+func TestAnalysisTools(t *testing.T) {
+	tools := analysisTools()
+
+	if len(tools) != 10 {
+		t.Errorf("expected 10 analysis tools, got %d", len(tools))
+	}
+	for _, tool := range tools {
+		if tool.Annotations == nil || !tool.Annotations.ReadOnlyHint {
+			t.Errorf("analysis tool %q should be annotated read-only", tool.Name)
+		}
+		if tool.OutputSchema == nil {
+			t.Fatalf("analysis tool %q should declare an OutputSchema", tool.Name)
+		}
+		if tool.OutputSchema.Properties["data"].Properties == nil {
+			t.Errorf("analysis tool %q should describe its data fields", tool.Name)
+		}
+	}
+}
+
+func TestPlanningTools(t *testing.T) {
+	tools := planningTools()
+
 	if len(tools) != 4 {
-		t.Errorf("expected 4 utility tools, got %d", len(tools))
+		t.Errorf("expected 4 planning tools, got %d", len(tools))
+	}
+	for _, tool := range tools {
+		if tool.Annotations == nil || tool.Annotations.ReadOnlyHint || tool.Annotations.DestructiveHint == nil || *tool.Annotations.DestructiveHint {
+			t.Errorf("planning tool %q should be annotated as a non-destructive write", tool.Name)
+		}
+		if tool.OutputSchema == nil || tool.OutputSchema.Properties["data"].Properties == nil {
+			t.Errorf("planning tool %q should describe its data fields", tool.Name)
+		}
 	}
 }
 
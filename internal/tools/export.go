@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+	"github.com/olgasafonova/productplan-mcp-server/internal/export"
+	"github.com/olgasafonova/productplan-mcp-server/internal/mcp"
+)
+
+func exportICSHandler(client *api.Client) mcp.Handler {
+	return typedHandler[ExportICSArgs](func(ctx context.Context, a ExportICSArgs) (json.RawMessage, error) {
+		cal, err := export.BuildCalendar(ctx, client, export.CalendarOptions{
+			RoadmapIDs:      a.RoadmapIDs,
+			IncludeLaunches: boolOr(a.IncludeLaunches, true),
+			IncludeTasks:    boolOr(a.IncludeTasks, true),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(map[string]any{
+			"event_count": len(cal.Events),
+			"ics":         cal.Encode(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(FormattedResponse{
+			Summary: fmt.Sprintf("Exported %d %s to iCalendar", len(cal.Events), pluralize("event", len(cal.Events))),
+			Data:    data,
+		})
+	})
+}
+
+func generateRoadmapReportHandler(client *api.Client, cal dates.Calendar) mcp.Handler {
+	return typedHandler[GenerateRoadmapReportArgs](func(ctx context.Context, a GenerateRoadmapReportArgs) (json.RawMessage, error) {
+		defaults := export.DefaultReportOptions()
+		opts := export.ReportOptions{
+			GroupBy:       a.GroupBy,
+			MilestoneDays: intOr(a.MilestoneDays, defaults.MilestoneDays),
+			CommentLimit:  intOr(a.CommentLimit, defaults.CommentLimit),
+			Calendar:      cal,
+		}
+		if a.Compact {
+			// The compact variant never shows comments; skip the fetch.
+			opts.CommentLimit = 0
+		}
+
+		type roadmapReport struct {
+			RoadmapID string `json:"roadmap_id"`
+			Roadmap   string `json:"roadmap"`
+			Markdown  string `json:"markdown"`
+		}
+		reports := make([]roadmapReport, 0, len(a.RoadmapIDs))
+		for _, id := range a.RoadmapIDs {
+			r, err := export.BuildReport(ctx, client, id, opts)
+			if err != nil {
+				return nil, err
+			}
+			md := r.Markdown()
+			if a.Compact {
+				md = r.Compact()
+			}
+			reports = append(reports, roadmapReport{RoadmapID: id, Roadmap: r.Roadmap.Name, Markdown: md})
+		}
+
+		data, err := json.Marshal(map[string]any{"reports": reports})
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(FormattedResponse{
+			Summary: fmt.Sprintf("Generated %d roadmap %s", len(reports), pluralize("report", len(reports))),
+			Data:    data,
+		})
+	})
+}
@@ -0,0 +1,130 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+)
+
+// decodeResponse unwraps a FormattedResponse and decodes its data into T.
+func decodeResponse[T any](t *testing.T, raw json.RawMessage) (string, T) {
+	t.Helper()
+	var resp FormattedResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	var data T
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	return resp.Summary, data
+}
+
+func TestExportICSHandler(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/roadmaps/1":            `{"id": 1, "name": "Core"}`,
+		"/roadmaps/1/milestones": `[{"id": 2, "title": "Beta", "date": "2026-05-01"}]`,
+		"/launches":              `[{"id": 3, "name": "Launch", "date": "2026-06-01"}]`,
+		"/launches/3/tasks":      `[]`,
+	})
+
+	result, err := exportICSHandler(client).Handle(context.Background(), map[string]any{
+		"roadmap_ids": []any{"1"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	summary, data := decodeResponse[struct {
+		EventCount int    `json:"event_count"`
+		ICS        string `json:"ics"`
+	}](t, result)
+	if data.EventCount != 2 {
+		t.Errorf("expected 2 events, got %d", data.EventCount)
+	}
+	if !strings.HasPrefix(data.ICS, "BEGIN:VCALENDAR") {
+		t.Errorf("expected ics text, got %q", data.ICS)
+	}
+	if !strings.Contains(summary, "2 events") {
+		t.Errorf("unexpected summary %q", summary)
+	}
+}
+
+func TestExportICSHandlerNothingSelected(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{})
+
+	_, err := exportICSHandler(client).Handle(context.Background(), map[string]any{
+		"include_launches": false,
+	})
+	if err == nil || !strings.Contains(err.Error(), "nothing to export") {
+		t.Errorf("expected nothing-to-export error, got %v", err)
+	}
+}
+
+func TestGenerateRoadmapReportHandler(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/roadmaps/1":            `{"id": 1, "name": "Core"}`,
+		"/roadmaps/1/lanes":      `[{"id": 3, "name": "Backend"}]`,
+		"/roadmaps/1/bars":       `[{"id": 10, "name": "Search", "lane_id": 3, "starts_on": "2020-01-01", "ends_on": "2099-01-01", "percent_done": 40}]`,
+		"/roadmaps/1/milestones": `[]`,
+		"/roadmaps/1/comments":   `[]`,
+	})
+
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"full", map[string]any{"roadmap_ids": []any{"1"}}, "## Backend"},
+		{"compact", map[string]any{"roadmap_ids": []any{"1"}, "compact": true}, "*Now:* Search 40%"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := generateRoadmapReportHandler(client, dates.Calendar{}).Handle(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			summary, data := decodeResponse[struct {
+				Reports []struct {
+					RoadmapID string `json:"roadmap_id"`
+					Roadmap   string `json:"roadmap"`
+					Markdown  string `json:"markdown"`
+				} `json:"reports"`
+			}](t, result)
+			if len(data.Reports) != 1 || data.Reports[0].Roadmap != "Core" {
+				t.Fatalf("unexpected reports %+v", data.Reports)
+			}
+			if !strings.Contains(data.Reports[0].Markdown, tt.want) {
+				t.Errorf("expected %q in:\n%s", tt.want, data.Reports[0].Markdown)
+			}
+			if summary != "Generated 1 roadmap report" {
+				t.Errorf("unexpected summary %q", summary)
+			}
+		})
+	}
+}
+
+func TestGenerateRoadmapReportArgsValidate(t *testing.T) {
+	days := 400
+	tests := []struct {
+		name    string
+		args    GenerateRoadmapReportArgs
+		errPart string
+	}{
+		{"no roadmaps", GenerateRoadmapReportArgs{}, "roadmap_ids"},
+		{"bad grouping", GenerateRoadmapReportArgs{RoadmapIDs: []string{"1"}, GroupBy: "month"}, "group_by"},
+		{"days out of range", GenerateRoadmapReportArgs{RoadmapIDs: []string{"1"}, MilestoneDays: &days}, "milestone_days"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.args.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.errPart) {
+				t.Errorf("expected error containing %q, got %v", tt.errPart, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/mcp"
+)
+
+// ToolFilter selects which tools RegisterAll exposes. The zero value
+// exposes every tool.
+type ToolFilter struct {
+	// Include lists tool names or path.Match patterns such as "get_*".
+	// Empty means all tools.
+	Include []string
+	// Exclude hides matching tools, even when Include matches them.
+	Exclude []string
+	// ReadOnly hides tools not annotated read-only.
+	ReadOnly bool
+}
+
+// Allows reports whether the filter exposes tool.
+func (f ToolFilter) Allows(tool mcp.Tool) bool {
+	if f.ReadOnly && (tool.Annotations == nil || !tool.Annotations.ReadOnlyHint) {
+		return false
+	}
+	if len(f.Include) > 0 && !matchAny(f.Include, tool.Name) {
+		return false
+	}
+	return !matchAny(f.Exclude, tool.Name)
+}
+
+// Check reports patterns that match none of tools, so a typo cannot
+// silently expose or hide the wrong set.
+func (f ToolFilter) Check(tools []mcp.Tool) error {
+	var unknown []string
+	for _, pattern := range append(slices.Clone(f.Include), f.Exclude...) {
+		if !slices.ContainsFunc(tools, func(t mcp.Tool) bool { return matchAny([]string{pattern}, t.Name) }) {
+			unknown = append(unknown, pattern)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("tool filter matches no tool: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// withDefaultRoadmap makes roadmap_id optional on a tool that requires it,
+// filling in roadmapID when the caller leaves it out. Tools without a
+// required roadmap_id are returned unchanged.
+func withDefaultRoadmap(tool mcp.Tool, handler mcp.Handler, roadmapID string) (mcp.Tool, mcp.Handler) {
+	tool, ok := optionalRoadmap(tool, "default "+roadmapID)
+	if !ok {
+		return tool, handler
+	}
+	return tool, fillRoadmap(handler, roadmapID)
+}
+
+// optionalRoadmap drops roadmap_id from tool's required arguments and
+// appends note to its description. It reports false, leaving tool as it
+// is, when roadmap_id is not required.
+func optionalRoadmap(tool mcp.Tool, note string) (mcp.Tool, bool) {
+	i := slices.Index(tool.InputSchema.Required, "roadmap_id")
+	if i < 0 {
+		return tool, false
+	}
+	props := maps.Clone(tool.InputSchema.Properties)
+	prop := props["roadmap_id"]
+	prop.Description += " (" + note + ")"
+	props["roadmap_id"] = prop
+	tool.InputSchema.Properties = props
+	tool.InputSchema.Required = slices.Delete(slices.Clone(tool.InputSchema.Required), i, i+1)
+	return tool, true
+}
+
+// fillRoadmap sets roadmap_id to roadmapID when the caller leaves it out.
+func fillRoadmap(handler mcp.Handler, roadmapID string) mcp.Handler {
+	return mcp.HandlerFunc(func(ctx context.Context, args map[string]any) (json.RawMessage, error) {
+		if v, ok := args["roadmap_id"]; !ok || v == nil || v == "" {
+			filled := maps.Clone(args)
+			if filled == nil {
+				filled = make(map[string]any, 1)
+			}
+			filled["roadmap_id"] = roadmapID
+			args = filled
+		}
+		return handler.Handle(ctx, args)
+	})
+}
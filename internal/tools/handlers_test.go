@@ -7,8 +7,12 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/olgasafonova/productplan-mcp-server/internal/analysis"
 	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
 )
 
 func setupTestServer(t *testing.T, response any) (*httptest.Server, *api.Client) {
@@ -165,7 +169,7 @@ func TestManageMilestoneHandler(t *testing.T) {
 	server, client := setupTestServer(t, map[string]any{"id": "milestone-1"})
 	defer server.Close()
 
-	handler := manageMilestoneHandler(client)
+	handler := manageMilestoneHandler(client, dates.Calendar{})
 
 	tests := []struct {
 		name string
@@ -225,11 +229,35 @@ func TestBarHandlers(t *testing.T) {
 	}
 }
 
+func TestGetBarTreeHandler(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/bars/1":            `{"id": 1, "name": "Epic"}`,
+		"/bars/1/child_bars": `[{"id": 2, "name": "Story", "effort": 3}, {"id": 1, "name": "Epic"}]`,
+		"/bars/2/child_bars": `[]`,
+	})
+
+	result, err := getBarTreeHandler(client).Handle(context.Background(), map[string]any{"bar_id": "1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary, tree := decodeResponse[analysis.BarTree](t, result)
+	if summary != `Bar tree for "Epic": 2 bars, 1 level deep; 1 cycle skipped` {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	if *tree.Root.TotalEffort != 3 || !strings.Contains(tree.Outline, "  - Story (#2)") {
+		t.Errorf("unexpected tree %+v", tree)
+	}
+
+	if _, err = getBarTreeHandler(client).Handle(context.Background(), map[string]any{"bar_id": "1", "max_depth": 21}); err == nil {
+		t.Error("expected error for max_depth 21")
+	}
+}
+
 func TestManageBarHandler(t *testing.T) {
 	server, client := setupTestServer(t, map[string]any{"id": "bar-1"})
 	defer server.Close()
 
-	handler := manageBarHandler(client)
+	handler := manageBarHandler(client, dates.Calendar{})
 
 	percentDone := 50
 	container := true
@@ -539,6 +567,36 @@ func TestUtilityHandlers(t *testing.T) {
 	}
 }
 
+func TestGetTimeframeHandler(t *testing.T) {
+	cal := dates.Calendar{FiscalYearStart: time.February}
+	result, err := getTimeframeHandler(cal).Handle(context.Background(), map[string]any{"dates": []any{"2026-10-18"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary, data := decodeResponse[struct {
+		Timeframes []dates.Timeframe `json:"timeframes"`
+	}](t, result)
+	want := "2026-10-18 is in FY2027 Q3 (2026-08-01..2026-10-31), day 79 of 92; week 2026-W42 starts 2026-10-12 (fiscal year starting February, weeks starting Monday)"
+	if summary != want {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	if len(data.Timeframes) != 1 || data.Timeframes[0].Half.Label != "FY2027 H2" {
+		t.Errorf("unexpected timeframes %+v", data.Timeframes)
+	}
+
+	result, err = getTimeframeHandler(cal).Handle(context.Background(), map[string]any{"dates": []any{"2026-02-01", "end of FY2027"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary, _ = decodeResponse[json.RawMessage](t, result); summary != "Mapped 2 dates: 2026-02-01 FY2027 Q1, 2027-01-31 FY2027 Q4 (fiscal year starting February, weeks starting Monday)" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+
+	if _, err = getTimeframeHandler(cal).Handle(context.Background(), map[string]any{"dates": []any{"someday"}}); err == nil {
+		t.Error("expected error for an unresolvable date")
+	}
+}
+
 func TestHandlerMissingRequiredParams(t *testing.T) {
 	server, client := setupTestServer(t, map[string]any{})
 	defer server.Close()
@@ -549,6 +607,7 @@ func TestHandlerMissingRequiredParams(t *testing.T) {
 		errPart string
 	}{
 		{"getBarHandler", getBarHandler(client), "bar_id"},
+		{"getBarTreeHandler", getBarTreeHandler(client), "bar_id"},
 		{"getObjectiveHandler", getObjectiveHandler(client), "objective_id"},
 		{"getIdeaHandler", getIdeaHandler(client), "idea_id"},
 		{"getLaunchHandler", getLaunchHandler(client), "launch_id"},
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
 	"github.com/olgasafonova/productplan-mcp-server/internal/mcp"
 )
 
@@ -125,11 +126,21 @@ func getLaunchTaskHandler(client *api.Client) mcp.Handler {
 	})
 }
 
-func manageLaunchTaskHandler(client *api.Client) mcp.Handler {
+// manageLaunchTaskHandler creates, updates or deletes a launch task. The
+// due date may be an expression, resolved against cal before the request;
+// tasks have no roadmap, so milestone references are rejected.
+func manageLaunchTaskHandler(client *api.Client, cal dates.Calendar) mcp.Handler {
 	return typedHandler[ManageLaunchTaskArgs](func(ctx context.Context, a ManageLaunchTaskArgs) (json.RawMessage, error) {
 		var data json.RawMessage
 		var err error
 
+		var notes []string
+		if a.Action == "create" || a.Action == "update" {
+			if notes, err = resolveDates(ctx, client, cal, "", dateArg{"due_date", &a.DueDate}); err != nil {
+				return nil, err
+			}
+		}
+
 		switch a.Action {
 		case "create":
 			payload := map[string]any{
@@ -158,6 +169,10 @@ func manageLaunchTaskHandler(client *api.Client) mcp.Handler {
 		if err != nil {
 			return nil, err
 		}
-		return FormatAction(data, a.Action, "task", a.TaskID)
+		resp, err := FormatAction(data, a.Action, "task", a.TaskID)
+		if err != nil {
+			return nil, err
+		}
+		return withDateNotes(resp, notes)
 	})
 }
@@ -0,0 +1,252 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/analysis"
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/barpayload"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+	"github.com/olgasafonova/productplan-mcp-server/internal/estimates"
+	"github.com/olgasafonova/productplan-mcp-server/internal/launchtemplate"
+	"github.com/olgasafonova/productplan-mcp-server/internal/mcp"
+)
+
+// scoreOpportunitiesHandler saves any estimates passed in, then scores and
+// ranks opportunities. A nil store keeps estimates for this session only.
+func scoreOpportunitiesHandler(client *api.Client, store *estimates.Store) mcp.Handler {
+	if store == nil {
+		store = estimates.Open("")
+	}
+	return typedHandler[ScoreOpportunitiesArgs](func(ctx context.Context, a ScoreOpportunitiesArgs) (json.RawMessage, error) {
+		changes := make(map[string]estimates.Estimate, len(a.Estimates))
+		for _, e := range a.Estimates {
+			id := strings.TrimSpace(e.OpportunityID)
+			changes[id] = changes[id].Merge(estimates.Estimate{
+				Reach: e.Reach, Impact: e.Impact, Confidence: e.Confidence, Effort: e.Effort, Ease: e.Ease, Criteria: e.Criteria,
+			})
+		}
+		all, err := store.Update(changes, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		report, err := analysis.ScoreOpportunities(ctx, client, all, analysis.ScoringOptions{
+			Model:          a.Model,
+			Weights:        a.Weights,
+			OpportunityIDs: a.OpportunityIDs,
+			CustomerValues: a.CustomerValues,
+			IncludeClosed:  a.IncludeClosed,
+			WriteBack:      a.WriteBack,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		summary := fmt.Sprintf("Ranked %d of %d opportunities by %s", report.Scored, report.Scored+report.Unscored, strings.ToUpper(report.Model))
+		if report.Unscored > 0 {
+			summary += fmt.Sprintf("; %d missing estimates", report.Unscored)
+		}
+		if len(changes) > 0 {
+			summary += fmt.Sprintf("; saved estimates for %d", len(changes))
+		}
+		if a.WriteBack {
+			summary += fmt.Sprintf("; wrote %d %s", len(report.Written), pluralize("score", len(report.Written)))
+		}
+		return analysisResponse(summary, report)
+	})
+}
+
+// promotedStatus is the workflow status a promoted opportunity moves to
+// unless the caller picks another: discovery is done once it is on a roadmap.
+const promotedStatus = "completed"
+
+// PromotedItem describes the result of promote_to_roadmap.
+type PromotedItem struct {
+	SourceType string   `json:"source_type"`
+	SourceID   string   `json:"source_id"`
+	SourceName string   `json:"source_name"`
+	SourceURL  string   `json:"source_url"`
+	BarID      string   `json:"bar_id"`
+	RoadmapID  string   `json:"roadmap_id"`
+	LaneID     string   `json:"lane_id"`
+	Name       string   `json:"name"`
+	Tags       []string `json:"tags"`
+	// WorkflowStatus is the opportunity's new status; empty for ideas.
+	WorkflowStatus string `json:"workflow_status,omitempty"`
+}
+
+// promoteToRoadmapHandler creates a bar from an idea or opportunity, links
+// the bar back to it and, for opportunities, moves the workflow status on.
+// The steps are not atomic: when a later one fails, the error names the
+// bar already created so it is not created twice.
+func promoteToRoadmapHandler(client *api.Client, cal dates.Calendar) mcp.Handler {
+	return typedHandler[PromoteToRoadmapArgs](func(ctx context.Context, a PromoteToRoadmapArgs) (json.RawMessage, error) {
+		notes, err := resolveDates(ctx, client, cal, a.RoadmapID,
+			dateArg{"starts_on", &a.StartsOn}, dateArg{"ends_on", &a.EndsOn})
+		if err != nil {
+			return nil, err
+		}
+		out := PromotedItem{SourceType: a.SourceType, SourceID: a.SourceID, RoadmapID: a.RoadmapID, LaneID: a.LaneID}
+		var description string
+		var tags []string
+		switch a.SourceType {
+		case "idea":
+			var idea api.Idea
+			if idea, err = client.FetchIdea(ctx, a.SourceID); err != nil {
+				return nil, err
+			}
+			out.SourceName, description, tags = idea.Name, idea.Description, idea.Tags
+			out.SourceURL = client.WebURL("/discovery/ideas/" + a.SourceID)
+		default:
+			var opp api.Opportunity
+			if opp, err = client.FetchOpportunity(ctx, a.SourceID); err != nil {
+				return nil, err
+			}
+			out.SourceName, description, tags = opp.ProblemStatement, opp.Description, opp.Tags
+			out.SourceURL = client.WebURL("/discovery/opportunities/" + a.SourceID)
+		}
+		out.Name = cmp.Or(strings.TrimSpace(a.Name), out.SourceName)
+		if out.Name == "" {
+			return nil, fmt.Errorf("%s %s has no name; pass name", a.SourceType, a.SourceID)
+		}
+		out.Tags = distinctTags(append(append([]string{}, tags...), a.Tags...))
+
+		data, err := client.CreateBar(ctx, barpayload.Create(barpayload.Fields{
+			RoadmapID:   a.RoadmapID,
+			LaneID:      a.LaneID,
+			Name:        out.Name,
+			Description: description,
+			StartsOn:    a.StartsOn,
+			EndsOn:      a.EndsOn,
+			Tags:        out.Tags,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		out.BarID = string(api.CreatedID(data))
+		if out.BarID == "" {
+			return nil, fmt.Errorf("bar created but the response had no id; link it to %s manually", out.SourceURL)
+		}
+
+		linkName := "Idea: " + out.SourceName
+		if a.SourceType == "opportunity" {
+			linkName = "Opportunity: " + out.SourceName
+		}
+		if _, err = client.CreateBarLink(ctx, out.BarID, map[string]any{"url": out.SourceURL, "name": linkName}); err != nil {
+			return nil, fmt.Errorf("bar %s created, but linking it to %s failed: %w", out.BarID, a.SourceType, err)
+		}
+
+		if a.SourceType == "opportunity" {
+			status := cmp.Or(a.WorkflowStatus, promotedStatus)
+			if _, err = client.UpdateOpportunity(ctx, a.SourceID, map[string]any{"workflow_status": status}); err != nil {
+				return nil, fmt.Errorf("bar %s created and linked, but updating the opportunity status failed: %w", out.BarID, err)
+			}
+			out.WorkflowStatus = status
+		}
+
+		summary := fmt.Sprintf("Promoted %s %s to bar %s on roadmap %s", a.SourceType, a.SourceID, out.BarID, a.RoadmapID)
+		if out.WorkflowStatus != "" {
+			summary += "; opportunity marked " + out.WorkflowStatus
+		}
+		resp, err := analysisResponse(summary, out)
+		if err != nil {
+			return nil, err
+		}
+		return withDateNotes(resp, notes)
+	})
+}
+
+// distinctTags drops blank and case-insensitively repeated tags, keeping
+// the first spelling and order. It never returns nil.
+func distinctTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	out := []string{}
+	for _, t := range tags {
+		t = strings.TrimSpace(t)
+		if key := strings.ToLower(t); t != "" && !seen[key] {
+			seen[key] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// errNoTemplates is returned when no template directory could be found.
+var errNoTemplates = fmt.Errorf("no launch template directory; set %s", launchtemplate.EnvDir)
+
+// applyLaunchTemplateHandler creates a template's sections and tasks on a
+// launch.
+func applyLaunchTemplateHandler(client *api.Client, lib *launchtemplate.Library) mcp.Handler {
+	return typedHandler[ApplyLaunchTemplateArgs](func(ctx context.Context, a ApplyLaunchTemplateArgs) (json.RawMessage, error) {
+		if lib == nil {
+			return nil, errNoTemplates
+		}
+		tmpl, _, err := lib.Load(a.Template)
+		if err != nil {
+			return nil, err
+		}
+		res, err := launchtemplate.Apply(ctx, client, a.LaunchID, tmpl, a.Template, launchtemplate.ApplyOptions{
+			Roles:      a.Roles,
+			LaunchDate: a.LaunchDate,
+			DryRun:     a.DryRun,
+		})
+		if err != nil {
+			if res != nil {
+				return nil, fmt.Errorf("%w (created %d %s and %d %s before the error)", err,
+					res.SectionsCreated, pluralize("section", res.SectionsCreated), res.TasksCreated, pluralize("task", res.TasksCreated))
+			}
+			return nil, err
+		}
+
+		verb := "Created"
+		if res.DryRun {
+			verb = "Would create"
+		}
+		summary := fmt.Sprintf("%s %d %s and %d %s on launch %s from %q", verb,
+			res.SectionsCreated, pluralize("section", res.SectionsCreated), res.TasksCreated, pluralize("task", res.TasksCreated), a.LaunchID, a.Template)
+		if res.Skipped > 0 {
+			summary += fmt.Sprintf("; %d already there", res.Skipped)
+		}
+		if len(res.UnmappedRoles) > 0 {
+			summary += "; unassigned roles: " + strings.Join(res.UnmappedRoles, ", ")
+		}
+		return analysisResponse(summary, res)
+	})
+}
+
+// SavedTemplate describes the save_launch_as_template result.
+type SavedTemplate struct {
+	Path     string                   `json:"path"`
+	Sections int                      `json:"sections"`
+	Tasks    int                      `json:"tasks"`
+	Template *launchtemplate.Template `json:"template"`
+}
+
+// saveLaunchAsTemplateHandler writes a launch's checklist to a template file.
+func saveLaunchAsTemplateHandler(client *api.Client, lib *launchtemplate.Library) mcp.Handler {
+	return typedHandler[SaveLaunchAsTemplateArgs](func(ctx context.Context, a SaveLaunchAsTemplateArgs) (json.RawMessage, error) {
+		if lib == nil {
+			return nil, errNoTemplates
+		}
+		tmpl, err := launchtemplate.Extract(ctx, client, a.LaunchID)
+		if err != nil {
+			return nil, err
+		}
+		out := SavedTemplate{Sections: len(tmpl.Sections), Template: tmpl}
+		for _, s := range tmpl.Sections {
+			out.Tasks += len(s.Tasks)
+		}
+		if out.Path, err = lib.Save(a.Name, a.Format, tmpl, a.Overwrite); err != nil {
+			return nil, err
+		}
+		summary := fmt.Sprintf("Saved launch %s as %s: %d %s, %d %s", a.LaunchID, out.Path,
+			out.Sections, pluralize("section", out.Sections), out.Tasks, pluralize("task", out.Tasks))
+		return analysisResponse(summary, out)
+	})
+}
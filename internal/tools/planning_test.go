@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/analysis"
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/apitest"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+	"github.com/olgasafonova/productplan-mcp-server/internal/estimates"
+	"github.com/olgasafonova/productplan-mcp-server/internal/launchtemplate"
+)
+
+func TestScoreOpportunitiesHandler(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/discovery/ideas": `[
+			{"id": 1, "customers": ["Acme", "Globex"], "opportunity_ids": [5]},
+			{"id": 2, "customers": ["Initech"], "opportunity_ids": [6]}
+		]`,
+		"/discovery/opportunities": `[
+			{"id": 5, "problem_statement": "Invoices are confusing"},
+			{"id": 6, "problem_statement": "Search is slow"}
+		]`,
+	})
+	store := estimates.Open(filepath.Join(t.TempDir(), "estimates.json"))
+	handler := scoreOpportunitiesHandler(client, store)
+
+	result, err := handler.Handle(context.Background(), map[string]any{
+		"estimates": []any{
+			map[string]any{"opportunity_id": "5", "impact": 2, "confidence": 0.8, "effort": 2},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary, report := decodeResponse[analysis.ScoringReport](t, result)
+	if summary != "Ranked 1 of 2 opportunities by RICE; 1 missing estimates; saved estimates for 1" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	if top := report.Opportunities[0]; top.ID != "5" || top.Score == nil || *top.Score != 1.6 {
+		t.Errorf("unexpected top opportunity %+v", top)
+	}
+
+	// Estimates persist, so a second call without them scores the same.
+	result, err = handler.Handle(context.Background(), map[string]any{"opportunity_ids": []any{"5"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, report = decodeResponse[analysis.ScoringReport](t, result); report.Scored != 1 {
+		t.Errorf("expected stored estimates to be reused, got %+v", report)
+	}
+
+	if err := (ScoreOpportunitiesArgs{Model: "moscow"}).Validate(); err == nil {
+		t.Error("expected error for an unknown model")
+	}
+}
+
+func TestPromoteToRoadmapHandler(t *testing.T) {
+	var calls []string
+	bodies := map[string]map[string]any{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := r.Method + " " + r.URL.Path
+		calls = append(calls, call)
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies[call] = body
+		w.Header().Set("Content-Type", "application/json")
+		switch call {
+		case "GET /discovery/opportunities/5":
+			_, _ = w.Write([]byte(`{"id": 5, "problem_statement": "Reports are slow", "description": "Exports time out", "tags": ["Perf"]}`))
+		case "POST /bars":
+			_, _ = w.Write([]byte(`{"id": 77}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := api.New(api.Config{Token: "test-token", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	result, err := promoteToRoadmapHandler(client, dates.Calendar{}).Handle(context.Background(), map[string]any{
+		"source_type": "opportunity", "source_id": "5", "roadmap_id": "1", "lane_id": "2", "tags": []any{"perf", "Q3"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary, item := decodeResponse[PromotedItem](t, result)
+	if summary != "Promoted opportunity 5 to bar 77 on roadmap 1; opportunity marked completed" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	want := []string{"GET /discovery/opportunities/5", "POST /bars", "POST /bars/77/links", "PATCH /discovery/opportunities/5"}
+	if strings.Join(calls, ", ") != strings.Join(want, ", ") {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if bar := bodies["POST /bars"]; bar["name"] != "Reports are slow" || bar["description"] != "Exports time out" || len(bar["tags"].([]any)) != 2 {
+		t.Errorf("unexpected bar payload %v", bar)
+	}
+	if link := bodies["POST /bars/77/links"]; link["url"] != server.URL+"/discovery/opportunities/5" || item.SourceURL != link["url"] {
+		t.Errorf("unexpected link payload %v", link)
+	}
+	if bodies["PATCH /discovery/opportunities/5"]["workflow_status"] != "completed" {
+		t.Errorf("unexpected status update %v", bodies["PATCH /discovery/opportunities/5"])
+	}
+
+	if err := (PromoteToRoadmapArgs{SourceType: "idea", SourceID: "1", RoadmapID: "1", LaneID: "2", WorkflowStatus: "completed"}).Validate(); err == nil {
+		t.Error("expected error for workflow_status on an idea")
+	}
+}
+
+func TestLaunchTemplateHandlers(t *testing.T) {
+	client := apitest.NewClient(t, map[string]string{
+		"/launches/1":                    `{"id": 1, "name": "v1", "date": "2026-05-01"}`,
+		"/launches/1/checklist_sections": `[{"id": 10, "name": "Legal"}]`,
+		"/launches/1/tasks":              `[{"id": 100, "name": "Legal review", "section_id": 10, "due_date": "2026-04-17", "assigned_user_id": 7}]`,
+		"/launches/2":                    `{"id": 2, "name": "v2", "date": "2026-09-01"}`,
+		"/launches/2/checklist_sections": `[]`,
+		"/launches/2/tasks":              `[]`,
+		"/users":                         `[{"id": 7, "first_name": "Ana", "last_name": "Lee"}]`,
+	})
+	lib := launchtemplate.OpenLibrary(t.TempDir())
+
+	result, err := saveLaunchAsTemplateHandler(client, lib).Handle(context.Background(), map[string]any{"launch_id": "1", "name": "standard"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary, saved := decodeResponse[SavedTemplate](t, result)
+	if !strings.HasSuffix(saved.Path, "standard.yaml") || saved.Tasks != 1 || saved.Template.Sections[0].Tasks[0].Due != "T-14d" {
+		t.Errorf("unexpected save %q %+v", summary, saved)
+	}
+
+	result, err = applyLaunchTemplateHandler(client, lib).Handle(context.Background(), map[string]any{
+		"launch_id": "2", "template": "standard", "dry_run": true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary, applied := decodeResponse[launchtemplate.Result](t, result)
+	if summary != `Would create 1 section and 1 task on launch 2 from "standard"` {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	if task := applied.Sections[0].Tasks[0]; task.DueDate != "2026-08-18" || task.AssignedUserID != "7" {
+		t.Errorf("unexpected task %+v", task)
+	}
+
+	if _, err = applyLaunchTemplateHandler(client, nil).Handle(context.Background(), map[string]any{"launch_id": "2", "template": "standard"}); err == nil {
+		t.Error("expected error without a template directory")
+	}
+}
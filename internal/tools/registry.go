@@ -6,7 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/olgasafonova/productplan-mcp-server/internal/analysis"
 	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
+	"github.com/olgasafonova/productplan-mcp-server/internal/estimates"
+	"github.com/olgasafonova/productplan-mcp-server/internal/launchtemplate"
 	"github.com/olgasafonova/productplan-mcp-server/internal/mcp"
 )
 
@@ -19,14 +23,45 @@ type HealthChecker interface {
 type Config struct {
 	Client        *api.Client
 	HealthChecker HealthChecker
+	// OKRLinks are the tag and custom field conventions that link bars to
+	// objectives when the API exposes no link. An empty field turns that
+	// convention off; callers normally start from analysis.DefaultLinkConventions.
+	OKRLinks analysis.LinkConventions
+	// Estimates stores opportunity scoring estimates between sessions. Nil
+	// keeps them in memory for the life of the process.
+	Estimates *estimates.Store
+	// LaunchTemplates is the directory of launch checklist templates. Nil
+	// makes the template tools report that none is configured.
+	LaunchTemplates *launchtemplate.Library
+	// Calendar places weeks, fiscal quarters and years wherever tools
+	// resolve date expressions such as "end of Q3" or group by period.
+	// The zero value is the calendar year with Monday weeks.
+	Calendar dates.Calendar
+	// Filter limits the tools registered. The zero value registers all.
+	Filter ToolFilter
+	// DefaultRoadmap, when set, fills in roadmap_id for tools that
+	// require one and makes the argument optional in their schema.
+	DefaultRoadmap string
+	// Workspace names the account Client talks to, as callers pass it in
+	// the workspace argument. Empty means DefaultWorkspace.
+	Workspace string
+	// Workspaces are further accounts that tool calls can be routed to
+	// with the workspace argument. Calls without one go to Client.
+	Workspaces []Workspace
 }
 
 // RegisterAll registers all ProductPlan tools with the MCP registry.
 func RegisterAll(registry *mcp.Registry, cfg Config) {
-	// Register tool definitions
+	workspaces := cfg.workspaces()
 	for _, tool := range BuildAllTools() {
-		handler := createHandler(tool.Name, cfg)
-		registry.Register(tool, handler)
+		if !cfg.Filter.Allows(tool) {
+			continue
+		}
+		registry.Register(withWorkspaces(tool, workspaces, func(ws Workspace) mcp.Handler {
+			c := cfg
+			c.Client, c.HealthChecker, c.Estimates = ws.Client, ws.HealthChecker, ws.Estimates
+			return createHandler(tool.Name, c)
+		}))
 	}
 }
 
@@ -53,13 +88,15 @@ func createHandler(name string, cfg Config) mcp.Handler {
 	case "manage_lane":
 		return manageLaneHandler(cfg.Client)
 	case "manage_milestone":
-		return manageMilestoneHandler(cfg.Client)
+		return manageMilestoneHandler(cfg.Client, cfg.Calendar)
 
 	// Bar handlers
 	case "get_bar":
 		return getBarHandler(cfg.Client)
 	case "get_bar_children":
 		return getBarChildrenHandler(cfg.Client)
+	case "get_bar_tree":
+		return getBarTreeHandler(cfg.Client)
 	case "get_bar_comments":
 		return getBarCommentsHandler(cfg.Client)
 	case "get_bar_connections":
@@ -67,7 +104,7 @@ func createHandler(name string, cfg Config) mcp.Handler {
 	case "get_bar_links":
 		return getBarLinksHandler(cfg.Client)
 	case "manage_bar":
-		return manageBarHandler(cfg.Client)
+		return manageBarHandler(cfg.Client, cfg.Calendar)
 	case "manage_bar_connection":
 		return manageBarConnectionHandler(cfg.Client)
 	case "manage_bar_link":
@@ -127,7 +164,7 @@ func createHandler(name string, cfg Config) mcp.Handler {
 	case "get_launch_task":
 		return getLaunchTaskHandler(cfg.Client)
 	case "manage_launch_task":
-		return manageLaunchTaskHandler(cfg.Client)
+		return manageLaunchTaskHandler(cfg.Client, cfg.Calendar)
 
 	// Utility handlers
 	case "check_status":
@@ -138,6 +175,48 @@ func createHandler(name string, cfg Config) mcp.Handler {
 		return listUsersHandler(cfg.Client)
 	case "list_teams":
 		return listTeamsHandler(cfg.Client)
+	case "get_timeframe":
+		return getTimeframeHandler(cfg.Calendar)
+	case "list_workspaces":
+		return listWorkspacesHandler(cfg.workspaces())
+
+	// Export and report handlers
+	case "export_ics":
+		return exportICSHandler(cfg.Client)
+	case "generate_roadmap_report":
+		return generateRoadmapReportHandler(cfg.Client, cfg.Calendar)
+
+	// Analysis handlers
+	case "okr_progress":
+		return okrProgressHandler(cfg.Client, cfg.Calendar)
+	case "objective_coverage":
+		return objectiveCoverageHandler(cfg.Client, cfg.OKRLinks)
+	case "analyze_dependencies":
+		return analyzeDependenciesHandler(cfg.Client)
+	case "detect_schedule_conflicts":
+		return detectScheduleConflictsHandler(cfg.Client, cfg.Calendar)
+	case "lint_roadmap":
+		return lintRoadmapHandler(cfg.Client)
+	case "find_stale_items":
+		return findStaleItemsHandler(cfg.Client)
+	case "cluster_ideas":
+		return clusterIdeasHandler(cfg.Client)
+	case "rank_customer_demand":
+		return rankCustomerDemandHandler(cfg.Client)
+	case "launch_readiness":
+		return launchReadinessHandler(cfg.Client)
+	case "user_workload":
+		return userWorkloadHandler(cfg.Client, cfg.Calendar)
+
+	// Planning handlers
+	case "score_opportunities":
+		return scoreOpportunitiesHandler(cfg.Client, cfg.Estimates)
+	case "promote_to_roadmap":
+		return promoteToRoadmapHandler(cfg.Client, cfg.Calendar)
+	case "apply_launch_template":
+		return applyLaunchTemplateHandler(cfg.Client, cfg.LaunchTemplates)
+	case "save_launch_as_template":
+		return saveLaunchAsTemplateHandler(cfg.Client, cfg.LaunchTemplates)
 
 	default:
 		return mcp.HandlerFunc(func(ctx context.Context, args map[string]any) (json.RawMessage, error) {
@@ -5,9 +5,13 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/estimates"
 	"github.com/olgasafonova/productplan-mcp-server/internal/mcp"
 )
 
@@ -80,6 +84,137 @@ func TestRegisterAll(t *testing.T) {
 	}
 }
 
+func TestRegisterAllFilter(t *testing.T) {
+	registry := mcp.NewRegistry()
+	RegisterAll(registry, Config{Filter: ToolFilter{Include: []string{"get_roadmap*", "manage_bar"}, Exclude: []string{"get_roadmap_comments"}}})
+	var names []string
+	for _, tool := range registry.Tools() {
+		names = append(names, tool.Name)
+	}
+	if len(names) != 7 || slices.Contains(names, "get_roadmap_comments") || !slices.Contains(names, "manage_bar") {
+		t.Errorf("unexpected tools %v", names)
+	}
+
+	registry = mcp.NewRegistry()
+	RegisterAll(registry, Config{Filter: ToolFilter{ReadOnly: true}})
+	if _, ok := registry.Handler("manage_bar"); ok || registry.Count() != 38+2+10 {
+		t.Errorf("read-only filter registered %d tools", registry.Count())
+	}
+
+	if err := (ToolFilter{Include: []string{"get_*", "list_roadmap"}}).Check(BuildAllTools()); err == nil || !strings.Contains(err.Error(), "list_roadmap") {
+		t.Errorf("expected an unknown pattern error, got %v", err)
+	}
+}
+
+func TestRegisterAllDefaultRoadmap(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		paths = append(paths, r.URL.Path)
+		json.NewEncoder(w).Encode([]any{})
+	})
+	defer server.Close()
+
+	registry := mcp.NewRegistry()
+	RegisterAll(registry, Config{Client: testClient(t, server), DefaultRoadmap: "42"})
+	for _, tool := range registry.Tools() {
+		if tool.Name == "get_roadmap_bars" && (slices.Contains(tool.InputSchema.Required, "roadmap_id") || !strings.Contains(tool.InputSchema.Properties["roadmap_id"].Description, "default 42")) {
+			t.Errorf("roadmap_id should be optional with a default: %+v", tool.InputSchema)
+		}
+	}
+	if _, err := registry.Call(context.Background(), "get_roadmap_bars", map[string]any{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := registry.Call(context.Background(), "get_roadmap_bars", map[string]any{"roadmap_id": "7"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Contains(paths, "/roadmaps/42/bars") || !slices.Contains(paths, "/roadmaps/7/bars") {
+		t.Errorf("unexpected requests %v", paths)
+	}
+}
+
+func TestRegisterAllWorkspaces(t *testing.T) {
+	var mu sync.Mutex
+	var prodPaths, sandboxPaths []string
+	record := func(paths *[]string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			*paths = append(*paths, r.URL.Path)
+			json.NewEncoder(w).Encode([]any{})
+		}
+	}
+	prod := testServer(t, record(&prodPaths))
+	defer prod.Close()
+	sandbox := testServer(t, record(&sandboxPaths))
+	defer sandbox.Close()
+
+	prodEstimates, sandboxEstimates := estimates.Open(""), estimates.Open("")
+	registry := mcp.NewRegistry()
+	RegisterAll(registry, Config{
+		Client:     testClient(t, prod),
+		Estimates:  prodEstimates,
+		Workspace:  "prod",
+		Workspaces: []Workspace{{Name: "sandbox", Client: testClient(t, sandbox), DefaultRoadmap: "7", Estimates: sandboxEstimates}},
+	})
+	for _, tool := range registry.Tools() {
+		_, hasWorkspace := tool.InputSchema.Properties["workspace"]
+		switch tool.Name {
+		case "get_roadmap_bars":
+			if !hasWorkspace || slices.Contains(tool.InputSchema.Required, "roadmap_id") || !strings.Contains(tool.InputSchema.Properties["roadmap_id"].Description, "sandbox: 7") {
+				t.Errorf("unexpected schema %+v", tool.InputSchema)
+			}
+		case "get_timeframe", "list_workspaces":
+			if hasWorkspace {
+				t.Errorf("%s should not take a workspace", tool.Name)
+			}
+		}
+	}
+
+	ctx := context.Background()
+	if _, err := registry.Call(ctx, "get_roadmap_bars", map[string]any{"workspace": "sandbox"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := registry.Call(ctx, "get_roadmap_bars", map[string]any{"roadmap_id": "3"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := registry.Call(ctx, "get_roadmap_bars", map[string]any{}); err == nil {
+		t.Error("expected a missing roadmap_id error for a workspace without a default")
+	}
+	if _, err := registry.Call(ctx, "list_roadmaps", map[string]any{"workspace": "staging"}); err == nil || !strings.Contains(err.Error(), "workspaces: prod, sandbox") {
+		t.Errorf("expected an unknown workspace error, got %v", err)
+	}
+	if !slices.Contains(sandboxPaths, "/roadmaps/7/bars") || !slices.Contains(prodPaths, "/roadmaps/3/bars") || slices.Contains(prodPaths, "/roadmaps/7/bars") {
+		t.Errorf("unexpected requests: prod %v, sandbox %v", prodPaths, sandboxPaths)
+	}
+
+	// Opportunity IDs are per account, so each workspace keeps its own
+	// estimates.
+	if _, err := registry.Call(ctx, "score_opportunities", map[string]any{
+		"workspace": "sandbox",
+		"estimates": []any{map[string]any{"opportunity_id": "5", "impact": 2, "confidence": 0.8, "effort": 2}},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved, _ := sandboxEstimates.Load(); len(saved) != 1 {
+		t.Errorf("expected the sandbox estimate to be saved, got %v", saved)
+	}
+	if saved, _ := prodEstimates.Load(); len(saved) != 0 {
+		t.Errorf("expected no prod estimates, got %v", saved)
+	}
+
+	raw, err := registry.Call(ctx, "list_workspaces", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var resp FormattedResponse
+	if err = json.Unmarshal(raw, &resp); err != nil || resp.Summary != "Found 2 workspaces" || !strings.Contains(string(resp.Data), sandbox.URL) {
+		t.Errorf("unexpected list_workspaces response %s", raw)
+	}
+}
+
 func TestCreateHandlerReturnsValidHandlers(t *testing.T) {
 	server := testServer(t, func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
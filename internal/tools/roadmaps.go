@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
 	"github.com/olgasafonova/productplan-mcp-server/internal/mcp"
 )
 
@@ -112,11 +113,20 @@ func manageLaneHandler(client *api.Client) mcp.Handler {
 	})
 }
 
-func manageMilestoneHandler(client *api.Client) mcp.Handler {
+// manageMilestoneHandler creates, updates or deletes a milestone. The date
+// may be an expression, resolved against cal before the request.
+func manageMilestoneHandler(client *api.Client, cal dates.Calendar) mcp.Handler {
 	return typedHandler[ManageMilestoneArgs](func(ctx context.Context, a ManageMilestoneArgs) (json.RawMessage, error) {
 		var data json.RawMessage
 		var err error
 
+		var notes []string
+		if a.Action == "create" || a.Action == "update" {
+			if notes, err = resolveDates(ctx, client, cal, a.RoadmapID, dateArg{"date", &a.Date}); err != nil {
+				return nil, err
+			}
+		}
+
 		switch a.Action {
 		case "create":
 			payload := map[string]any{
@@ -140,7 +150,11 @@ func manageMilestoneHandler(client *api.Client) mcp.Handler {
 		if err != nil {
 			return nil, err
 		}
-		return FormatAction(data, a.Action, "milestone", a.MilestoneID)
+		resp, err := FormatAction(data, a.Action, "milestone", a.MilestoneID)
+		if err != nil {
+			return nil, err
+		}
+		return withDateNotes(resp, notes)
 	})
 }
 
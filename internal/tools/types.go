@@ -4,6 +4,11 @@ package tools
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/barpayload"
 )
 
 // ParseArgs unmarshals map[string]any into a typed struct.
@@ -133,33 +138,32 @@ func (a GetBarArgs) Validate() error {
 	return requireField(a.BarID, "bar_id")
 }
 
-// CustomFieldValue represents a name-value pair for custom fields.
-type CustomFieldValue struct {
-	Name  string `json:"name"`
-	Value string `json:"value"`
+// GetBarTreeArgs holds arguments for the bar hierarchy walk.
+type GetBarTreeArgs struct {
+	BarID    string `json:"bar_id"`
+	MaxDepth *int   `json:"max_depth,omitempty"`
+}
+
+// Validate checks required fields and the depth limit.
+func (a GetBarTreeArgs) Validate() error {
+	if err := requireField(a.BarID, "bar_id"); err != nil {
+		return err
+	}
+	if a.MaxDepth != nil && (*a.MaxDepth < 1 || *a.MaxDepth > 20) {
+		return fmt.Errorf("max_depth must be between 1 and 20")
+	}
+	return nil
 }
 
-// ManageBarArgs holds arguments for bar management operations.
+// CustomFieldValue represents a name-value pair for custom fields.
+type CustomFieldValue = barpayload.CustomField
+
+// ManageBarArgs holds arguments for bar management operations. The bar
+// fields are shared with the CSV importer through barpayload.Fields.
 type ManageBarArgs struct {
-	Action               string             `json:"action"`
-	BarID                string             `json:"bar_id,omitempty"`
-	RoadmapID            string             `json:"roadmap_id,omitempty"`
-	LaneID               string             `json:"lane_id,omitempty"`
-	Name                 string             `json:"name,omitempty"`
-	StartsOn             string             `json:"starts_on,omitempty"`
-	EndsOn               string             `json:"ends_on,omitempty"`
-	Description          string             `json:"description,omitempty"`
-	LegendID             string             `json:"legend_id,omitempty"`
-	PercentDone          *int               `json:"percent_done,omitempty"`
-	Container            *bool              `json:"container,omitempty"`
-	Parked               *bool              `json:"parked,omitempty"`
-	ParentID             string             `json:"parent_id,omitempty"`
-	StrategicValue       string             `json:"strategic_value,omitempty"`
-	Notes                string             `json:"notes,omitempty"`
-	Effort               *int               `json:"effort,omitempty"`
-	Tags                 []string           `json:"tags,omitempty"`
-	CustomTextFields     []CustomFieldValue `json:"custom_text_fields,omitempty"`
-	CustomDropdownFields []CustomFieldValue `json:"custom_dropdown_fields,omitempty"`
+	Action string `json:"action"`
+	BarID  string `json:"bar_id,omitempty"`
+	barpayload.Fields
 }
 
 // Validate checks required fields based on action.
@@ -511,3 +515,382 @@ type HealthCheckArgs struct {
 func (a HealthCheckArgs) Validate() error {
 	return nil
 }
+
+// GetTimeframeArgs holds arguments for mapping dates to fiscal periods.
+type GetTimeframeArgs struct {
+	Dates []string `json:"dates,omitempty"`
+}
+
+// Validate caps the number of dates.
+func (a GetTimeframeArgs) Validate() error {
+	if len(a.Dates) > 50 {
+		return fmt.Errorf("dates accepts at most 50 entries, got %d", len(a.Dates))
+	}
+	return nil
+}
+
+// --- Export Args ---
+
+// ExportICSArgs holds arguments for the iCalendar export.
+type ExportICSArgs struct {
+	RoadmapIDs      []string `json:"roadmap_ids,omitempty"`
+	IncludeLaunches *bool    `json:"include_launches,omitempty"`
+	IncludeTasks    *bool    `json:"include_tasks,omitempty"`
+}
+
+// Validate rejects an export that would select nothing.
+func (a ExportICSArgs) Validate() error {
+	if len(a.RoadmapIDs) == 0 && a.IncludeLaunches != nil && !*a.IncludeLaunches {
+		return fmt.Errorf("nothing to export: pass roadmap_ids or set include_launches")
+	}
+	return nil
+}
+
+// GenerateRoadmapReportArgs holds arguments for the Markdown roadmap report.
+type GenerateRoadmapReportArgs struct {
+	RoadmapIDs    []string `json:"roadmap_ids"`
+	GroupBy       string   `json:"group_by,omitempty"`
+	MilestoneDays *int     `json:"milestone_days,omitempty"`
+	CommentLimit  *int     `json:"comment_limit,omitempty"`
+	Compact       bool     `json:"compact,omitempty"`
+}
+
+// Validate checks the roadmap selection and option ranges.
+func (a GenerateRoadmapReportArgs) Validate() error {
+	if len(a.RoadmapIDs) == 0 {
+		return fmt.Errorf("required parameter missing: roadmap_ids")
+	}
+	switch a.GroupBy {
+	case "", "horizon", "quarter":
+	default:
+		return fmt.Errorf("group_by must be horizon or quarter, got %q", a.GroupBy)
+	}
+	if a.MilestoneDays != nil && (*a.MilestoneDays < 0 || *a.MilestoneDays > 365) {
+		return fmt.Errorf("milestone_days must be between 0 and 365")
+	}
+	if a.CommentLimit != nil && (*a.CommentLimit < 0 || *a.CommentLimit > 50) {
+		return fmt.Errorf("comment_limit must be between 0 and 50")
+	}
+	return nil
+}
+
+// intOr returns *p, or def when p is nil.
+func intOr(p *int, def int) int {
+	if p == nil {
+		return def
+	}
+	return *p
+}
+
+// boolOr returns *p, or def when p is nil.
+func boolOr(p *bool, def bool) bool {
+	if p == nil {
+		return def
+	}
+	return *p
+}
+
+// --- Analysis Args ---
+
+// OKRProgressArgs holds arguments for the OKR progress rollup.
+type OKRProgressArgs struct {
+	ObjectiveIDs []string `json:"objective_ids,omitempty"`
+	TimeFrame    string   `json:"time_frame,omitempty"`
+	RiskMargin   *float64 `json:"risk_margin,omitempty"`
+}
+
+// Validate checks the risk margin range. Objective IDs only filter the
+// objectives list, so unknown IDs simply match nothing.
+func (a OKRProgressArgs) Validate() error {
+	if a.RiskMargin != nil && (*a.RiskMargin <= 0 || *a.RiskMargin > 100) {
+		return fmt.Errorf("risk_margin must be greater than 0 and at most 100")
+	}
+	return nil
+}
+
+// ObjectiveCoverageArgs holds arguments for the strategic coverage report.
+// TagPrefix and CustomField override the configured link conventions when
+// set; an empty string turns that convention off.
+type ObjectiveCoverageArgs struct {
+	RoadmapIDs    []string `json:"roadmap_ids,omitempty"`
+	TagPrefix     *string  `json:"tag_prefix,omitempty"`
+	CustomField   *string  `json:"custom_field,omitempty"`
+	IncludeParked bool     `json:"include_parked,omitempty"`
+}
+
+// Validate checks that roadmap IDs are non-empty.
+func (a ObjectiveCoverageArgs) Validate() error {
+	for _, id := range a.RoadmapIDs {
+		if strings.TrimSpace(id) == "" {
+			return fmt.Errorf("roadmap_ids must not contain empty IDs")
+		}
+	}
+	return nil
+}
+
+// DetectScheduleConflictsArgs holds arguments for the lane conflict scan.
+type DetectScheduleConflictsArgs struct {
+	RoadmapID     string   `json:"roadmap_id"`
+	MaxConcurrent *int     `json:"max_concurrent,omitempty"`
+	Period        string   `json:"period,omitempty"`
+	Capacity      *float64 `json:"capacity,omitempty"`
+}
+
+// Validate checks the roadmap and option ranges.
+func (a DetectScheduleConflictsArgs) Validate() error {
+	if err := requireField(a.RoadmapID, "roadmap_id"); err != nil {
+		return err
+	}
+	if a.MaxConcurrent != nil && (*a.MaxConcurrent < 1 || *a.MaxConcurrent > 100) {
+		return fmt.Errorf("max_concurrent must be between 1 and 100")
+	}
+	switch a.Period {
+	case "", "week", "month", "quarter":
+	default:
+		return fmt.Errorf("period must be week, month or quarter, got %q", a.Period)
+	}
+	if a.Capacity != nil && *a.Capacity <= 0 {
+		return fmt.Errorf("capacity must be greater than 0")
+	}
+	return nil
+}
+
+// LintRoadmapArgs holds arguments for the roadmap hygiene linter.
+type LintRoadmapArgs struct {
+	RoadmapID string   `json:"roadmap_id"`
+	Rules     []string `json:"rules,omitempty"`
+	Skip      []string `json:"skip,omitempty"`
+}
+
+// Validate checks required fields. Rule names are checked by the linter,
+// which knows the available rules.
+func (a LintRoadmapArgs) Validate() error {
+	return requireField(a.RoadmapID, "roadmap_id")
+}
+
+// FindStaleItemsArgs holds arguments for the stale item scan.
+type FindStaleItemsArgs struct {
+	Days       *int     `json:"days,omitempty"`
+	Kinds      []string `json:"kinds,omitempty"`
+	RoadmapIDs []string `json:"roadmap_ids,omitempty"`
+}
+
+// Validate checks the threshold and roadmap IDs. Kinds are checked by the
+// analysis, which knows the supported kinds.
+func (a FindStaleItemsArgs) Validate() error {
+	if a.Days != nil && (*a.Days < 1 || *a.Days > 3650) {
+		return fmt.Errorf("days must be between 1 and 3650")
+	}
+	for _, id := range a.RoadmapIDs {
+		if strings.TrimSpace(id) == "" {
+			return fmt.Errorf("roadmap_ids must not contain empty IDs")
+		}
+	}
+	return nil
+}
+
+// ClusterIdeasArgs holds arguments for idea clustering.
+type ClusterIdeasArgs struct {
+	Threshold     *float64 `json:"threshold,omitempty"`
+	MinSize       *int     `json:"min_size,omitempty"`
+	IncludeClosed bool     `json:"include_closed,omitempty"`
+}
+
+// Validate checks option ranges.
+func (a ClusterIdeasArgs) Validate() error {
+	if a.Threshold != nil && (*a.Threshold <= 0 || *a.Threshold > 1) {
+		return fmt.Errorf("threshold must be greater than 0 and at most 1")
+	}
+	if a.MinSize != nil && (*a.MinSize < 2 || *a.MinSize > 1000) {
+		return fmt.Errorf("min_size must be between 2 and 1000")
+	}
+	return nil
+}
+
+// RankCustomerDemandArgs holds arguments for the customer demand ranking.
+type RankCustomerDemandArgs struct {
+	CustomerValues map[string]float64 `json:"customer_values,omitempty"`
+	DefaultValue   *float64           `json:"default_value,omitempty"`
+	Limit          *int               `json:"limit,omitempty"`
+	IncludeClosed  bool               `json:"include_closed,omitempty"`
+}
+
+// Validate checks weights and the limit.
+func (a RankCustomerDemandArgs) Validate() error {
+	for name, v := range a.CustomerValues {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("customer_values must not contain empty customer names")
+		}
+		if v < 0 {
+			return fmt.Errorf("customer_values[%q] must not be negative", name)
+		}
+	}
+	if a.DefaultValue != nil && *a.DefaultValue <= 0 {
+		return fmt.Errorf("default_value must be greater than 0")
+	}
+	if a.Limit != nil && (*a.Limit < 1 || *a.Limit > 200) {
+		return fmt.Errorf("limit must be between 1 and 200")
+	}
+	return nil
+}
+
+// UserWorkloadArgs holds arguments for the workload report.
+type UserWorkloadArgs struct {
+	WeeklyLimit *int     `json:"weekly_limit,omitempty"`
+	RoadmapIDs  []string `json:"roadmap_ids,omitempty"`
+	SkipBars    bool     `json:"skip_bars,omitempty"`
+}
+
+// Validate checks the limit and roadmap IDs.
+func (a UserWorkloadArgs) Validate() error {
+	if a.WeeklyLimit != nil && (*a.WeeklyLimit < 1 || *a.WeeklyLimit > 100) {
+		return fmt.Errorf("weekly_limit must be between 1 and 100")
+	}
+	for _, id := range a.RoadmapIDs {
+		if strings.TrimSpace(id) == "" {
+			return fmt.Errorf("roadmap_ids must not contain empty IDs")
+		}
+	}
+	return nil
+}
+
+// --- Planning Args ---
+
+// OpportunityEstimateArgs is one opportunity's estimates for scoring.
+type OpportunityEstimateArgs struct {
+	OpportunityID string             `json:"opportunity_id"`
+	Reach         *float64           `json:"reach,omitempty"`
+	Impact        *float64           `json:"impact,omitempty"`
+	Confidence    *float64           `json:"confidence,omitempty"`
+	Effort        *float64           `json:"effort,omitempty"`
+	Ease          *float64           `json:"ease,omitempty"`
+	Criteria      map[string]float64 `json:"criteria,omitempty"`
+}
+
+// ScoreOpportunitiesArgs holds arguments for opportunity scoring.
+type ScoreOpportunitiesArgs struct {
+	Model          string                    `json:"model,omitempty"`
+	Weights        map[string]float64        `json:"weights,omitempty"`
+	Estimates      []OpportunityEstimateArgs `json:"estimates,omitempty"`
+	OpportunityIDs []string                  `json:"opportunity_ids,omitempty"`
+	CustomerValues map[string]float64        `json:"customer_values,omitempty"`
+	IncludeClosed  bool                      `json:"include_closed,omitempty"`
+	WriteBack      bool                      `json:"write_back,omitempty"`
+}
+
+// Validate checks the model and estimate ranges.
+func (a ScoreOpportunitiesArgs) Validate() error {
+	switch strings.ToLower(a.Model) {
+	case "", "rice", "ice":
+	case "weighted":
+		if len(a.Weights) == 0 {
+			return fmt.Errorf("weights are required for the weighted model")
+		}
+	default:
+		return fmt.Errorf("model must be rice, ice or weighted, got %q", a.Model)
+	}
+	for i, e := range a.Estimates {
+		if strings.TrimSpace(e.OpportunityID) == "" {
+			return fmt.Errorf("estimates[%d]: opportunity_id is required", i)
+		}
+		if e.Confidence != nil && (*e.Confidence < 0 || *e.Confidence > 1) {
+			return fmt.Errorf("estimates[%d]: confidence must be between 0 and 1", i)
+		}
+		if e.Effort != nil && *e.Effort <= 0 {
+			return fmt.Errorf("estimates[%d]: effort must be greater than 0", i)
+		}
+		for name, v := range map[string]*float64{"reach": e.Reach, "impact": e.Impact, "ease": e.Ease} {
+			if v != nil && *v < 0 {
+				return fmt.Errorf("estimates[%d]: %s must not be negative", i, name)
+			}
+		}
+	}
+	return nil
+}
+
+// opportunityStatuses are the workflow statuses an opportunity can take.
+var opportunityStatuses = []string{"draft", "in_discovery", "validated", "invalidated", "completed"}
+
+// PromoteToRoadmapArgs holds arguments for turning discovery work into a bar.
+type PromoteToRoadmapArgs struct {
+	SourceType     string   `json:"source_type"`
+	SourceID       string   `json:"source_id"`
+	RoadmapID      string   `json:"roadmap_id"`
+	LaneID         string   `json:"lane_id"`
+	Name           string   `json:"name,omitempty"`
+	StartsOn       string   `json:"starts_on,omitempty"`
+	EndsOn         string   `json:"ends_on,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	WorkflowStatus string   `json:"workflow_status,omitempty"`
+}
+
+// Validate checks the source and target.
+func (a PromoteToRoadmapArgs) Validate() error {
+	if err := requireAll(
+		fieldCheck{a.SourceType, "source_type"},
+		fieldCheck{a.SourceID, "source_id"},
+		fieldCheck{a.RoadmapID, "roadmap_id"},
+		fieldCheck{a.LaneID, "lane_id"},
+	); err != nil {
+		return err
+	}
+	if a.SourceType != "idea" && a.SourceType != "opportunity" {
+		return fmt.Errorf("source_type must be idea or opportunity, got %q", a.SourceType)
+	}
+	if a.WorkflowStatus != "" {
+		if a.SourceType != "opportunity" {
+			return fmt.Errorf("workflow_status applies only to opportunities")
+		}
+		if !slices.Contains(opportunityStatuses, a.WorkflowStatus) {
+			return fmt.Errorf("workflow_status must be one of %s, got %q", strings.Join(opportunityStatuses, ", "), a.WorkflowStatus)
+		}
+	}
+	return nil
+}
+
+// ApplyLaunchTemplateArgs holds arguments for applying a checklist template.
+type ApplyLaunchTemplateArgs struct {
+	LaunchID   string            `json:"launch_id"`
+	Template   string            `json:"template"`
+	Roles      map[string]string `json:"roles,omitempty"`
+	LaunchDate string            `json:"launch_date,omitempty"`
+	DryRun     bool              `json:"dry_run,omitempty"`
+}
+
+// Validate checks required fields and the launch date format.
+func (a ApplyLaunchTemplateArgs) Validate() error {
+	if err := requireAll(
+		fieldCheck{a.LaunchID, "launch_id"},
+		fieldCheck{a.Template, "template"},
+	); err != nil {
+		return err
+	}
+	if a.LaunchDate != "" {
+		if _, err := time.Parse(time.DateOnly, a.LaunchDate); err != nil {
+			return fmt.Errorf("launch_date must be YYYY-MM-DD, got %q", a.LaunchDate)
+		}
+	}
+	return nil
+}
+
+// SaveLaunchAsTemplateArgs holds arguments for saving a launch as a template.
+type SaveLaunchAsTemplateArgs struct {
+	LaunchID  string `json:"launch_id"`
+	Name      string `json:"name"`
+	Format    string `json:"format,omitempty"`
+	Overwrite bool   `json:"overwrite,omitempty"`
+}
+
+// Validate checks required fields and the format.
+func (a SaveLaunchAsTemplateArgs) Validate() error {
+	if err := requireAll(
+		fieldCheck{a.LaunchID, "launch_id"},
+		fieldCheck{a.Name, "name"},
+	); err != nil {
+		return err
+	}
+	if a.Format != "" && a.Format != "yaml" && a.Format != "json" {
+		return fmt.Errorf("format must be yaml or json, got %q", a.Format)
+	}
+	return nil
+}
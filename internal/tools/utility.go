@@ -3,8 +3,12 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/dates"
 	"github.com/olgasafonova/productplan-mcp-server/internal/mcp"
 )
 
@@ -48,3 +52,49 @@ func listTeamsHandler(client *api.Client) mcp.Handler {
 		return FormatList(data, "team")
 	})
 }
+
+func getTimeframeHandler(cal dates.Calendar) mcp.Handler {
+	return typedHandler[GetTimeframeArgs](func(ctx context.Context, a GetTimeframeArgs) (json.RawMessage, error) {
+		type timeframe struct {
+			Input string `json:"input"`
+			dates.Timeframe
+		}
+		inputs := a.Dates
+		if len(inputs) == 0 {
+			inputs = []string{"today"}
+		}
+		now := time.Now()
+		r := dates.Resolver{Calendar: cal, Today: now}
+		frames := make([]timeframe, 0, len(inputs))
+		for _, in := range inputs {
+			day, err := r.Resolve(in)
+			if err != nil {
+				return nil, err
+			}
+			frames = append(frames, timeframe{Input: in, Timeframe: cal.Timeframe(day)})
+		}
+
+		report := map[string]any{
+			"calendar": map[string]string{
+				"fiscal_year_start": cal.FirstMonth().String(),
+				"week_start":        cal.FirstWeekday().String(),
+				"description":       cal.String(),
+			},
+			"today":      now.Format(time.DateOnly),
+			"timeframes": frames,
+		}
+		var summary string
+		if len(frames) == 1 {
+			f := frames[0]
+			summary = fmt.Sprintf("%s is in %s (%s..%s), day %d of %d; week %s starts %s",
+				f.Date, f.Quarter.Label, f.Quarter.Start, f.Quarter.End, f.DayOfQuarter, f.DaysInQuarter, f.Week.Label, f.Week.Start)
+		} else {
+			quarters := make([]string, len(frames))
+			for i, f := range frames {
+				quarters[i] = f.Date + " " + f.Quarter.Label
+			}
+			summary = fmt.Sprintf("Mapped %d dates: %s", len(frames), strings.Join(quarters, ", "))
+		}
+		return analysisResponse(summary+" ("+cal.String()+")", report)
+	})
+}
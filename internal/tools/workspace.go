@@ -0,0 +1,147 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/olgasafonova/productplan-mcp-server/internal/api"
+	"github.com/olgasafonova/productplan-mcp-server/internal/estimates"
+	"github.com/olgasafonova/productplan-mcp-server/internal/mcp"
+)
+
+// DefaultWorkspace names Config.Client's account when Config.Workspace is
+// empty.
+const DefaultWorkspace = "default"
+
+// Workspace is a ProductPlan account that tool calls can be routed to with
+// the workspace argument.
+type Workspace struct {
+	// Name is the value callers pass as workspace.
+	Name          string
+	Client        *api.Client
+	HealthChecker HealthChecker
+	// DefaultRoadmap fills in roadmap_id for calls routed to this
+	// workspace, as Config.DefaultRoadmap does for the primary one.
+	DefaultRoadmap string
+	// Estimates stores this workspace's opportunity estimates, which are
+	// keyed by opportunity ID and so cannot be shared between accounts.
+	// Nil keeps them in memory.
+	Estimates *estimates.Store
+}
+
+// workspaceFree lists tools that never call the API, so a workspace
+// argument would mean nothing to them.
+var workspaceFree = map[string]bool{
+	"list_workspaces": true,
+	"get_timeframe":   true,
+}
+
+// workspaces returns the primary workspace followed by the others.
+func (cfg Config) workspaces() []Workspace {
+	name := cfg.Workspace
+	if name == "" {
+		name = DefaultWorkspace
+	}
+	primary := Workspace{
+		Name:           name,
+		Client:         cfg.Client,
+		HealthChecker:  cfg.HealthChecker,
+		DefaultRoadmap: cfg.DefaultRoadmap,
+		Estimates:      cfg.Estimates,
+	}
+	return append([]Workspace{primary}, cfg.Workspaces...)
+}
+
+// withWorkspaces builds tool's handler once per workspace with build and
+// routes each call by its workspace argument, defaulting to the first
+// workspace. The argument is only added to the schema when there is more
+// than one workspace to choose from.
+func withWorkspaces(tool mcp.Tool, workspaces []Workspace, build func(Workspace) mcp.Handler) (mcp.Tool, mcp.Handler) {
+	if len(workspaces) == 1 || workspaceFree[tool.Name] {
+		ws := workspaces[0]
+		handler := build(ws)
+		if ws.DefaultRoadmap != "" {
+			return withDefaultRoadmap(tool, handler, ws.DefaultRoadmap)
+		}
+		return tool, handler
+	}
+
+	names := make([]string, len(workspaces))
+	var defaults []string
+	for i, ws := range workspaces {
+		names[i] = ws.Name
+		if ws.DefaultRoadmap != "" {
+			defaults = append(defaults, ws.Name+": "+ws.DefaultRoadmap)
+		}
+	}
+	optional := false
+	if len(defaults) > 0 {
+		tool, optional = optionalRoadmap(tool, "default in "+strings.Join(defaults, ", "))
+	}
+
+	handlers := make(map[string]mcp.Handler, len(workspaces))
+	for _, ws := range workspaces {
+		handler := build(ws)
+		if optional && ws.DefaultRoadmap != "" {
+			handler = fillRoadmap(handler, ws.DefaultRoadmap)
+		}
+		handlers[ws.Name] = handler
+	}
+
+	props := maps.Clone(tool.InputSchema.Properties)
+	if props == nil {
+		props = make(map[string]mcp.Property, 1)
+	}
+	props["workspace"] = mcp.Property{
+		Type:        "string",
+		Description: fmt.Sprintf("ProductPlan workspace to use (default %s); see list_workspaces", names[0]),
+		Enum:        names,
+	}
+	tool.InputSchema.Properties = props
+
+	return tool, mcp.HandlerFunc(func(ctx context.Context, args map[string]any) (json.RawMessage, error) {
+		name := names[0]
+		if v, ok := args["workspace"]; ok && v != nil && v != "" {
+			s, isString := v.(string)
+			if !isString {
+				return nil, fmt.Errorf("workspace must be a string")
+			}
+			name = s
+		}
+		handler, ok := handlers[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown workspace %q (workspaces: %s)", name, strings.Join(names, ", "))
+		}
+		if _, ok = args["workspace"]; ok {
+			args = maps.Clone(args)
+			delete(args, "workspace")
+		}
+		return handler.Handle(ctx, args)
+	})
+}
+
+func listWorkspacesHandler(workspaces []Workspace) mcp.Handler {
+	return mcp.HandlerFunc(func(ctx context.Context, args map[string]any) (json.RawMessage, error) {
+		type workspace struct {
+			Name           string `json:"name"`
+			BaseURL        string `json:"base_url,omitempty"`
+			Default        bool   `json:"default"`
+			DefaultRoadmap string `json:"default_roadmap,omitempty"`
+		}
+		list := make([]workspace, len(workspaces))
+		for i, ws := range workspaces {
+			list[i] = workspace{Name: ws.Name, Default: i == 0, DefaultRoadmap: ws.DefaultRoadmap}
+			if ws.Client != nil {
+				list[i].BaseURL = ws.Client.BaseURL()
+			}
+		}
+		data, err := json.Marshal(list)
+		if err != nil {
+			return nil, err
+		}
+		return FormatList(data, "workspace")
+	})
+}